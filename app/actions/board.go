@@ -0,0 +1,150 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/gosimple/slug"
+)
+
+// CreateEditBoard is used to create a new board or edit existing
+type CreateEditBoard struct {
+	Slug                string   `route:"slug"`
+	Name                string   `json:"name"`
+	WelcomeMessage      string   `json:"welcomeMessage"`
+	DefaultTags         []string `json:"defaultTags"`
+	IsModerationEnabled bool     `json:"isModerationEnabled"`
+	IsPrivate           bool     `json:"isPrivate"`
+	Position            int      `json:"position"`
+	StaffIDs            []int    `json:"staffIds"`
+
+	Board *entity.Board
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditBoard) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *CreateEditBoard) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Slug != "" {
+		getBoard := &query.GetBoardBySlug{Slug: action.Slug}
+		if err := bus.Dispatch(ctx, getBoard); err != nil {
+			return validate.Error(err)
+		}
+		action.Board = getBoard.Result
+	}
+
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 60 {
+		result.AddFieldFailure("name", "Name must have less than 60 characters.")
+	} else if slug.Make(action.Name) == "" {
+		result.AddFieldFailure("name", "Name must contain at least one letter or number.")
+	} else {
+		getDuplicate := &query.GetBoardBySlug{Slug: slug.Make(action.Name)}
+		err := bus.Dispatch(ctx, getDuplicate)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err == nil && (action.Board == nil || action.Board.ID != getDuplicate.Result.ID) {
+			result.AddFieldFailure("name", "This board name is already in use.")
+		}
+	}
+
+	if action.DefaultTags == nil {
+		action.DefaultTags = []string{}
+	}
+	for _, tagSlug := range action.DefaultTags {
+		err := bus.Dispatch(ctx, &query.GetTagBySlug{Slug: tagSlug})
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("defaultTags", fmt.Sprintf("Tag '%s' does not exist.", tagSlug))
+		}
+	}
+
+	for _, userID := range action.StaffIDs {
+		getUser := &query.GetUserByID{UserID: userID}
+		err := bus.Dispatch(ctx, getUser)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil || !getUser.Result.IsCollaborator() {
+			result.AddFieldFailure("staffIds", "Only collaborators and administrators can be assigned to a board.")
+			break
+		}
+	}
+
+	return result
+}
+
+// DeleteBoard is used to delete an existing board
+type DeleteBoard struct {
+	Slug string `route:"slug"`
+
+	Board *entity.Board
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeleteBoard) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *DeleteBoard) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getBoard := &query.GetBoardBySlug{Slug: action.Slug}
+	if err := bus.Dispatch(ctx, getBoard); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Board = getBoard.Result
+	return validate.Success()
+}
+
+// MovePost is used to move a post to another board
+type MovePost struct {
+	Number    int    `route:"number"`
+	BoardSlug string `json:"board"`
+
+	Post  *entity.Post
+	Board *entity.Board
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *MovePost) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *MovePost) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	// An empty board removes the post from its current board
+	if action.BoardSlug != "" {
+		getBoard := &query.GetBoardBySlug{Slug: action.BoardSlug}
+		err := bus.Dispatch(ctx, getBoard)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("board", "Board does not exist.")
+		} else {
+			action.Board = getBoard.Result
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,108 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/rand"
+)
+
+func TestCreateEditBoard_InvalidName(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetBoardBySlug) error {
+		q.Result = &entity.Board{ID: 1, Slug: "it-services", Name: "IT Services"}
+		return nil
+	})
+
+	for _, name := range []string{
+		"",
+		"IT Services",
+		"---",
+		rand.String(61),
+	} {
+		action := &actions.CreateEditBoard{Name: name}
+		result := action.Validate(context.Background(), nil)
+		ExpectFailed(result, "name")
+	}
+}
+
+func TestCreateEditBoard_InvalidDefaultTagsAndStaff(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetBoardBySlug) error {
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if q.Slug == "bug" {
+			q.Result = &entity.Tag{ID: 1, Slug: "bug"}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == 1 {
+			q.Result = &entity.User{ID: 1, Role: enum.RoleCollaborator}
+			return nil
+		}
+		q.Result = &entity.User{ID: q.UserID, Role: enum.RoleVisitor}
+		return nil
+	})
+
+	action := &actions.CreateEditBoard{Name: "Facilities", DefaultTags: []string{"bug", "unknown"}, StaffIDs: []int{1, 2}}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "defaultTags", "staffIds")
+
+	action = &actions.CreateEditBoard{Name: "Facilities", DefaultTags: []string{"bug"}, StaffIDs: []int{1}}
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+}
+
+func TestCreateEditBoard_RenameExisting(t *testing.T) {
+	RegisterT(t)
+
+	board := &entity.Board{ID: 1, Slug: "it-services", Name: "IT Services"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetBoardBySlug) error {
+		if q.Slug == board.Slug {
+			q.Result = board
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := &actions.CreateEditBoard{Slug: "it-services", Name: "IT Services"}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Board).Equals(board)
+}
+
+func TestMovePost_UnknownBoard(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetBoardBySlug) error {
+		return app.ErrNotFound
+	})
+
+	action := &actions.MovePost{Number: 1, BoardSlug: "unknown"}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "board")
+
+	action = &actions.MovePost{Number: 1}
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Board).IsNil()
+}
@@ -0,0 +1,168 @@
+package actions
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+var customFieldKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// CreateEditCustomField is used to create a new custom field or edit existing
+type CreateEditCustomField struct {
+	Key        string               `route:"key"`
+	NewKey     string               `json:"key" format:"lower"`
+	Name       string               `json:"name"`
+	Type       enum.CustomFieldType `json:"type"`
+	Options    []string             `json:"options"`
+	IsRequired bool                 `json:"isRequired"`
+	IsPublic   bool                 `json:"isPublic"`
+	Position   int                  `json:"position"`
+
+	Field *entity.CustomField
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditCustomField) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *CreateEditCustomField) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Key != "" {
+		getField := &query.GetCustomFieldByKey{Key: action.Key}
+		if err := bus.Dispatch(ctx, getField); err != nil {
+			return validate.Error(err)
+		}
+		action.Field = getField.Result
+
+		// Key and type can't be changed once values have been stored
+		action.NewKey = action.Field.Key
+		action.Type = action.Field.Type
+	} else {
+		if action.NewKey == "" {
+			result.AddFieldFailure("key", "Key is required.")
+		} else if len(action.NewKey) > 50 {
+			result.AddFieldFailure("key", "Key must have less than 50 characters.")
+		} else if !customFieldKeyRegex.MatchString(action.NewKey) {
+			result.AddFieldFailure("key", "Key must start with a letter and contain only lowercase letters, numbers and underscores.")
+		} else {
+			getDuplicate := &query.GetCustomFieldByKey{Key: action.NewKey}
+			err := bus.Dispatch(ctx, getDuplicate)
+			if err != nil && errors.Cause(err) != app.ErrNotFound {
+				return validate.Error(err)
+			} else if err == nil {
+				result.AddFieldFailure("key", "This key is already in use.")
+			}
+		}
+
+		if action.Type.Name() == "unknown" {
+			result.AddFieldFailure("type", "Type is invalid.")
+		}
+	}
+
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 100 {
+		result.AddFieldFailure("name", "Name must have less than 100 characters.")
+	}
+
+	options := make([]string, 0, len(action.Options))
+	for _, option := range action.Options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		if strings.Contains(option, ",") {
+			result.AddFieldFailure("options", "Options cannot contain commas.")
+		}
+		options = append(options, option)
+	}
+	action.Options = options
+
+	if action.Type.HasOptions() && len(action.Options) == 0 {
+		result.AddFieldFailure("options", "At least one option is required.")
+	} else if !action.Type.HasOptions() {
+		action.Options = []string{}
+	}
+
+	return result
+}
+
+// DeleteCustomField is used to delete an existing custom field
+type DeleteCustomField struct {
+	Key string `route:"key"`
+
+	Field *entity.CustomField
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeleteCustomField) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *DeleteCustomField) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getField := &query.GetCustomFieldByKey{Key: action.Key}
+	if err := bus.Dispatch(ctx, getField); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Field = getField.Result
+	return validate.Success()
+}
+
+// validateCustomFieldValues checks values against the custom fields of current tenant and returns them normalized.
+// When isNew is true, required fields must be present; otherwise only the given values are checked.
+func validateCustomFieldValues(ctx context.Context, user *entity.User, result *validate.Result, values map[string]string, isNew bool) (map[string]string, error) {
+	getFields := &query.GetAllCustomFields{}
+	if err := bus.Dispatch(ctx, getFields); err != nil {
+		return nil, err
+	}
+
+	isCollaborator := user != nil && user.IsCollaborator()
+	fieldsByKey := make(map[string]*entity.CustomField, len(getFields.Result))
+	for _, field := range getFields.Result {
+		fieldsByKey[field.Key] = field
+	}
+
+	normalized := make(map[string]string, len(values))
+	for key, value := range values {
+		field, ok := fieldsByKey[key]
+		if !ok || (!field.IsPublic && !isCollaborator) {
+			result.AddFieldFailure("customFields."+key, i18n.T(ctx, "validation.invalid", i18n.Params{"name": key}))
+			continue
+		}
+
+		value = field.NormalizeValue(value)
+		if value == "" {
+			if field.IsRequired {
+				result.AddFieldFailure("customFields."+key, i18n.T(ctx, "validation.required", i18n.Params{"name": field.Name}))
+			}
+		} else if !field.IsValidValue(value) {
+			result.AddFieldFailure("customFields."+key, i18n.T(ctx, "validation.invalidvalue", i18n.Params{"name": field.Name, "value": value}))
+		}
+		normalized[key] = value
+	}
+
+	if isNew {
+		for _, field := range getFields.Result {
+			if _, ok := values[field.Key]; !ok && field.IsRequired && (field.IsPublic || isCollaborator) {
+				result.AddFieldFailure("customFields."+field.Key, i18n.T(ctx, "validation.required", i18n.Params{"name": field.Name}))
+			}
+		}
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,83 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestCreateEditCustomField_InvalidKey(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomFieldByKey) error {
+		if q.Key == "platform" {
+			q.Result = &entity.CustomField{ID: 1, Key: "platform"}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	for _, key := range []string{
+		"",
+		"1st_field",
+		"has-dash",
+		"platform",
+	} {
+		action := &actions.CreateEditCustomField{NewKey: key, Name: "Field", Type: enum.CustomFieldText}
+		result := action.Validate(context.Background(), nil)
+		ExpectFailed(result, "key")
+	}
+}
+
+func TestCreateEditCustomField_SelectRequiresOptions(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomFieldByKey) error {
+		return app.ErrNotFound
+	})
+
+	action := &actions.CreateEditCustomField{NewKey: "platform", Name: "Platform", Type: enum.CustomFieldSelect, Options: []string{" "}}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "options")
+
+	action = &actions.CreateEditCustomField{NewKey: "platform", Name: "Platform", Type: enum.CustomFieldSelect, Options: []string{" iOS ", "Android"}}
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Options).Equals([]string{"iOS", "Android"})
+}
+
+func TestCreateNewPost_CustomFields(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error {
+		q.Result = []*entity.CustomField{
+			{Key: "platform", Name: "Platform", Type: enum.CustomFieldMultiSelect, Options: []string{"ios", "android"}, IsRequired: true, IsPublic: true},
+			{Key: "severity", Name: "Severity", Type: enum.CustomFieldNumber, IsPublic: true},
+		}
+		return nil
+	})
+
+	action := &actions.CreateNewPost{Title: "this is my new post"}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "customFields.platform")
+
+	action = &actions.CreateNewPost{Title: "this is my new post", CustomFields: map[string]string{"platform": "ios", "severity": "high", "unknown": "1"}}
+	result = action.Validate(context.Background(), nil)
+	ExpectFailed(result, "customFields.severity", "customFields.unknown")
+
+	action = &actions.CreateNewPost{Title: "this is my new post", CustomFields: map[string]string{"platform": " ios, android ", "severity": "3"}}
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.CustomFields).Equals(map[string]string{"platform": "ios,android", "severity": "3"})
+}
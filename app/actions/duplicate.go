@@ -0,0 +1,33 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// DismissPostDuplicateCandidate is used to hide a pair of posts that are not duplicates from the report
+type DismissPostDuplicateCandidate struct {
+	ID int `route:"id"`
+
+	Candidate *entity.PostDuplicateCandidate
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DismissPostDuplicateCandidate) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *DismissPostDuplicateCandidate) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getCandidate := &query.GetPostDuplicateCandidateByID{CandidateID: action.ID}
+	if err := bus.Dispatch(ctx, getCandidate); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Candidate = getCandidate.Result
+	return validate.Success()
+}
@@ -0,0 +1,49 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestDismissPostDuplicateCandidate_IsAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.DismissPostDuplicateCandidate{ID: 1}
+	Expect(action.IsAuthorized(context.Background(), nil)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleVisitor})).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleCollaborator})).IsTrue()
+}
+
+func TestDismissPostDuplicateCandidate_NotFound(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostDuplicateCandidateByID) error {
+		return app.ErrNotFound
+	})
+
+	action := &actions.DismissPostDuplicateCandidate{ID: 99}
+	result := action.Validate(context.Background(), nil)
+	Expect(result.Err).Equals(app.ErrNotFound)
+}
+
+func TestDismissPostDuplicateCandidate_Valid(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostDuplicateCandidateByID) error {
+		q.Result = &entity.PostDuplicateCandidate{ID: q.CandidateID, Score: 0.8}
+		return nil
+	})
+
+	action := &actions.DismissPostDuplicateCandidate{ID: 5}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Candidate.ID).Equals(5)
+}
@@ -0,0 +1,194 @@
+package actions
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// MaxPollOptions is the maximum number of options a poll can have
+const MaxPollOptions = 10
+
+// AddNewPoll is used to ask a poll on a post or on a staff comment
+type AddNewPoll struct {
+	Number           int        `route:"number"`
+	CommentID        int        `json:"commentId"`
+	Question         string     `json:"question"`
+	Options          []string   `json:"options"`
+	IsMultipleChoice bool       `json:"isMultipleChoice"`
+	IsVotersOnly     bool       `json:"isVotersOnly"`
+	ClosesAt         *time.Time `json:"closesAt"`
+
+	Post    *entity.Post
+	Comment *entity.Comment
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *AddNewPoll) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *AddNewPoll) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	if action.CommentID > 0 {
+		getComment := &query.GetCommentByID{CommentID: action.CommentID}
+		getCommentPostID := &query.GetCommentPostID{CommentID: action.CommentID}
+		if err := bus.Dispatch(ctx, getComment, getCommentPostID); err != nil {
+			return validate.Error(err)
+		}
+		if getCommentPostID.Result != action.Post.ID {
+			result.AddFieldFailure("commentId", "Comment not found.")
+		} else if getComment.Result.User == nil || !getComment.Result.User.IsCollaborator() {
+			result.AddFieldFailure("commentId", "Polls can only be added to comments from staff.")
+		}
+		action.Comment = getComment.Result
+	}
+
+	action.Question = strings.TrimSpace(action.Question)
+	if action.Question == "" {
+		result.AddFieldFailure("question", "Question is required.")
+	} else if len(action.Question) > 200 {
+		result.AddFieldFailure("question", "Question must have less than 200 characters.")
+	}
+
+	options := make([]string, 0, len(action.Options))
+	seen := make(map[string]bool, len(action.Options))
+	for _, option := range action.Options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		if len(option) > 100 {
+			result.AddFieldFailure("options", "Options must have less than 100 characters.")
+			break
+		}
+		if seen[strings.ToLower(option)] {
+			result.AddFieldFailure("options", "Options must be unique.")
+			break
+		}
+		seen[strings.ToLower(option)] = true
+		options = append(options, option)
+	}
+	action.Options = options
+
+	if len(action.Options) < 2 {
+		result.AddFieldFailure("options", "A poll needs at least 2 options.")
+	} else if len(action.Options) > MaxPollOptions {
+		result.AddFieldFailure("options", "A poll can't have more than 10 options.")
+	}
+
+	if action.ClosesAt != nil && !action.ClosesAt.After(time.Now()) {
+		result.AddFieldFailure("closesAt", "Closing time must be in the future.")
+	}
+
+	return result
+}
+
+// AnswerPoll is used to answer a poll, replacing previous answers of current user
+type AnswerPoll struct {
+	Number  int   `route:"number"`
+	ID      int   `route:"id"`
+	Options []int `json:"options"`
+
+	Poll *entity.Poll
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *AnswerPoll) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *AnswerPoll) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	getPoll := &query.GetPollByID{PollID: action.ID}
+	if err := bus.Dispatch(ctx, getPost, getPoll); err != nil {
+		return validate.Error(err)
+	}
+	if getPoll.Result.PostID != getPost.Result.ID {
+		return validate.Error(app.ErrNotFound)
+	}
+	action.Poll = getPoll.Result
+
+	if getPost.Result.IsLocked() {
+		return validate.Failed(i18n.T(ctx, "validation.custom.postlocked"))
+	}
+
+	if action.Poll.IsClosed(time.Now()) {
+		return validate.Failed(i18n.T(ctx, "validation.custom.pollclosed"))
+	}
+
+	if action.Poll.IsVotersOnly && !getPost.Result.HasVoted {
+		return validate.Failed(i18n.T(ctx, "validation.custom.pollvotersonly"))
+	}
+
+	options := make([]int, 0, len(action.Options))
+	seen := make(map[int]bool, len(action.Options))
+	for _, optionID := range action.Options {
+		if !seen[optionID] {
+			seen[optionID] = true
+			options = append(options, optionID)
+		}
+	}
+	action.Options = options
+
+	if len(action.Options) == 0 {
+		result.AddFieldFailure("options", propertyIsRequired(ctx, "answer"))
+	} else if len(action.Options) > 1 && !action.Poll.IsMultipleChoice {
+		result.AddFieldFailure("options", propertyIsInvalid(ctx, "answer"))
+	}
+
+	for _, optionID := range action.Options {
+		if !action.Poll.HasOption(optionID) {
+			result.AddFieldFailure("options", propertyIsInvalid(ctx, "answer"))
+			break
+		}
+	}
+
+	return result
+}
+
+// DeletePoll is used to delete a poll and all of its answers
+type DeletePoll struct {
+	Number int `route:"number"`
+	ID     int `route:"id"`
+
+	Poll *entity.Poll
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeletePoll) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *DeletePoll) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	getPoll := &query.GetPollByID{PollID: action.ID}
+	if err := bus.Dispatch(ctx, getPost, getPoll); err != nil {
+		return validate.Error(err)
+	}
+	if getPoll.Result.PostID != getPost.Result.ID {
+		return validate.Error(app.ErrNotFound)
+	}
+	action.Poll = getPoll.Result
+
+	return validate.Success()
+}
@@ -0,0 +1,103 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestAddNewPoll_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1, Title: "Post 1"}
+		return nil
+	})
+
+	past := time.Now().Add(-1 * time.Hour)
+	testCases := []struct {
+		field  string
+		action *actions.AddNewPoll
+	}{
+		{"question", &actions.AddNewPoll{Number: 1, Question: "", Options: []string{"Yes", "No"}}},
+		{"options", &actions.AddNewPoll{Number: 1, Question: "Do you like it?", Options: []string{"Yes"}}},
+		{"options", &actions.AddNewPoll{Number: 1, Question: "Do you like it?", Options: []string{"Yes", " ", ""}}},
+		{"options", &actions.AddNewPoll{Number: 1, Question: "Do you like it?", Options: []string{"Yes", "yes "}}},
+		{"options", &actions.AddNewPoll{Number: 1, Question: "Do you like it?", Options: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}}},
+		{"closesAt", &actions.AddNewPoll{Number: 1, Question: "Do you like it?", Options: []string{"Yes", "No"}, ClosesAt: &past}},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), nil)
+		ExpectFailed(result, testCase.field)
+	}
+}
+
+func TestAddNewPoll_Valid(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1, Title: "Post 1"}
+		return nil
+	})
+
+	action := &actions.AddNewPoll{Number: 1, Question: " Do you like it? ", Options: []string{" Yes", "", "No "}}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Question).Equals("Do you like it?")
+	Expect(action.Options).Equals([]string{"Yes", "No"})
+}
+
+func TestAnswerPoll_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	closedAt := time.Now().Add(-1 * time.Hour)
+	polls := map[int]*entity.Poll{
+		1: {ID: 1, PostID: 1, Options: []*entity.PollOption{{ID: 10}, {ID: 11}}},
+		2: {ID: 2, PostID: 1, ClosesAt: &closedAt, Options: []*entity.PollOption{{ID: 20}, {ID: 21}}},
+		3: {ID: 3, PostID: 1, IsVotersOnly: true, Options: []*entity.PollOption{{ID: 30}, {ID: 31}}},
+		4: {ID: 4, PostID: 2, Options: []*entity.PollOption{{ID: 40}, {ID: 41}}},
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1, Title: "Post 1", HasVoted: false}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPollByID) error {
+		q.Result = polls[q.PollID]
+		return nil
+	})
+
+	user := &entity.User{ID: 2, Role: enum.RoleVisitor}
+	testCases := []struct {
+		field  string
+		action *actions.AnswerPoll
+	}{
+		{"options", &actions.AnswerPoll{Number: 1, ID: 1, Options: []int{}}},
+		{"options", &actions.AnswerPoll{Number: 1, ID: 1, Options: []int{10, 11}}},
+		{"options", &actions.AnswerPoll{Number: 1, ID: 1, Options: []int{20}}},
+		{"", &actions.AnswerPoll{Number: 1, ID: 2, Options: []int{20}}},
+		{"", &actions.AnswerPoll{Number: 1, ID: 3, Options: []int{30}}},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), user)
+		ExpectFailed(result, testCase.field)
+	}
+
+	result := (&actions.AnswerPoll{Number: 1, ID: 4, Options: []int{40}}).Validate(context.Background(), user)
+	Expect(result.Err).Equals(app.ErrNotFound)
+
+	action := &actions.AnswerPoll{Number: 1, ID: 1, Options: []int{11, 11}}
+	result = action.Validate(context.Background(), user)
+	ExpectSuccess(result)
+	Expect(action.Options).Equals([]int{11})
+}
@@ -2,7 +2,9 @@ package actions
 
 import (
 	"context"
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,12 +25,18 @@ import (
 
 // CreateNewPost is used to create a new post
 type CreateNewPost struct {
-	Title       string             `json:"title"`
-	Description string             `json:"description"`
-	TagSlugs    []string           `json:"tags"`
-	Attachments []*dto.ImageUpload `json:"attachments"`
-
-	Tags []*entity.Tag
+	Title        string             `json:"title"`
+	Description  string             `json:"description"`
+	TagSlugs     []string           `json:"tags"`
+	Attachments  []*dto.ImageUpload `json:"attachments"`
+	CustomFields map[string]string  `json:"customFields"`
+	BoardSlug    string             `json:"board"`
+	IsAnonymous  bool               `json:"isAnonymous"`
+	TemplateID   int                `json:"templateId"`
+
+	Tags     []*entity.Tag
+	Board    *entity.Board
+	Template *entity.PostTemplate
 }
 
 // OnPreExecute prefetches Tags for later use
@@ -107,15 +115,65 @@ func (action *CreateNewPost) Validate(ctx context.Context, user *entity.User) *v
 	}
 	result.AddFieldFailure("attachments", messages...)
 
+	action.CustomFields, err = validateCustomFieldValues(ctx, user, result, action.CustomFields, true)
+	if err != nil {
+		return validate.Error(err)
+	}
+
+	if action.TemplateID > 0 {
+		getTemplate := &query.GetPostTemplateByID{TemplateID: action.TemplateID}
+		err := bus.Dispatch(ctx, getTemplate)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("templateId", propertyIsInvalid(ctx, "template"))
+		} else {
+			action.Template = getTemplate.Result
+
+			// Templates of a board are only offered on that board, posts without a board are moved to it
+			if action.Template.BoardSlug != "" {
+				if action.BoardSlug == "" {
+					action.BoardSlug = action.Template.BoardSlug
+				} else if action.BoardSlug != action.Template.BoardSlug {
+					result.AddFieldFailure("templateId", propertyIsInvalid(ctx, "template"))
+				}
+			}
+
+			for _, section := range action.Template.EmptySections(action.Description) {
+				result.AddFieldFailure("description", i18n.T(ctx, "validation.custom.emptysection", i18n.Params{"name": section}))
+			}
+		}
+	}
+
+	if action.BoardSlug != "" {
+		getBoard := &query.GetBoardBySlug{Slug: action.BoardSlug}
+		err := bus.Dispatch(ctx, getBoard)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("board", propertyIsInvalid(ctx, "board"))
+		} else {
+			action.Board = getBoard.Result
+		}
+	}
+
+	if action.IsAnonymous {
+		tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+		if !ok || !tenant.IsAnonymousPostingEnabled {
+			result.AddFieldFailure("isAnonymous", i18n.T(ctx, "validation.custom.anonymouspostingdisabled"))
+		}
+	}
+
 	return result
 }
 
 // UpdatePost is used to edit an existing new post
 type UpdatePost struct {
-	Number      int                `route:"number"`
-	Title       string             `json:"title"`
-	Description string             `json:"description"`
-	Attachments []*dto.ImageUpload `json:"attachments"`
+	Number       int                `route:"number"`
+	Title        string             `json:"title"`
+	Description  string             `json:"description"`
+	Attachments  []*dto.ImageUpload `json:"attachments"`
+	CustomFields map[string]string  `json:"customFields"`
 
 	Post *entity.Post
 }
@@ -137,6 +195,11 @@ func (input *UpdatePost) IsAuthorized(ctx context.Context, user *entity.User) bo
 		return true
 	}
 
+	// Co-authors were explicitly granted edit rights by staff, so they aren't bound to the edit window
+	if input.Post.IsCoAuthor(user.ID) {
+		return true
+	}
+
 	timeAgo := time.Now().UTC().Sub(input.Post.CreatedAt)
 	return input.Post.User.ID == user.ID && timeAgo <= 1*time.Hour
 }
@@ -187,6 +250,14 @@ func (action *UpdatePost) Validate(ctx context.Context, user *entity.User) *vali
 		result.AddFieldFailure("attachments", messages...)
 	}
 
+	if action.CustomFields != nil {
+		customFields, err := validateCustomFieldValues(ctx, user, result, action.CustomFields, false)
+		if err != nil {
+			return validate.Error(err)
+		}
+		action.CustomFields = customFields
+	}
+
 	return result
 }
 
@@ -206,31 +277,81 @@ func (action *ToggleCommentReaction) Validate(ctx context.Context, user *entity.
 
 	result := validate.Success()
 
-	allowedEmojis := []string{"👍", "👎", "😄", "🎉", "😕", "❤️", "🚀", "👀"}
-	isAllowed := false
-	for _, emoji := range allowedEmojis {
-		if action.Reaction == emoji {
-			isAllowed = true
-			break
-		}
+	if !isReactionAllowed(ctx, action.Reaction) {
+		result.AddFieldFailure("reaction", i18n.T(ctx, "validation.custom.invalidemoji"))
+		return result
+	}
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	if getPost.Result.IsLocked() {
+		return validate.Failed(i18n.T(ctx, "validation.custom.postlocked"))
 	}
 
-	if !isAllowed {
+	return result
+}
+
+// TogglePostReaction adds or removes a reaction on a post
+type TogglePostReaction struct {
+	Number   int    `route:"number"`
+	Reaction string `route:"reaction"`
+
+	Post *entity.Post
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *TogglePostReaction) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *TogglePostReaction) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if !isReactionAllowed(ctx, action.Reaction) {
 		result.AddFieldFailure("reaction", i18n.T(ctx, "validation.custom.invalidemoji"))
+		return result
 	}
 
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	if getPost.Result.IsLocked() {
+		return validate.Failed(i18n.T(ctx, "validation.custom.postlocked"))
+	}
+	action.Post = getPost.Result
+
 	return result
 }
 
+// isReactionAllowed returns true if current tenant allows reacting with given emoji
+func isReactionAllowed(ctx context.Context, emoji string) bool {
+	tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	if !ok || tenant == nil {
+		return (&entity.Tenant{}).IsReactionAllowed(emoji)
+	}
+	return tenant.IsReactionAllowed(emoji)
+}
+
 // AddNewComment represents a new comment to be added
 type AddNewComment struct {
 	Number      int                `route:"number"`
 	Content     string             `json:"content"`
 	Attachments []*dto.ImageUpload `json:"attachments"`
+	ParentID    int                `json:"parentId"`
+	IsInternal  bool               `json:"isInternal"`
+
+	Parent *entity.Comment
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *AddNewComment) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	if action.IsInternal {
+		return user != nil && user.IsCollaborator()
+	}
 	return user != nil
 }
 
@@ -254,9 +375,76 @@ func (action *AddNewComment) Validate(ctx context.Context, user *entity.User) *v
 	}
 	result.AddFieldFailure("attachments", messages...)
 
+	isStaff := user != nil && user.IsCollaborator()
+	if !isStaff || action.ParentID > 0 {
+		getPost := &query.GetPostByNumber{Number: action.Number}
+		if err := bus.Dispatch(ctx, getPost); err != nil {
+			return validate.Error(err)
+		}
+
+		// Staff can still explain on a locked post why the discussion is over
+		if !isStaff && getPost.Result.IsLocked() {
+			return validate.Failed(i18n.T(ctx, "validation.custom.postlocked"))
+		}
+
+		if action.ParentID > 0 {
+			parent, messages, err := validateReplyParent(ctx, getPost.Result, action.ParentID)
+			if err != nil {
+				return validate.Error(err)
+			}
+			result.AddFieldFailure("parentId", messages...)
+			action.Parent = parent
+
+			// Replies to an internal note stay internal
+			if parent != nil && parent.IsInternal {
+				action.IsInternal = true
+			}
+		}
+	}
+
 	return result
 }
 
+// validateReplyParent returns the comment being replied to, as long as it belongs to given post
+// and the reply doesn't go deeper than the configured depth
+func validateReplyParent(ctx context.Context, post *entity.Post, parentID int) (*entity.Comment, []string, error) {
+	getPostID := &query.GetCommentPostID{CommentID: parentID}
+	err := bus.Dispatch(ctx, getPostID)
+	if err != nil && errors.Cause(err) != app.ErrNotFound {
+		return nil, nil, err
+	}
+	if getPostID.Result != post.ID {
+		return nil, []string{i18n.T(ctx, "validation.custom.commentnotfound")}, nil
+	}
+
+	getParent := &query.GetCommentByID{CommentID: parentID}
+	if err := bus.Dispatch(ctx, getParent); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, []string{i18n.T(ctx, "validation.custom.commentnotfound")}, nil
+		}
+		return nil, nil, err
+	}
+
+	depth := 1
+	for ancestor := getParent.Result; ancestor.ParentID > 0; depth++ {
+		getAncestor := &query.GetCommentByID{CommentID: ancestor.ParentID}
+		if err := bus.Dispatch(ctx, getAncestor); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				// Replies to a deleted comment stay at their depth
+				depth++
+				break
+			}
+			return nil, nil, err
+		}
+		ancestor = getAncestor.Result
+	}
+
+	if depth > env.Config.CommentReplyMaxDepth {
+		return nil, []string{i18n.T(ctx, "validation.custom.replytoodeep", i18n.Params{"depth": env.Config.CommentReplyMaxDepth})}, nil
+	}
+	return getParent.Result, nil, nil
+}
+
 // SetResponse represents the action to update an post response
 type SetResponse struct {
 	Number         int             `route:"number"`
@@ -309,6 +497,82 @@ func (action *SetResponse) Validate(ctx context.Context, user *entity.User) *val
 	return result
 }
 
+// ScheduleResponse represents the action to schedule a post response for a future time or save it as a draft
+type ScheduleResponse struct {
+	Number      int             `route:"number"`
+	Status      enum.PostStatus `json:"status"`
+	Text        string          `json:"text"`
+	ScheduledAt *time.Time      `json:"scheduledAt"`
+
+	Post *entity.Post
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *ScheduleResponse) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *ScheduleResponse) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	// Duplicates require an original post, which may not exist anymore when the response is applied
+	if action.Status < enum.PostOpen || action.Status >= enum.PostDuplicate {
+		result.AddFieldFailure("status", propertyIsInvalid(ctx, "status"))
+	}
+
+	if action.ScheduledAt != nil && !action.ScheduledAt.After(time.Now()) {
+		result.AddFieldFailure("scheduledAt", "Scheduled time must be in the future.")
+	}
+
+	if action.Text != "" {
+		if ok, _ := profanity.ContainsProfanity(action.Text); ok {
+			result.AddFieldFailure("text", i18n.T(ctx, "validation.custom.profanity"))
+		}
+	}
+
+	return result
+}
+
+// CancelScheduledResponse represents the action to cancel a pending scheduled response or discard a draft
+type CancelScheduledResponse struct {
+	Number int `route:"number"`
+	ID     int `route:"id"`
+
+	Response *entity.ScheduledResponse
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CancelScheduledResponse) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *CancelScheduledResponse) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+
+	getResponse := &query.GetScheduledResponseByID{ID: action.ID}
+	if err := bus.Dispatch(ctx, getResponse); err != nil {
+		return validate.Error(err)
+	}
+
+	if getResponse.Result.PostID != getPost.Result.ID {
+		return validate.Error(app.ErrNotFound)
+	}
+	action.Response = getResponse.Result
+
+	return validate.Success()
+}
+
 // DeletePost represents the action of an administrator deleting an existing Post (sets status to deleted)
 type DeletePost struct {
 	Number int    `route:"number"`
@@ -343,6 +607,182 @@ func (action *DeletePost) Validate(ctx context.Context, user *entity.User) *vali
 	return validate.Success()
 }
 
+// RevealPostAuthor represents the action of an administrator finding out who wrote an anonymous post
+type RevealPostAuthor struct {
+	Number int    `route:"number"`
+	Reason string `json:"reason"`
+
+	Post *entity.Post
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action (administrators only)
+func (action *RevealPostAuthor) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *RevealPostAuthor) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	if !action.Post.IsAnonymous {
+		return validate.Failed("This post is not anonymous.")
+	}
+
+	action.Reason = strings.TrimSpace(action.Reason)
+	if action.Reason == "" {
+		result.AddFieldFailure("reason", "A reason is required to reveal the author.")
+	} else if len(action.Reason) > 500 {
+		result.AddFieldFailure("reason", "Reason must have less than 500 characters.")
+	}
+
+	return result
+}
+
+// MaxBulkPosts is the maximum number of posts that can be changed by a single bulk operation
+const MaxBulkPosts = 500
+
+// BulkPostFilter selects posts the same way the post search does
+type BulkPostFilter struct {
+	Query    string   `json:"query"`
+	View     string   `json:"view"`
+	Statuses []string `json:"statuses"`
+	Tags     []string `json:"tags"`
+	NoTags   bool     `json:"notags"`
+	Board    string   `json:"board"`
+}
+
+// BulkUpdatePosts represents the action of a staff member changing many posts at once
+type BulkUpdatePosts struct {
+	Numbers   []int           `json:"numbers"`
+	Filter    *BulkPostFilter `json:"filter"`
+	Operation string          `json:"operation"`
+	Status    enum.PostStatus `json:"status"`
+	Text      string          `json:"text"`
+	TagSlug   string          `json:"tag"`
+	BoardSlug string          `json:"board"`
+
+	Posts  []*entity.Post
+	Change *dto.BulkPostChange
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *BulkUpdatePosts) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	if action.Operation == dto.BulkPostDelete {
+		return user != nil && user.IsAdministrator()
+	}
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *BulkUpdatePosts) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	action.Change = &dto.BulkPostChange{Operation: action.Operation}
+	switch action.Operation {
+	case dto.BulkPostRespond:
+		// Duplicates need an original post, so they have to be marked one at a time
+		if action.Status < enum.PostOpen || action.Status >= enum.PostDuplicate {
+			result.AddFieldFailure("status", "Status is invalid.")
+		}
+		if action.Text != "" {
+			if ok, _ := profanity.ContainsProfanity(action.Text); ok {
+				result.AddFieldFailure("text", i18n.T(ctx, "validation.custom.profanity"))
+			}
+		}
+		action.Change.Status = action.Status
+		action.Change.Text = action.Text
+	case dto.BulkPostAddTag, dto.BulkPostRemoveTag:
+		getTag := &query.GetTagBySlug{Slug: action.TagSlug}
+		err := bus.Dispatch(ctx, getTag)
+		if err != nil && errors.Cause(err) == app.ErrNotFound {
+			result.AddFieldFailure("tag", "Tag not found.")
+		} else if err != nil {
+			return validate.Error(err)
+		}
+		action.Change.Tag = getTag.Result
+	case dto.BulkPostMove:
+		getBoard := &query.GetBoardBySlug{Slug: action.BoardSlug}
+		err := bus.Dispatch(ctx, getBoard)
+		if err != nil && errors.Cause(err) == app.ErrNotFound {
+			result.AddFieldFailure("board", "Board not found.")
+		} else if err != nil {
+			return validate.Error(err)
+		}
+		action.Change.Board = getBoard.Result
+	case dto.BulkPostDelete:
+		action.Change.Status = enum.PostDeleted
+		action.Change.Text = action.Text
+	default:
+		result.AddFieldFailure("operation", "Operation is invalid.")
+	}
+
+	if !result.Ok {
+		return result
+	}
+
+	if (len(action.Numbers) == 0) == (action.Filter == nil) {
+		result.AddFieldFailure("numbers", "Either a list of posts or a filter is required.")
+		return result
+	}
+
+	if action.Filter != nil {
+		searchPosts := &query.SearchPosts{
+			Query:      action.Filter.Query,
+			View:       action.Filter.View,
+			Limit:      strconv.Itoa(MaxBulkPosts + 1),
+			Tags:       action.Filter.Tags,
+			NoTagsOnly: action.Filter.NoTags,
+			Board:      action.Filter.Board,
+		}
+		if searchPosts.View == "" {
+			searchPosts.View = "all"
+		}
+		searchPosts.SetStatusesFromStrings(action.Filter.Statuses)
+		if err := bus.Dispatch(ctx, searchPosts); err != nil {
+			return validate.Error(err)
+		}
+		action.Posts = searchPosts.Result
+	} else if len(action.Numbers) <= MaxBulkPosts {
+		for _, number := range action.Numbers {
+			getPost := &query.GetPostByNumber{Number: number}
+			err := bus.Dispatch(ctx, getPost)
+			if err != nil && errors.Cause(err) == app.ErrNotFound {
+				result.AddFieldFailure("numbers", fmt.Sprintf("Post #%d not found.", number))
+				continue
+			} else if err != nil {
+				return validate.Error(err)
+			}
+			action.Posts = append(action.Posts, getPost.Result)
+		}
+	}
+
+	if len(action.Numbers) > MaxBulkPosts || len(action.Posts) > MaxBulkPosts {
+		result.AddFieldFailure("numbers", fmt.Sprintf("At most %d posts can be changed at once.", MaxBulkPosts))
+	} else if result.Ok && len(action.Posts) == 0 {
+		result.AddFieldFailure("numbers", "No posts match the given filter.")
+	}
+
+	if result.Ok && action.Operation == dto.BulkPostDelete {
+		for _, post := range action.Posts {
+			isReferenced := &query.PostIsReferenced{PostID: post.ID}
+			if err := bus.Dispatch(ctx, isReferenced); err != nil {
+				return validate.Error(err)
+			}
+			if isReferenced.Result {
+				result.AddFieldFailure("numbers", fmt.Sprintf("Post #%d has duplicates and cannot be deleted.", post.Number))
+			}
+		}
+	}
+
+	return result
+}
+
 // EditComment represents the action to update an existing comment
 type EditComment struct {
 	PostNumber  int                `route:"number"`
@@ -446,6 +886,40 @@ func (action *SetPostPinned) Validate(ctx context.Context, user *entity.User) *v
 	return validate.Success()
 }
 
+// SetPostLocked represents the action of locking or unlocking a post (staff only)
+type SetPostLocked struct {
+	Number int    `route:"number"`
+	Locked bool   `json:"locked"`
+	Reason string `json:"reason"`
+
+	Post *entity.Post
+}
+
+// IsAuthorized returns true if current user is a collaborator
+func (action *SetPostLocked) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate ensures the post exists and the reason isn't too long
+func (action *SetPostLocked) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	action.Reason = strings.TrimSpace(action.Reason)
+	if !action.Locked {
+		action.Reason = ""
+	} else if len(action.Reason) > 500 {
+		result.AddFieldFailure("reason", "Reason must have less than 500 characters.")
+	}
+
+	return result
+}
+
 // SetCommentPinned represents the action of pinning or unpinning a comment (moderators only)
 type SetCommentPinned struct {
 	PostNumber int  `route:"number"`
@@ -474,6 +948,63 @@ func (action *SetCommentPinned) Validate(ctx context.Context, user *entity.User)
 	return validate.Success()
 }
 
+// SetAcceptedAnswer represents the action of marking a comment as the accepted answer of a post (staff or post author)
+type SetAcceptedAnswer struct {
+	PostNumber int  `route:"number"`
+	CommentID  int  `route:"id"`
+	Accepted   bool `json:"accepted"`
+
+	Post    *entity.Post
+	Comment *entity.Comment
+}
+
+// OnPreExecute prefetches Post for later use
+func (action *SetAcceptedAnswer) OnPreExecute(ctx context.Context) error {
+	getPost := &query.GetPostByNumber{Number: action.PostNumber}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return err
+	}
+
+	action.Post = getPost.Result
+	return nil
+}
+
+// IsAuthorized returns true if current user is a collaborator or the author of the post
+func (action *SetAcceptedAnswer) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	if user == nil {
+		return false
+	}
+	return user.IsCollaborator() || (action.Post.User != nil && action.Post.User.ID == user.ID)
+}
+
+// Validate ensures the comment belongs to the post and is visible to everyone
+func (action *SetAcceptedAnswer) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getCommentPostID := &query.GetCommentPostID{CommentID: action.CommentID}
+	err := bus.Dispatch(ctx, getCommentPostID)
+	if err != nil && errors.Cause(err) != app.ErrNotFound {
+		return validate.Error(err)
+	}
+	if getCommentPostID.Result != action.Post.ID {
+		return validate.Failed(i18n.T(ctx, "validation.custom.commentnotfound"))
+	}
+
+	if action.Accepted {
+		getComment := &query.GetCommentByID{CommentID: action.CommentID}
+		if err := bus.Dispatch(ctx, getComment); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return validate.Failed(i18n.T(ctx, "validation.custom.commentnotfound"))
+			}
+			return validate.Error(err)
+		}
+		if !getComment.Result.IsApproved || getComment.Result.IsInternal {
+			return validate.Failed(i18n.T(ctx, "validation.custom.answernotpublic"))
+		}
+		action.Comment = getComment.Result
+	}
+
+	return validate.Success()
+}
+
 // DeleteComment represents the action of deleting an existing comment
 type DeleteComment struct {
 	PostNumber int `route:"number"`
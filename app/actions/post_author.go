@@ -0,0 +1,148 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// ChangePostOwner is used to transfer the ownership of a post to another user
+type ChangePostOwner struct {
+	Number int `route:"number"`
+	UserID int `json:"userId"`
+
+	Post *entity.Post
+	User *entity.User
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *ChangePostOwner) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *ChangePostOwner) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	post, owner, author, result := getPostAndAuthor(ctx, user, action.Number, action.UserID)
+	if !result.Ok {
+		return result
+	}
+
+	if owner.ID == author.ID {
+		result.AddFieldFailure("userId", "This user already owns this post.")
+	}
+
+	action.Post, action.User = post, author
+	return result
+}
+
+// AddPostCoAuthor is used to let another user edit a post and receive its notifications
+type AddPostCoAuthor struct {
+	Number int `route:"number"`
+	UserID int `json:"userId"`
+
+	Post *entity.Post
+	User *entity.User
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *AddPostCoAuthor) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *AddPostCoAuthor) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	post, owner, author, result := getPostAndAuthor(ctx, user, action.Number, action.UserID)
+	if !result.Ok {
+		return result
+	}
+
+	if owner.ID == author.ID {
+		result.AddFieldFailure("userId", "The owner of a post can't be a co-author.")
+	}
+
+	action.Post, action.User = post, author
+	return result
+}
+
+// RemovePostCoAuthor is used to remove a co-author from a post
+type RemovePostCoAuthor struct {
+	Number int `route:"number"`
+	UserID int `route:"userID"`
+
+	Post *entity.Post
+	User *entity.User
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *RemovePostCoAuthor) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *RemovePostCoAuthor) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+
+	if !canChangeAuthors(getPost.Result, user) {
+		return validate.Failed(anonymousAuthorsMessage)
+	}
+
+	if !getPost.Result.IsCoAuthor(action.UserID) {
+		return validate.Error(app.ErrNotFound)
+	}
+
+	getUser := &query.GetUserByID{UserID: action.UserID}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Post, action.User = getPost.Result, getUser.Result
+	return validate.Success()
+}
+
+// anonymousAuthorsMessage is the only failure given on anonymous posts to those who can't see their author,
+// so that it can't be used to find out who the author is
+const anonymousAuthorsMessage = "Only administrators can change the authors of an anonymous post."
+
+// canChangeAuthors returns true if given user can change the owner and co-authors of given post
+func canChangeAuthors(post *entity.Post, user *entity.User) bool {
+	return !post.IsAnonymous || (user != nil && user.IsAdministrator())
+}
+
+// getPostAndAuthor loads the post, its real owner (even when the post is anonymous) and the user being assigned to it
+func getPostAndAuthor(ctx context.Context, user *entity.User, number, userID int) (*entity.Post, *entity.User, *entity.User, *validate.Result) {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return nil, nil, nil, validate.Error(err)
+	}
+
+	if !canChangeAuthors(getPost.Result, user) {
+		return nil, nil, nil, validate.Failed(anonymousAuthorsMessage)
+	}
+
+	getOwner := &query.GetPostAuthor{PostID: getPost.Result.ID}
+	if err := bus.Dispatch(ctx, getOwner); err != nil {
+		return nil, nil, nil, validate.Error(err)
+	}
+
+	getUser := &query.GetUserByID{UserID: userID}
+	err := bus.Dispatch(ctx, getUser)
+	if err != nil && errors.Cause(err) != app.ErrNotFound {
+		return nil, nil, nil, validate.Error(err)
+	} else if err != nil || getUser.Result.Status != enum.UserActive {
+		result.AddFieldFailure("userId", "User not found.")
+		return nil, nil, nil, result
+	}
+
+	return getPost.Result, getOwner.Result, getUser.Result, result
+}
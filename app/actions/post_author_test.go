@@ -0,0 +1,136 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func mockPostAuthorQueries() {
+	owner := &entity.User{ID: 1, Name: "Jon Snow", Status: enum.UserActive}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 10, Number: q.Number, User: owner, CoAuthorIDs: []int{3}}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostAuthor) error {
+		q.Result = owner
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		switch q.UserID {
+		case 1:
+			q.Result = owner
+		case 2:
+			q.Result = &entity.User{ID: 2, Name: "Arya Stark", Status: enum.UserActive}
+		case 3:
+			q.Result = &entity.User{ID: 3, Name: "Sansa Stark", Status: enum.UserActive}
+		case 4:
+			q.Result = &entity.User{ID: 4, Name: "Ramsay Bolton", Status: enum.UserBlocked}
+		default:
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func TestChangePostOwner_IsAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.ChangePostOwner{Number: 1, UserID: 2}
+	Expect(action.IsAuthorized(context.Background(), nil)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleVisitor})).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleCollaborator})).IsTrue()
+}
+
+func TestChangePostOwner_Invalid(t *testing.T) {
+	RegisterT(t)
+	mockPostAuthorQueries()
+
+	for _, userID := range []int{1, 4, 99} {
+		action := &actions.ChangePostOwner{Number: 1, UserID: userID}
+		result := action.Validate(context.Background(), nil)
+		ExpectFailed(result, "userId")
+	}
+}
+
+func TestChangePostOwner_Valid(t *testing.T) {
+	RegisterT(t)
+	mockPostAuthorQueries()
+
+	action := &actions.ChangePostOwner{Number: 1, UserID: 2}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Post.ID).Equals(10)
+	Expect(action.User.ID).Equals(2)
+}
+
+func TestAddPostCoAuthor_Invalid(t *testing.T) {
+	RegisterT(t)
+	mockPostAuthorQueries()
+
+	for _, userID := range []int{1, 4, 99} {
+		action := &actions.AddPostCoAuthor{Number: 1, UserID: userID}
+		result := action.Validate(context.Background(), nil)
+		ExpectFailed(result, "userId")
+	}
+}
+
+func TestAddPostCoAuthor_Valid(t *testing.T) {
+	RegisterT(t)
+	mockPostAuthorQueries()
+
+	action := &actions.AddPostCoAuthor{Number: 1, UserID: 2}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.User.Name).Equals("Arya Stark")
+}
+
+func TestRemovePostCoAuthor(t *testing.T) {
+	RegisterT(t)
+	mockPostAuthorQueries()
+
+	action := &actions.RemovePostCoAuthor{Number: 1, UserID: 2}
+	result := action.Validate(context.Background(), nil)
+	Expect(result.Err).Equals(app.ErrNotFound)
+
+	action = &actions.RemovePostCoAuthor{Number: 1, UserID: 3}
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.User.Name).Equals("Sansa Stark")
+}
+
+func TestPostAuthors_AnonymousPost(t *testing.T) {
+	RegisterT(t)
+	mockPostAuthorQueries()
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 10, Number: q.Number, IsAnonymous: true, User: entity.AnonymousAuthor()}
+		return nil
+	})
+
+	collaborator := &entity.User{ID: 5, Role: enum.RoleCollaborator}
+	administrator := &entity.User{ID: 6, Role: enum.RoleAdministrator}
+
+	// The failure is the same whoever the user is, so it can't be used to find the author
+	for _, userID := range []int{1, 2, 99} {
+		result := (&actions.ChangePostOwner{Number: 1, UserID: userID}).Validate(context.Background(), collaborator)
+		ExpectFailed(result, "")
+		Expect(result.Errors[0].Message).Equals("Only administrators can change the authors of an anonymous post.")
+
+		result = (&actions.AddPostCoAuthor{Number: 1, UserID: userID}).Validate(context.Background(), collaborator)
+		ExpectFailed(result, "")
+		Expect(result.Errors[0].Message).Equals("Only administrators can change the authors of an anonymous post.")
+	}
+
+	result := (&actions.RemovePostCoAuthor{Number: 1, UserID: 3}).Validate(context.Background(), collaborator)
+	ExpectFailed(result, "")
+
+	result = (&actions.ChangePostOwner{Number: 1, UserID: 2}).Validate(context.Background(), administrator)
+	ExpectSuccess(result)
+}
@@ -0,0 +1,137 @@
+package actions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// AddPostLink is used to link an external reference to a post
+type AddPostLink struct {
+	Number int    `route:"number"`
+	Type   string `json:"type"`
+	URL    string `json:"url"`
+	State  string `json:"state"`
+
+	Post       *entity.Post
+	ExternalID string
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *AddPostLink) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *AddPostLink) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	action.Type = strings.ToLower(strings.TrimSpace(action.Type))
+	action.URL = strings.TrimSpace(action.URL)
+	if action.State == "" {
+		action.State = entity.PostLinkOpen
+	}
+
+	if !entity.IsValidPostLinkType(action.Type) {
+		result.AddFieldFailure("type", "Type must be one of github, gitlab, jira or url.")
+	}
+
+	if !entity.IsValidPostLinkState(action.State) {
+		result.AddFieldFailure("state", "State must be one of open, closed or merged.")
+	}
+
+	if action.URL == "" {
+		result.AddFieldFailure("url", "URL is required.")
+	} else if len(action.URL) > 300 {
+		result.AddFieldFailure("url", "URL must have less than 300 characters.")
+	} else if messages := validate.URL(ctx, action.URL); len(messages) > 0 {
+		result.AddFieldFailure("url", messages...)
+	} else if result.Ok {
+		externalID, err := entity.ParsePostLinkExternalID(action.Type, action.URL)
+		if err != nil {
+			result.AddFieldFailure("url", "URL doesn't point to an issue, merge request or ticket of this type.")
+		}
+		action.ExternalID = externalID
+	}
+
+	return result
+}
+
+// UpdatePostLink is used to change the state of an external reference
+type UpdatePostLink struct {
+	Number int    `route:"number"`
+	ID     int    `route:"id"`
+	State  string `json:"state"`
+
+	Post *entity.Post
+	Link *entity.PostLink
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UpdatePostLink) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *UpdatePostLink) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	post, link, err := getPostLink(ctx, action.Number, action.ID)
+	if err != nil {
+		return validate.Error(err)
+	}
+	action.Post = post
+	action.Link = link
+
+	result := validate.Success()
+	if !entity.IsValidPostLinkState(action.State) {
+		result.AddFieldFailure("state", "State must be one of open, closed or merged.")
+	}
+	return result
+}
+
+// DeletePostLink is used to remove an external reference from a post
+type DeletePostLink struct {
+	Number int `route:"number"`
+	ID     int `route:"id"`
+
+	Post *entity.Post
+	Link *entity.PostLink
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeletePostLink) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *DeletePostLink) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	post, link, err := getPostLink(ctx, action.Number, action.ID)
+	if err != nil {
+		return validate.Error(err)
+	}
+	action.Post = post
+	action.Link = link
+	return validate.Success()
+}
+
+func getPostLink(ctx context.Context, number, linkID int) (*entity.Post, *entity.PostLink, error) {
+	getPost := &query.GetPostByNumber{Number: number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return nil, nil, err
+	}
+
+	getLink := &query.GetPostLinkByID{PostID: getPost.Result.ID, LinkID: linkID}
+	if err := bus.Dispatch(ctx, getLink); err != nil {
+		return nil, nil, err
+	}
+	return getPost.Result, getLink.Result, nil
+}
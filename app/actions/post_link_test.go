@@ -0,0 +1,84 @@
+package actions_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestAddPostLink_IsAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.AddPostLink{Number: 1}
+	Expect(action.IsAuthorized(context.Background(), nil)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleVisitor})).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleCollaborator})).IsTrue()
+}
+
+func TestAddPostLink_Invalid(t *testing.T) {
+	RegisterT(t)
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 10, Number: q.Number}
+		return nil
+	})
+
+	testCases := []struct {
+		action *actions.AddPostLink
+		field  string
+	}{
+		{&actions.AddPostLink{Number: 1, Type: "trello", URL: "https://trello.com/c/abc"}, "type"},
+		{&actions.AddPostLink{Number: 1, Type: "github", URL: "https://github.com/a/b/issues/1", State: "done"}, "state"},
+		{&actions.AddPostLink{Number: 1, Type: "github", URL: ""}, "url"},
+		{&actions.AddPostLink{Number: 1, Type: "github", URL: "not a url"}, "url"},
+		{&actions.AddPostLink{Number: 1, Type: "github", URL: "https://github.com/a/b"}, "url"},
+		{&actions.AddPostLink{Number: 1, Type: "url", URL: "https://example.com/" + strings.Repeat("a", 300)}, "url"},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), nil)
+		ExpectFailed(result, testCase.field)
+	}
+}
+
+func TestAddPostLink_Valid(t *testing.T) {
+	RegisterT(t)
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 10, Number: q.Number}
+		return nil
+	})
+
+	action := &actions.AddPostLink{Number: 1, Type: " GitHub ", URL: "https://github.com/getfider/fider/pull/12"}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Post.ID).Equals(10)
+	Expect(action.Type).Equals(entity.PostLinkGitHub)
+	Expect(action.State).Equals(entity.PostLinkOpen)
+	Expect(action.ExternalID).Equals("getfider/fider#12")
+}
+
+func TestUpdatePostLink_InvalidState(t *testing.T) {
+	RegisterT(t)
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 10, Number: q.Number}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostLinkByID) error {
+		q.Result = &entity.PostLink{ID: q.LinkID, PostID: q.PostID, State: entity.PostLinkOpen}
+		return nil
+	})
+
+	action := &actions.UpdatePostLink{Number: 1, ID: 2, State: "done"}
+	ExpectFailed(action.Validate(context.Background(), nil), "state")
+
+	action = &actions.UpdatePostLink{Number: 1, ID: 2, State: entity.PostLinkMerged}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+	Expect(action.Link.ID).Equals(2)
+	Expect(action.Post.ID).Equals(10)
+}
@@ -0,0 +1,204 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// Statuses a rule can set on new posts
+var postRuleStatuses = []enum.PostStatus{enum.PostOpen, enum.PostPlanned, enum.PostStarted, enum.PostCompleted, enum.PostDeclined}
+
+// CreateEditPostRule is used to create a new post rule or edit existing
+type CreateEditPostRule struct {
+	ID                int         `route:"id"`
+	Name              string      `json:"name"`
+	IsEnabled         bool        `json:"isEnabled"`
+	Keywords          []string    `json:"keywords"`
+	Pattern           string      `json:"pattern"`
+	MatchIn           string      `json:"matchIn"`
+	EmailDomains      []string    `json:"emailDomains"`
+	Roles             []enum.Role `json:"roles"`
+	BoardSlugs        []string    `json:"boards"`
+	Tags              []string    `json:"tags"`
+	Status            string      `json:"status"`
+	SubscriberIDs     []int       `json:"subscriberIds"`
+	FlagForModeration bool        `json:"flagForModeration"`
+	Position          int         `json:"position"`
+
+	Rule *entity.PostRule
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditPostRule) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *CreateEditPostRule) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.ID > 0 {
+		getRule := &query.GetPostRuleByID{RuleID: action.ID}
+		if err := bus.Dispatch(ctx, getRule); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return validate.Error(app.ErrNotFound)
+			}
+			return validate.Error(err)
+		}
+	}
+
+	rule := &entity.PostRule{
+		ID:                action.ID,
+		Name:              strings.TrimSpace(action.Name),
+		IsEnabled:         action.IsEnabled,
+		Keywords:          trimAll(action.Keywords, strings.TrimSpace),
+		Pattern:           strings.TrimSpace(action.Pattern),
+		MatchIn:           action.MatchIn,
+		EmailDomains:      trimAll(action.EmailDomains, normalizeEmailDomain),
+		Roles:             action.Roles,
+		BoardSlugs:        trimAll(action.BoardSlugs, strings.TrimSpace),
+		Tags:              trimAll(action.Tags, strings.TrimSpace),
+		SubscriberIDs:     action.SubscriberIDs,
+		FlagForModeration: action.FlagForModeration,
+		Position:          action.Position,
+	}
+	if rule.Roles == nil {
+		rule.Roles = []enum.Role{}
+	}
+	if rule.SubscriberIDs == nil {
+		rule.SubscriberIDs = []int{}
+	}
+
+	if rule.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(rule.Name) > 60 {
+		result.AddFieldFailure("name", "Name must have less than 60 characters.")
+	}
+
+	if !rule.HasConditions() {
+		result.AddFieldFailure("conditions", "At least one condition is required.")
+	}
+
+	if len(rule.Keywords) > 50 {
+		result.AddFieldFailure("keywords", "A rule can have at most 50 keywords.")
+	}
+
+	if len(rule.Pattern) > 200 {
+		result.AddFieldFailure("pattern", "Pattern must have less than 200 characters.")
+	} else if _, err := regexp.Compile(rule.Pattern); err != nil {
+		result.AddFieldFailure("pattern", "Pattern is not a valid regular expression.")
+	}
+
+	if rule.MatchIn != entity.PostRuleMatchAny && rule.MatchIn != entity.PostRuleMatchTitle && rule.MatchIn != entity.PostRuleMatchDescription {
+		result.AddFieldFailure("matchIn", "Match in must be title, description or empty.")
+	}
+
+	for _, domain := range rule.EmailDomains {
+		if strings.ContainsAny(domain, "@ ") || !strings.Contains(domain, ".") {
+			result.AddFieldFailure("emailDomains", fmt.Sprintf("'%s' is not a valid email domain.", domain))
+		}
+	}
+
+	for _, role := range rule.Roles {
+		if role != enum.RoleVisitor && role != enum.RoleCollaborator && role != enum.RoleAdministrator {
+			result.AddFieldFailure("roles", "Role is invalid.")
+		}
+	}
+
+	for _, boardSlug := range rule.BoardSlugs {
+		err := bus.Dispatch(ctx, &query.GetBoardBySlug{Slug: boardSlug})
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("boards", fmt.Sprintf("Board '%s' does not exist.", boardSlug))
+		}
+	}
+
+	for _, tagSlug := range rule.Tags {
+		err := bus.Dispatch(ctx, &query.GetTagBySlug{Slug: tagSlug})
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("tags", fmt.Sprintf("Tag '%s' does not exist.", tagSlug))
+		}
+	}
+
+	if action.Status != "" {
+		idx := slices.IndexFunc(postRuleStatuses, func(s enum.PostStatus) bool { return s.Name() == action.Status })
+		if idx == -1 {
+			result.AddFieldFailure("status", "Status is invalid.")
+		} else {
+			rule.Status = &postRuleStatuses[idx]
+		}
+	}
+
+	for _, userID := range rule.SubscriberIDs {
+		getUser := &query.GetUserByID{UserID: userID}
+		err := bus.Dispatch(ctx, getUser)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil || !getUser.Result.IsCollaborator() {
+			result.AddFieldFailure("subscriberIds", "Only staff members can be subscribed by a rule.")
+		}
+	}
+
+	if !rule.HasActions() {
+		result.AddFieldFailure("actions", "At least one action is required.")
+	}
+
+	action.Rule = rule
+	return result
+}
+
+// DeletePostRule is used to delete an existing post rule
+type DeletePostRule struct {
+	ID int `route:"id"`
+
+	Rule *entity.PostRule
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeletePostRule) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *DeletePostRule) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getRule := &query.GetPostRuleByID{RuleID: action.ID}
+	if err := bus.Dispatch(ctx, getRule); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return validate.Error(app.ErrNotFound)
+		}
+		return validate.Error(err)
+	}
+
+	action.Rule = getRule.Result
+	return validate.Success()
+}
+
+// trimAll normalizes each value and removes the empty and repeated ones
+func trimAll(values []string, normalize func(string) string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = normalize(value)
+		if value != "" && !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func normalizeEmailDomain(domain string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "@")
+}
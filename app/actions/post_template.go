@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// CreateEditPostTemplate is used to create a new post template or edit existing
+type CreateEditPostTemplate struct {
+	ID               int      `route:"id"`
+	Name             string   `json:"name"`
+	Content          string   `json:"content"`
+	RequiredSections []string `json:"requiredSections"`
+	TagSlug          string   `json:"tag"`
+	BoardSlug        string   `json:"board"`
+	Position         int      `json:"position"`
+
+	Template *entity.PostTemplate
+	Board    *entity.Board
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditPostTemplate) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *CreateEditPostTemplate) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.ID > 0 {
+		getTemplate := &query.GetPostTemplateByID{TemplateID: action.ID}
+		if err := bus.Dispatch(ctx, getTemplate); err != nil {
+			return validate.Error(err)
+		}
+		action.Template = getTemplate.Result
+	}
+
+	action.Name = strings.TrimSpace(action.Name)
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 60 {
+		result.AddFieldFailure("name", "Name must have less than 60 characters.")
+	}
+
+	if strings.TrimSpace(action.Content) == "" {
+		result.AddFieldFailure("content", "Content is required.")
+	}
+
+	sections := make([]string, 0, len(action.RequiredSections))
+	template := &entity.PostTemplate{Content: action.Content}
+	for _, section := range action.RequiredSections {
+		section = strings.TrimSpace(section)
+		if section == "" {
+			continue
+		}
+		if !template.HasSection(section) {
+			result.AddFieldFailure("requiredSections", fmt.Sprintf("Section '%s' is not a heading of the template.", section))
+		}
+		sections = append(sections, section)
+	}
+	action.RequiredSections = sections
+
+	if action.TagSlug != "" {
+		err := bus.Dispatch(ctx, &query.GetTagBySlug{Slug: action.TagSlug})
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("tag", fmt.Sprintf("Tag '%s' does not exist.", action.TagSlug))
+		}
+	}
+
+	if action.BoardSlug != "" {
+		getBoard := &query.GetBoardBySlug{Slug: action.BoardSlug}
+		err := bus.Dispatch(ctx, getBoard)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("board", "Board does not exist.")
+		} else {
+			action.Board = getBoard.Result
+		}
+	}
+
+	return result
+}
+
+// DeletePostTemplate is used to delete an existing post template
+type DeletePostTemplate struct {
+	ID int `route:"id"`
+
+	Template *entity.PostTemplate
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeletePostTemplate) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *DeletePostTemplate) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getTemplate := &query.GetPostTemplateByID{TemplateID: action.ID}
+	if err := bus.Dispatch(ctx, getTemplate); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Template = getTemplate.Result
+	return validate.Success()
+}
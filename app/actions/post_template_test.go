@@ -0,0 +1,81 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestCreateEditPostTemplate_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetBoardBySlug) error {
+		return app.ErrNotFound
+	})
+
+	action := &actions.CreateEditPostTemplate{
+		Name:             " ",
+		Content:          "## Steps to reproduce\n",
+		RequiredSections: []string{"Steps to reproduce", "Screenshots"},
+		TagSlug:          "bug",
+		BoardSlug:        "it-services",
+	}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "name", "requiredSections", "tag", "board")
+}
+
+func TestCreateNewPost_WithTemplate(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostTemplateByID) error {
+		if q.TemplateID == 1 {
+			q.Result = &entity.PostTemplate{
+				ID:               1,
+				Content:          "## Steps to reproduce\n\n## Expected behavior\n",
+				RequiredSections: []string{"Steps to reproduce"},
+				BoardSlug:        "it-services",
+			}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetBoardBySlug) error {
+		q.Result = &entity.Board{ID: 1, Slug: q.Slug}
+		return nil
+	})
+
+	action := &actions.CreateNewPost{Title: "this is my new post", TemplateID: 2}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "templateId")
+
+	action = &actions.CreateNewPost{Title: "this is my new post", TemplateID: 1, Description: "## Steps to reproduce\n\n## Expected behavior\nIt works"}
+	result = action.Validate(context.Background(), nil)
+	ExpectFailed(result, "description")
+
+	action = &actions.CreateNewPost{Title: "this is my new post", TemplateID: 1, BoardSlug: "hr", Description: "## Steps to reproduce\nOpen it"}
+	result = action.Validate(context.Background(), nil)
+	ExpectFailed(result, "templateId")
+
+	action = &actions.CreateNewPost{Title: "this is my new post", TemplateID: 1, Description: "## Steps to reproduce\nOpen it"}
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Board.Slug).Equals("it-services")
+	Expect(action.Template.ID).Equals(1)
+}
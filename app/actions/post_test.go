@@ -3,6 +3,7 @@ package actions_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/entity"
@@ -12,11 +13,14 @@ import (
 	"github.com/getfider/fider/app/actions"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 )
 
 func TestCreateNewPost_InvalidPostTitles(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+
 	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
 		if q.Slug == "my-great-post" {
 			q.Result = &entity.Post{Slug: q.Slug}
@@ -42,6 +46,8 @@ func TestCreateNewPost_InvalidPostTitles(t *testing.T) {
 func TestCreateNewPost_ValidPostTitles(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+
 	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
 		return app.ErrNotFound
 	})
@@ -56,6 +62,127 @@ func TestCreateNewPost_ValidPostTitles(t *testing.T) {
 	}
 }
 
+func TestCreateNewPost_AnonymousPostingDisabled(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
+		return app.ErrNotFound
+	})
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{IsAnonymousPostingEnabled: false})
+	action := &actions.CreateNewPost{Title: "this is my new post", IsAnonymous: true}
+	result := action.Validate(ctx, nil)
+	ExpectFailed(result, "isAnonymous")
+
+	ctx = context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{IsAnonymousPostingEnabled: true})
+	action = &actions.CreateNewPost{Title: "this is my new post", IsAnonymous: true}
+	result = action.Validate(ctx, nil)
+	ExpectSuccess(result)
+}
+
+func TestAddNewComment_LockedPost(t *testing.T) {
+	RegisterT(t)
+
+	lockedAt := time.Now()
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number, Title: "Post 1", LockedAt: &lockedAt}
+		return nil
+	})
+
+	action := &actions.AddNewComment{Number: 1, Content: "This is a comment!"}
+	result := action.Validate(context.Background(), &entity.User{ID: 2, Role: enum.RoleVisitor})
+	ExpectFailed(result)
+
+	action = &actions.AddNewComment{Number: 1, Content: "This post is now locked."}
+	result = action.Validate(context.Background(), &entity.User{ID: 1, Role: enum.RoleCollaborator})
+	ExpectSuccess(result)
+}
+
+func mockReplyQueries() {
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number, Title: "Post 1"}
+		return nil
+	})
+	comments := map[int]*entity.Comment{
+		10: {ID: 10, Content: "Top-level"},
+		11: {ID: 11, Content: "Reply", ParentID: 10},
+		12: {ID: 12, Content: "Internal", IsInternal: true},
+		20: {ID: 20, Content: "On another post"},
+	}
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentPostID) error {
+		switch q.CommentID {
+		case 10, 11, 12:
+			q.Result = 1
+		case 20:
+			q.Result = 2
+		default:
+			return app.ErrNotFound
+		}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
+		comment, ok := comments[q.CommentID]
+		if !ok {
+			return app.ErrNotFound
+		}
+		q.Result = comment
+		return nil
+	})
+}
+
+func TestAddNewComment_Reply(t *testing.T) {
+	RegisterT(t)
+	mockReplyQueries()
+
+	action := &actions.AddNewComment{Number: 1, Content: "I agree!", ParentID: 10}
+	result := action.Validate(context.Background(), &entity.User{ID: 2, Role: enum.RoleVisitor})
+	ExpectSuccess(result)
+	Expect(action.Parent.ID).Equals(10)
+}
+
+func TestAddNewComment_InvalidReply(t *testing.T) {
+	RegisterT(t)
+	mockReplyQueries()
+
+	for _, parentID := range []int{11, 20, 99} {
+		action := &actions.AddNewComment{Number: 1, Content: "I agree!", ParentID: parentID}
+		result := action.Validate(context.Background(), &entity.User{ID: 2, Role: enum.RoleVisitor})
+		ExpectFailed(result, "parentId")
+	}
+}
+
+func TestAddNewComment_ReplyWithinConfiguredDepth(t *testing.T) {
+	RegisterT(t)
+	mockReplyQueries()
+	env.Config.CommentReplyMaxDepth = 2
+
+	action := &actions.AddNewComment{Number: 1, Content: "I agree!", ParentID: 11}
+	result := action.Validate(context.Background(), &entity.User{ID: 2, Role: enum.RoleVisitor})
+	ExpectSuccess(result)
+	Expect(action.Parent.ID).Equals(11)
+}
+
+func TestAddNewComment_InternalNote(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.AddNewComment{Number: 1, Content: "We should plan this", IsInternal: true}
+	Expect(action.IsAuthorized(context.Background(), &entity.User{ID: 1, Role: enum.RoleCollaborator})).IsTrue()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{ID: 2, Role: enum.RoleVisitor})).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), nil)).IsFalse()
+}
+
+func TestAddNewComment_ReplyToInternalNote(t *testing.T) {
+	RegisterT(t)
+	mockReplyQueries()
+
+	action := &actions.AddNewComment{Number: 1, Content: "Agreed", ParentID: 12}
+	result := action.Validate(context.Background(), &entity.User{ID: 1, Role: enum.RoleCollaborator})
+	ExpectSuccess(result)
+	Expect(action.IsInternal).IsTrue()
+}
+
 func TestSetResponse_InvalidStatus(t *testing.T) {
 	RegisterT(t)
 
@@ -67,6 +194,53 @@ func TestSetResponse_InvalidStatus(t *testing.T) {
 	ExpectFailed(result, "status")
 }
 
+func TestScheduleResponse_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1, Title: "Post 1"}
+		return nil
+	})
+
+	past := time.Now().Add(-1 * time.Hour)
+	action := &actions.ScheduleResponse{
+		Number:      1,
+		Status:      enum.PostDuplicate,
+		ScheduledAt: &past,
+	}
+	result := action.Validate(context.Background(), nil)
+	ExpectFailed(result, "status", "scheduledAt")
+
+	future := time.Now().Add(24 * time.Hour)
+	action = &actions.ScheduleResponse{
+		Number:      1,
+		Status:      enum.PostCompleted,
+		Text:        "Shipped!",
+		ScheduledAt: &future,
+	}
+	result = action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Post.ID).Equals(1)
+}
+
+func TestCancelScheduledResponse_OfAnotherPost(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: 1, Title: "Post 1"}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetScheduledResponseByID) error {
+		q.Result = &entity.ScheduledResponse{ID: q.ID, PostID: 2}
+		return nil
+	})
+
+	action := &actions.CancelScheduledResponse{Number: 1, ID: 3}
+	result := action.Validate(context.Background(), nil)
+	Expect(result.Err).Equals(app.ErrNotFound)
+}
+
 func TestDeletePost_WhenIsBeingReferenced(t *testing.T) {
 	RegisterT(t)
 
@@ -2,9 +2,12 @@ package actions
 
 import (
 	"context"
+	"fmt"
 	"regexp"
+	"slices"
 
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 
 	"github.com/getfider/fider/app"
@@ -22,8 +25,12 @@ type CreateEditTag struct {
 	Name     string `json:"name"`
 	Color    string `json:"color" format:"upper"`
 	IsPublic bool   `json:"isPublic"`
+	Group    string `json:"group"`
+	Parent   string `json:"parent"`
 
-	Tag *entity.Tag
+	Tag       *entity.Tag
+	TagGroup  *entity.TagGroup
+	ParentTag *entity.Tag
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
@@ -49,11 +56,13 @@ func (action *CreateEditTag) Validate(ctx context.Context, user *entity.User) *v
 	} else if len(action.Name) > 30 {
 		result.AddFieldFailure("name", "Name must have less than 30 characters.")
 	} else {
-		getDuplicateSlug := &query.GetTagBySlug{Slug: slug.Make(action.Name)}
+		newSlug := slug.Make(action.Name)
+		getDuplicateSlug := &query.GetTagBySlug{Slug: newSlug}
 		err := bus.Dispatch(ctx, getDuplicateSlug)
 		if err != nil && errors.Cause(err) != app.ErrNotFound {
 			return validate.Error(err)
-		} else if err == nil && (action.Tag == nil || action.Tag.ID != getDuplicateSlug.Result.ID) {
+		} else if err == nil && getDuplicateSlug.Result.Slug == newSlug && (action.Tag == nil || action.Tag.ID != getDuplicateSlug.Result.ID) {
+			// Old slugs of renamed or merged tags can be taken by a new name
 			result.AddFieldFailure("name", "This tag name is already in use.")
 		}
 	}
@@ -66,9 +75,170 @@ func (action *CreateEditTag) Validate(ctx context.Context, user *entity.User) *v
 		result.AddFieldFailure("color", "Color is invalid.")
 	}
 
+	if action.Group != "" {
+		getGroup := &query.GetTagGroupBySlug{Slug: action.Group}
+		err := bus.Dispatch(ctx, getGroup)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err != nil {
+			result.AddFieldFailure("group", "Tag group not found.")
+		}
+		action.TagGroup = getGroup.Result
+	}
+
+	// Posts can't end up with several tags of a single-select group
+	if action.Tag != nil && action.TagGroup != nil && action.TagGroup.IsSingleSelect && action.Tag.GroupID != action.TagGroup.ID {
+		countConflicts := &query.CountPostsWithManyGroupTags{GroupID: action.TagGroup.ID, TagID: action.Tag.ID}
+		if err := bus.Dispatch(ctx, countConflicts); err != nil {
+			return validate.Error(err)
+		}
+		if countConflicts.Result > 0 {
+			result.AddFieldFailure("group", fmt.Sprintf("%d post(s) already have another tag of this group, which only allows one.", countConflicts.Result))
+		}
+	}
+
+	if action.Parent != "" {
+		messages, err := action.validateParent(ctx)
+		if err != nil {
+			return validate.Error(err)
+		}
+		result.AddFieldFailure("parent", messages...)
+	}
+
+	return result
+}
+
+// validateParent ensures the parent exists and doesn't turn the tag into its own ancestor
+func (action *CreateEditTag) validateParent(ctx context.Context) ([]string, error) {
+	getAllTags := &query.GetAllTags{}
+	if err := bus.Dispatch(ctx, getAllTags); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*entity.Tag)
+	for _, tag := range getAllTags.Result {
+		byID[tag.ID] = tag
+		if tag.Slug == action.Parent {
+			action.ParentTag = tag
+		}
+	}
+
+	if action.ParentTag == nil {
+		return []string{"Parent tag not found."}, nil
+	}
+
+	if action.Tag != nil && isSameOrDescendant(byID, action.ParentTag, action.Tag.ID) {
+		return []string{"A tag cannot be a child of itself or of its children."}, nil
+	}
+	return []string{}, nil
+}
+
+// isSameOrDescendant returns true if tag is the one with given ID or one of its descendants.
+// Tags already visited stop the walk, so a broken hierarchy can't loop forever
+func isSameOrDescendant(byID map[int]*entity.Tag, tag *entity.Tag, ancestorID int) bool {
+	visited := make(map[int]bool)
+	for current := tag; current != nil && !visited[current.ID]; current = byID[current.ParentID] {
+		if current.ID == ancestorID {
+			return true
+		}
+		visited[current.ID] = true
+	}
+	return false
+}
+
+// GroupID returns the ID of the group of the tag, zero when ungrouped
+func (action *CreateEditTag) GroupID() int {
+	if action.TagGroup != nil {
+		return action.TagGroup.ID
+	}
+	return 0
+}
+
+// ParentID returns the ID of the parent of the tag, zero when it's a top-level tag
+func (action *CreateEditTag) ParentID() int {
+	if action.ParentTag != nil {
+		return action.ParentTag.ID
+	}
+	return 0
+}
+
+// CreateEditTagGroup is used to create a new tag group or edit existing
+type CreateEditTagGroup struct {
+	Slug           string `route:"slug"`
+	Name           string `json:"name"`
+	IsSingleSelect bool   `json:"isSingleSelect"`
+
+	Group *entity.TagGroup
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditTagGroup) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *CreateEditTagGroup) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Slug != "" {
+		getGroup := &query.GetTagGroupBySlug{Slug: action.Slug}
+		if err := bus.Dispatch(ctx, getGroup); err != nil {
+			return validate.Error(err)
+		}
+		action.Group = getGroup.Result
+	}
+
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 30 {
+		result.AddFieldFailure("name", "Name must have less than 30 characters.")
+	} else {
+		getDuplicateSlug := &query.GetTagGroupBySlug{Slug: slug.Make(action.Name)}
+		err := bus.Dispatch(ctx, getDuplicateSlug)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		} else if err == nil && (action.Group == nil || action.Group.ID != getDuplicateSlug.Result.ID) {
+			result.AddFieldFailure("name", "This tag group name is already in use.")
+		}
+	}
+
+	// Posts with several tags of the group must be fixed before it becomes single-select
+	if action.Group != nil && action.IsSingleSelect && !action.Group.IsSingleSelect {
+		countConflicts := &query.CountPostsWithManyGroupTags{GroupID: action.Group.ID}
+		if err := bus.Dispatch(ctx, countConflicts); err != nil {
+			return validate.Error(err)
+		}
+		if countConflicts.Result > 0 {
+			result.AddFieldFailure("isSingleSelect", fmt.Sprintf("%d post(s) have more than one tag of this group.", countConflicts.Result))
+		}
+	}
+
 	return result
 }
 
+// DeleteTagGroup is used to delete an existing tag group, its tags become ungrouped
+type DeleteTagGroup struct {
+	Slug string `route:"slug"`
+
+	Group *entity.TagGroup
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeleteTagGroup) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *DeleteTagGroup) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getGroup := &query.GetTagGroupBySlug{Slug: action.Slug}
+	if err := bus.Dispatch(ctx, getGroup); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Group = getGroup.Result
+	return validate.Success()
+}
+
 // DeleteTag is used to delete an existing tag
 type DeleteTag struct {
 	Slug string `route:"slug"`
@@ -93,6 +263,86 @@ func (action *DeleteTag) Validate(ctx context.Context, user *entity.User) *valid
 	return validate.Success()
 }
 
+// MergeTags is used to merge a tag into another, keeping its posts and old slug
+type MergeTags struct {
+	Slug string `route:"slug"`
+	Into string `json:"into"`
+
+	From   *entity.Tag
+	Target *entity.Tag
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *MergeTags) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *MergeTags) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getFrom := &query.GetTagBySlug{Slug: action.Slug}
+	if err := bus.Dispatch(ctx, getFrom); err != nil {
+		return validate.Error(err)
+	}
+	action.From = getFrom.Result
+
+	if action.Into == "" {
+		return validate.Failed("Target tag is required.")
+	}
+
+	getTarget := &query.GetTagBySlug{Slug: action.Into}
+	err := bus.Dispatch(ctx, getTarget)
+	if err != nil && errors.Cause(err) != app.ErrNotFound {
+		return validate.Error(err)
+	} else if err != nil {
+		return validate.Failed("Target tag not found.")
+	}
+	action.Target = getTarget.Result
+
+	if action.Target.ID == action.From.ID {
+		return validate.Failed("A tag cannot be merged into itself.")
+	}
+
+	// Posts can't end up with several tags of a single-select group
+	if action.Target.GroupID > 0 {
+		getAllGroups := &query.GetAllTagGroups{}
+		if err := bus.Dispatch(ctx, getAllGroups); err != nil {
+			return validate.Error(err)
+		}
+
+		isSingleSelect := slices.ContainsFunc(getAllGroups.Result, func(group *entity.TagGroup) bool {
+			return group.ID == action.Target.GroupID && group.IsSingleSelect
+		})
+		if isSingleSelect {
+			countConflicts := &query.CountPostsWithManyGroupTags{GroupID: action.Target.GroupID, TagID: action.From.ID, MergedIntoID: action.Target.ID}
+			if err := bus.Dispatch(ctx, countConflicts); err != nil {
+				return validate.Error(err)
+			}
+			if countConflicts.Result > 0 {
+				return validate.Failed(fmt.Sprintf("%d post(s) already have another tag of the group of the target tag, which only allows one.", countConflicts.Result))
+			}
+		}
+	}
+
+	// Children of the merged tag move under the target, which can't be one of them
+	if action.Target.ParentID > 0 {
+		getAllTags := &query.GetAllTags{}
+		if err := bus.Dispatch(ctx, getAllTags); err != nil {
+			return validate.Error(err)
+		}
+
+		byID := make(map[int]*entity.Tag)
+		for _, tag := range getAllTags.Result {
+			byID[tag.ID] = tag
+		}
+
+		if isSameOrDescendant(byID, action.Target, action.From.ID) {
+			return validate.Failed("A tag cannot be merged into one of its children.")
+		}
+	}
+
+	return validate.Success()
+}
+
 // AssignUnassignTag is used to assign or remove a tag to/from an post
 type AssignUnassignTag struct {
 	Slug   string `route:"slug"`
@@ -119,3 +369,69 @@ func (action *AssignUnassignTag) Validate(ctx context.Context, user *entity.User
 	action.Tag = getSlug.Result
 	return validate.Success()
 }
+
+// SubscribeToTag is used by current user to follow a tag, or to change how they are notified about it
+type SubscribeToTag struct {
+	Slug            string `route:"slug"`
+	Channels        int    `json:"channels"`
+	IncludeComments bool   `json:"includeComments"`
+
+	Tag *entity.Tag
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *SubscribeToTag) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *SubscribeToTag) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	tag, err := getVisibleTag(ctx, user, action.Slug)
+	if err != nil {
+		return validate.Error(err)
+	}
+	action.Tag = tag
+
+	channels := enum.NotificationChannel(action.Channels)
+	if channels&^(enum.NotificationChannelWeb|enum.NotificationChannelEmail) != 0 || channels == 0 {
+		return validate.Failed("Channels must be web, email or both.")
+	}
+
+	return validate.Success()
+}
+
+// UnsubscribeFromTag is used by current user to stop following a tag
+type UnsubscribeFromTag struct {
+	Slug string `route:"slug"`
+
+	Tag *entity.Tag
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UnsubscribeFromTag) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *UnsubscribeFromTag) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getSlug := &query.GetTagBySlug{Slug: action.Slug}
+	if err := bus.Dispatch(ctx, getSlug); err != nil {
+		return validate.Error(err)
+	}
+
+	action.Tag = getSlug.Result
+	return validate.Success()
+}
+
+// getVisibleTag returns the tag with given slug, private tags are only visible to staff
+func getVisibleTag(ctx context.Context, user *entity.User, slug string) (*entity.Tag, error) {
+	getSlug := &query.GetTagBySlug{Slug: slug}
+	if err := bus.Dispatch(ctx, getSlug); err != nil {
+		return nil, err
+	}
+
+	if !getSlug.Result.IsPublic && !user.IsCollaborator() {
+		return nil, app.ErrNotFound
+	}
+	return getSlug.Result, nil
+}
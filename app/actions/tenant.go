@@ -3,6 +3,7 @@ package actions
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/getfider/fider/app/models/query"
@@ -261,8 +262,9 @@ func (action *UpdateTenantSettings) Validate(ctx context.Context, user *entity.U
 
 // UpdateTenantAdvancedSettings is the input model used to update tenant advanced settings
 type UpdateTenantAdvancedSettings struct {
-	CustomCSS      string `json:"customCSS"`
-	AllowedSchemes string `json:"allowedSchemes"`
+	CustomCSS          string `json:"customCSS"`
+	AllowedSchemes     string `json:"allowedSchemes"`
+	IssueWebhookSecret string `json:"issueWebhookSecret"`
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
@@ -272,14 +274,22 @@ func (action *UpdateTenantAdvancedSettings) IsAuthorized(ctx context.Context, us
 
 // Validate if current model is valid
 func (action *UpdateTenantAdvancedSettings) Validate(ctx context.Context, user *entity.User) *validate.Result {
-	return validate.Success()
+	result := validate.Success()
+
+	action.IssueWebhookSecret = strings.TrimSpace(action.IssueWebhookSecret)
+	if len(action.IssueWebhookSecret) > 100 {
+		result.AddFieldFailure("issueWebhookSecret", "Webhook secret must have less than 100 characters.")
+	}
+
+	return result
 }
 
 // UpdateTenantPrivacySettings is the input model used to update tenant privacy settings
 type UpdateTenantPrivacySettings struct {
-	IsPrivate           bool `json:"isPrivate"`
-	IsFeedEnabled       bool `json:"isFeedEnabled"`
-	IsModerationEnabled bool `json:"isModerationEnabled"`
+	IsPrivate                 bool `json:"isPrivate"`
+	IsFeedEnabled             bool `json:"isFeedEnabled"`
+	IsModerationEnabled       bool `json:"isModerationEnabled"`
+	IsAnonymousPostingEnabled bool `json:"isAnonymousPostingEnabled"`
 }
 
 // IsAuthorized returns true if current user is authorized to perform this action
@@ -295,6 +305,175 @@ func (action *UpdateTenantPrivacySettings) Validate(ctx context.Context, user *e
 	return validate.Success()
 }
 
+// UpdateTenantVotingSettings is the input model used to update tenant voting settings
+type UpdateTenantVotingSettings struct {
+	VoteBudget              int            `json:"voteBudget"`
+	IsVoteImportanceEnabled bool           `json:"isVoteImportanceEnabled"`
+	VoteRoleWeights         map[string]int `json:"voteRoleWeights"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UpdateTenantVotingSettings) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.Role == enum.RoleAdministrator
+}
+
+// Validate if current model is valid
+func (action *UpdateTenantVotingSettings) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.VoteBudget < 0 {
+		result.AddFieldFailure("voteBudget", "Vote budget cannot be negative.")
+	} else if action.VoteBudget > 1000 {
+		result.AddFieldFailure("voteBudget", "Vote budget must be less than 1000.")
+	}
+
+	for role, weight := range action.VoteRoleWeights {
+		var r enum.Role
+		_ = r.UnmarshalText([]byte(role))
+		if r == 0 {
+			result.AddFieldFailure("voteRoleWeights", fmt.Sprintf("'%s' is not a valid role.", role))
+		} else if weight < 1 || weight > 100 {
+			result.AddFieldFailure("voteRoleWeights", fmt.Sprintf("Weight of '%s' must be between 1 and 100.", role))
+		}
+	}
+
+	return result
+}
+
+// UpdateTenantReactionSettings is the input model used to update the reaction palette of a tenant
+type UpdateTenantReactionSettings struct {
+	Reactions []*ReactionTypeInput `json:"reactions"`
+}
+
+// ReactionTypeInput is a reaction of the palette, the image is kept unless removed or replaced
+type ReactionTypeInput struct {
+	Emoji string           `json:"emoji"`
+	Name  string           `json:"name"`
+	Image *dto.ImageUpload `json:"image"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UpdateTenantReactionSettings) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.Role == enum.RoleAdministrator
+}
+
+// Validate if current model is valid
+func (action *UpdateTenantReactionSettings) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if len(action.Reactions) == 0 {
+		result.AddFieldFailure("reactions", "At least one reaction is required.")
+	} else if len(action.Reactions) > 20 {
+		result.AddFieldFailure("reactions", "A maximum of 20 reactions is allowed.")
+	}
+
+	existing := make(map[string]*entity.ReactionType)
+	if tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant); ok {
+		for _, reaction := range tenant.ReactionPalette() {
+			existing[reaction.Emoji] = reaction
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, reaction := range action.Reactions {
+		if reaction == nil {
+			result.AddFieldFailure("reactions", "Reaction is required.")
+			continue
+		}
+
+		field := fmt.Sprintf("reactions[%d]", i)
+		reaction.Emoji = strings.TrimSpace(reaction.Emoji)
+		reaction.Name = strings.TrimSpace(reaction.Name)
+		if reaction.Emoji == "" || len(reaction.Emoji) > 50 || strings.ContainsAny(reaction.Emoji, " \t/?#%") {
+			result.AddFieldFailure(field+".emoji", fmt.Sprintf("'%s' is not a valid reaction.", reaction.Emoji))
+		} else if seen[reaction.Emoji] {
+			result.AddFieldFailure(field+".emoji", fmt.Sprintf("'%s' is listed more than once.", reaction.Emoji))
+		}
+		seen[reaction.Emoji] = true
+
+		if reaction.Name == "" {
+			result.AddFieldFailure(field+".name", "Name is required.")
+		} else if len(reaction.Name) > 30 {
+			result.AddFieldFailure(field+".name", "Name must have less than 30 characters.")
+		}
+
+		if reaction.Image == nil {
+			reaction.Image = &dto.ImageUpload{}
+		}
+		reaction.Image.BlobKey = ""
+		if current, ok := existing[reaction.Emoji]; ok {
+			reaction.Image.BlobKey = current.ImageBlobKey
+		}
+
+		messages, err := validate.ImageUpload(ctx, reaction.Image, validate.ImageUploadOpts{
+			IsRequired:   false,
+			MinHeight:    16,
+			MinWidth:     16,
+			MaxKilobytes: 50,
+			ExactRatio:   true,
+		})
+		if err != nil {
+			return validate.Error(err)
+		}
+		result.AddFieldFailure(field+".image", messages...)
+	}
+
+	return result
+}
+
+// UpdateStalePostRules is the input model used to update how inactive posts are closed
+type UpdateStalePostRules struct {
+	InactiveDays  int             `json:"inactiveDays"`
+	GraceDays     int             `json:"graceDays"`
+	CloseStatus   enum.PostStatus `json:"closeStatus"`
+	Response      string          `json:"response"`
+	ExemptTags    []string        `json:"exemptTags"`
+	LockAfterDays int             `json:"lockAfterDays"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UpdateStalePostRules) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.Role == enum.RoleAdministrator
+}
+
+// Validate if current model is valid
+func (action *UpdateStalePostRules) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.InactiveDays < 0 || action.InactiveDays > 3650 {
+		result.AddFieldFailure("inactiveDays", "Inactive days must be between 0 and 3650.")
+	}
+
+	if action.GraceDays < 1 || action.GraceDays > 365 {
+		result.AddFieldFailure("graceDays", "Grace days must be between 1 and 365.")
+	}
+
+	if action.LockAfterDays < 0 || action.LockAfterDays > 3650 {
+		result.AddFieldFailure("lockAfterDays", "Lock after days must be between 0 and 3650.")
+	}
+
+	if action.CloseStatus == enum.PostOpen || action.CloseStatus == enum.PostDuplicate ||
+		action.CloseStatus == enum.PostDeleted || action.CloseStatus.Name() == "unknown" {
+		result.AddFieldFailure("closeStatus", "Status is invalid.")
+	}
+
+	if len(action.Response) > 2000 {
+		result.AddFieldFailure("response", "Response must have less than 2000 characters.")
+	}
+
+	if action.ExemptTags == nil {
+		action.ExemptTags = []string{}
+	}
+	for _, tagSlug := range action.ExemptTags {
+		getTag := &query.GetTagBySlug{Slug: tagSlug}
+		if err := bus.Dispatch(ctx, getTag); err != nil {
+			result.AddFieldFailure("exemptTags", fmt.Sprintf("Tag '%s' does not exist.", tagSlug))
+		}
+	}
+
+	return result
+}
+
 // UpdateTenantEmailAuthAllowed is the input model used to update tenant privacy settings
 type UpdateTenantEmailAuthAllowed struct {
 	IsEmailAuthAllowed bool `json:"isEmailAuthAllowed"`
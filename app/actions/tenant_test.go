@@ -181,3 +181,34 @@ func TestUpdateTenantSettings_ExistingTenant_WithLogo(t *testing.T) {
 	ExpectSuccess(result)
 	Expect(action.Logo.BlobKey).Equals("hello-world.png")
 }
+
+func TestUpdateStalePostRules_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if q.Slug == "roadmap" {
+			q.Result = &entity.Tag{ID: 1, Slug: "roadmap"}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := &actions.UpdateStalePostRules{
+		InactiveDays: -1,
+		GraceDays:    0,
+		CloseStatus:  enum.PostDuplicate,
+		ExemptTags:   []string{"roadmap", "unknown"},
+	}
+	result := action.Validate(context.Background(), &entity.User{ID: 1, Role: enum.RoleAdministrator})
+	ExpectFailed(result, "inactiveDays", "graceDays", "closeStatus", "exemptTags")
+
+	action = &actions.UpdateStalePostRules{
+		InactiveDays: 90,
+		GraceDays:    14,
+		CloseStatus:  enum.PostDeclined,
+		Response:     "Closed due to inactivity.",
+		ExemptTags:   []string{"roadmap"},
+	}
+	result = action.Validate(context.Background(), &entity.User{ID: 1, Role: enum.RoleAdministrator})
+	ExpectSuccess(result)
+}
@@ -0,0 +1,193 @@
+package actions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// AddVote represents the action of voting on a post
+type AddVote struct {
+	Number     int                 `route:"number"`
+	Importance enum.VoteImportance `json:"importance"`
+
+	Post *entity.Post
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *AddVote) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *AddVote) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	if action.Post.IsLocked() {
+		return validate.Failed(i18n.T(ctx, "validation.custom.postlocked"))
+	}
+
+	if action.Importance == 0 {
+		action.Importance = enum.VoteNiceToHave
+	} else if action.Importance.Name() == "unknown" {
+		result.AddFieldFailure("importance", propertyIsInvalid(ctx, "importance"))
+	}
+
+	hasBudget, err := HasVoteBudget(ctx, user, action.Post)
+	if err != nil {
+		return validate.Error(err)
+	}
+	if !hasBudget {
+		tenant := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+		return validate.Failed(i18n.T(ctx, "validation.custom.votebudgetexhausted", i18n.Params{"budget": tenant.VoteBudget}))
+	}
+
+	return result
+}
+
+// RemoveVote represents the action of removing current user's vote from a post
+type RemoveVote struct {
+	Number int `route:"number"`
+
+	Post *entity.Post
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *RemoveVote) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *RemoveVote) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	if action.Post.IsLocked() {
+		return validate.Failed(i18n.T(ctx, "validation.custom.postlocked"))
+	}
+
+	return validate.Success()
+}
+
+// HasVoteBudget returns false when the tenant limits the number of active votes per user
+// and given user has no votes left to spend on given post
+func HasVoteBudget(ctx context.Context, user *entity.User, post *entity.Post) (bool, error) {
+	tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	if !ok || tenant.VoteBudget <= 0 || !post.Status.IsActive() {
+		return true, nil
+	}
+
+	// A vote already cast on this post doesn't count, so users can still change its importance
+	countVotes := &query.CountActiveVotes{UserID: user.ID, ExcludePostID: post.ID}
+	if err := bus.Dispatch(ctx, countVotes); err != nil {
+		return false, err
+	}
+
+	return countVotes.Result < tenant.VoteBudget, nil
+}
+
+// AddProxyVote represents the action of a staff member voting on behalf of someone else
+type AddProxyVote struct {
+	Number     int                 `route:"number"`
+	UserID     int                 `json:"userId"`
+	Email      string              `json:"email" format:"lower"`
+	Name       string              `json:"name"`
+	Note       string              `json:"note"`
+	Importance enum.VoteImportance `json:"importance"`
+
+	Post *entity.Post
+	// User is who the vote is for. It has no ID when it's a new contact that still needs to be registered
+	User *entity.User
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *AddProxyVote) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsCollaborator()
+}
+
+// Validate if current model is valid
+func (action *AddProxyVote) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getPost := &query.GetPostByNumber{Number: action.Number}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		return validate.Error(err)
+	}
+	action.Post = getPost.Result
+
+	if action.Post.IsLocked() {
+		return validate.Failed(i18n.T(ctx, "validation.custom.postlocked"))
+	}
+
+	if action.Importance == 0 {
+		action.Importance = enum.VoteNiceToHave
+	} else if action.Importance.Name() == "unknown" {
+		result.AddFieldFailure("importance", "Importance is invalid.")
+	}
+
+	if len(action.Note) > 500 {
+		result.AddFieldFailure("note", "Note must have less than 500 characters.")
+	}
+
+	if action.UserID > 0 {
+		getUser := &query.GetUserByID{UserID: action.UserID}
+		err := bus.Dispatch(ctx, getUser)
+		if err != nil && errors.Cause(err) == app.ErrNotFound {
+			result.AddFieldFailure("userId", "User not found.")
+		} else if err != nil {
+			return validate.Error(err)
+		} else {
+			action.User = getUser.Result
+		}
+		return result
+	}
+
+	if action.Email == "" {
+		result.AddFieldFailure("email", "Either user or email is required.")
+		return result
+	}
+
+	messages := validate.Email(ctx, action.Email)
+	if len(messages) > 0 {
+		result.AddFieldFailure("email", messages...)
+		return result
+	}
+
+	getUser := &query.GetUserByEmail{Email: action.Email}
+	err := bus.Dispatch(ctx, getUser)
+	if err == nil {
+		action.User = getUser.Result
+	} else if errors.Cause(err) == app.ErrNotFound {
+		if action.Name == "" {
+			action.Name = strings.Split(action.Email, "@")[0]
+		} else if len(action.Name) > 100 {
+			result.AddFieldFailure("name", "Name must have less than 100 characters.")
+		}
+		action.User = &entity.User{
+			Name:  action.Name,
+			Email: action.Email,
+			Role:  enum.RoleVisitor,
+		}
+	} else {
+		return validate.Error(err)
+	}
+
+	return result
+}
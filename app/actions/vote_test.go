@@ -0,0 +1,72 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestAddVote_InvalidImportance(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number}
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{ID: 1})
+	action := &actions.AddVote{Number: 1, Importance: enum.VoteImportance(9)}
+	result := action.Validate(ctx, &entity.User{ID: 1})
+	ExpectFailed(result, "importance")
+
+	action = &actions.AddVote{Number: 1}
+	result = action.Validate(ctx, &entity.User{ID: 1})
+	ExpectSuccess(result)
+	Expect(action.Importance).Equals(enum.VoteNiceToHave)
+}
+
+func TestAddVote_VoteBudget(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		status := enum.PostOpen
+		if q.Number == 2 {
+			status = enum.PostCompleted
+		}
+		q.Result = &entity.Post{ID: q.Number, Number: q.Number, Status: status}
+		return nil
+	})
+
+	var countVotes *query.CountActiveVotes
+	bus.AddHandler(func(ctx context.Context, q *query.CountActiveVotes) error {
+		countVotes = q
+		q.Result = 3
+		return nil
+	})
+
+	user := &entity.User{ID: 5}
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{ID: 1, VoteBudget: 3})
+	action := &actions.AddVote{Number: 1}
+	result := action.Validate(ctx, user)
+	ExpectFailed(result)
+	Expect(countVotes.UserID).Equals(5)
+	Expect(countVotes.ExcludePostID).Equals(1)
+
+	// Closed posts don't use the budget
+	action = &actions.AddVote{Number: 2}
+	result = action.Validate(ctx, user)
+	ExpectSuccess(result)
+
+	ctx = context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{ID: 1, VoteBudget: 4})
+	action = &actions.AddVote{Number: 1}
+	result = action.Validate(ctx, user)
+	ExpectSuccess(result)
+}
@@ -55,6 +55,7 @@ func routes(r *web.Engine) *web.Engine {
 		feed.Use(middlewares.ClientCache(5 * time.Minute))
 
 		feed.Get("/feed/global.atom", handlers.GlobalFeed())
+		feed.Get("/feed/boards/:path", handlers.GlobalFeed())
 		feed.Get("/feed/posts/:path", handlers.CommentFeed())
 	}
 
@@ -77,6 +78,19 @@ func routes(r *web.Engine) *web.Engine {
 		stripeWh.Post("/webhooks/stripe", webhooks.IncomingStripeWebhook())
 	}
 
+	// Issue tracker webhooks (before CSRF middleware)
+	issueWh := r.Group()
+	{
+		issueWh.Post("/webhooks/github", webhooks.IncomingGitHubWebhook())
+		issueWh.Post("/webhooks/gitlab", webhooks.IncomingGitLabWebhook())
+	}
+
+	// Inbound email webhook (before CSRF middleware)
+	emailWh := r.Group()
+	{
+		emailWh.Post("/webhooks/email", webhooks.IncomingEmailWebhook())
+	}
+
 	r.Use(middlewares.CSRF())
 
 	r.Get("/terms", handlers.LegalPage("Terms of Service", "terms.md"))
@@ -137,6 +151,7 @@ func routes(r *web.Engine) *web.Engine {
 	r.Use(middlewares.CheckTenantPrivacy())
 
 	r.Get("/", handlers.Index())
+	r.Get("/boards/:slug", handlers.Index())
 	r.Get("/leaderboard", handlers.Page("Leaderboard", "Top ideas and users", "Leaderboard/Leaderboard.page"))
 	r.Get("/posts/:number", handlers.PostDetails())
 	r.Get("/posts/:number/:slug", handlers.PostDetails())
@@ -175,6 +190,7 @@ func routes(r *web.Engine) *web.Engine {
 		ui.Get("/admin/moderation", handlers.GetModerationPageHandler())
 		ui.Get("/admin/flagged", handlers.Page("Flagged Ideas · Moderation", "Ideas flagged for inappropriateness", "Administration/pages/FlaggedPosts.page"))
 		ui.Get("/admin/flagged-comments", handlers.Page("Flagged Comments · Moderation", "Comments flagged for inappropriateness", "Administration/pages/FlaggedComments.page"))
+		ui.Get("/admin/duplicates", handlers.Page("Possible Duplicates · Moderation", "Pairs of ideas that may be duplicates", "Administration/pages/DuplicatePosts.page"))
 		ui.Get("/admin/authentication", handlers.ManageAuthentication())
 		ui.Get("/_api/admin/oauth/:provider", handlers.GetOAuthConfig())
 
@@ -183,6 +199,7 @@ func routes(r *web.Engine) *web.Engine {
 
 		ui.Get("/admin/export", handlers.Page("Export · Site Settings", "", "Administration/pages/Export.page"))
 		ui.Get("/admin/export/posts.csv", handlers.ExportPostsToCSV())
+		ui.Get("/admin/export/polls.csv", handlers.ExportPollsToCSV())
 		ui.Get("/admin/export/backup.zip", handlers.ExportBackupZip())
 		ui.Get("/admin/webhooks", handlers.ManageWebhooks())
 		ui.Post("/_api/admin/webhook", handlers.CreateWebhook())
@@ -194,6 +211,10 @@ func routes(r *web.Engine) *web.Engine {
 		ui.Post("/_api/admin/settings/general", handlers.UpdateSettings())
 		ui.Post("/_api/admin/settings/advanced", handlers.UpdateAdvancedSettings())
 		ui.Post("/_api/admin/settings/privacy", handlers.UpdatePrivacySettings())
+		ui.Post("/_api/admin/settings/voting", handlers.UpdateVotingSettings())
+		ui.Post("/_api/admin/settings/reactions", handlers.UpdateReactionSettings())
+		ui.Get("/_api/admin/settings/stale-posts", handlers.GetStalePostRules())
+		ui.Post("/_api/admin/settings/stale-posts", handlers.UpdateStalePostRules())
 		ui.Post("/_api/admin/settings/emailauth", handlers.UpdateEmailAuthAllowed())
 		ui.Post("/_api/admin/oauth", handlers.SaveOAuthConfig())
 		ui.Post("/_api/admin/oauth/:provider/status", handlers.SetSystemProviderStatus())
@@ -219,11 +240,19 @@ func routes(r *web.Engine) *web.Engine {
 		publicApi.Get("/api/v1/similarposts", apiv1.FindSimilarPosts())
 		publicApi.Get("/api/v1/posts", apiv1.SearchPosts())
 		publicApi.Get("/api/v1/tags", apiv1.ListTags())
+		publicApi.Get("/api/v1/tag-groups", apiv1.ListTagGroups())
+		publicApi.Get("/api/v1/custom-fields", apiv1.ListCustomFields())
+		publicApi.Get("/api/v1/boards", apiv1.ListBoards())
+		publicApi.Get("/api/v1/post-templates", apiv1.ListPostTemplates())
 		publicApi.Get("/api/v1/posts/:number", apiv1.GetPost())
 		publicApi.Get("/api/v1/posts/:number/comments", apiv1.ListComments())
 		publicApi.Get("/api/v1/posts/:number/comments/:id", apiv1.GetComment())
 		publicApi.Get("/api/v1/taggable-users", apiv1.ListTaggableUsers())
 		publicApi.Get("/api/v1/posts/:number/votes", apiv1.ListVotes())
+		publicApi.Get("/api/v1/posts/:number/reactions", apiv1.ListPostReactions())
+		publicApi.Get("/api/v1/posts/:number/comments/:id/reactions", apiv1.ListCommentReactions())
+		publicApi.Get("/api/v1/posts/:number/polls", apiv1.ListPolls())
+		publicApi.Get("/api/v1/posts/:number/coauthors", apiv1.ListPostCoAuthors())
 		publicApi.Get("/api/v1/leaderboard/ideas", apiv1.TopIdeasLeaderboard())
 		publicApi.Get("/api/v1/leaderboard/users", apiv1.TopUsersLeaderboard())
 	}
@@ -237,17 +266,23 @@ func routes(r *web.Engine) *web.Engine {
 
 		membersApi.Post("/api/v1/posts", apiv1.CreatePost())
 		membersApi.Put("/api/v1/posts/:number", apiv1.UpdatePost())
+		membersApi.Post("/api/v1/posts/:number/reactions/:reaction", apiv1.TogglePostReaction())
 		membersApi.Post("/api/v1/posts/:number/comments/:id/reactions/:reaction", apiv1.ToggleReaction())
 		membersApi.Post("/api/v1/posts/:number/comments", apiv1.PostComment())
 		membersApi.Post("/api/v1/posts/:number/comments/:id/flag", apiv1.FlagComment())
+		membersApi.Post("/api/v1/posts/:number/comments/:id/answer", apiv1.AcceptAnswer())
 		membersApi.Post("/api/v1/posts/:number/flag", apiv1.FlagPost())
 		membersApi.Put("/api/v1/posts/:number/comments/:id", apiv1.UpdateComment())
 		membersApi.Delete("/api/v1/posts/:number/comments/:id", apiv1.DeleteComment())
 		membersApi.Post("/api/v1/posts/:number/votes", apiv1.AddVote())
 		membersApi.Delete("/api/v1/posts/:number/votes", apiv1.RemoveVote())
 		membersApi.Post("/api/v1/posts/:number/votes/toggle", apiv1.ToggleVote())
+		membersApi.Post("/api/v1/posts/:number/polls/:id/answers", apiv1.AnswerPoll())
 		membersApi.Post("/api/v1/posts/:number/subscription", apiv1.Subscribe())
 		membersApi.Delete("/api/v1/posts/:number/subscription", apiv1.Unsubscribe())
+		membersApi.Get("/api/v1/tag-subscriptions", apiv1.ListTagSubscriptions())
+		membersApi.Post("/api/v1/tags/:slug/subscription", apiv1.SubscribeToTag())
+		membersApi.Delete("/api/v1/tags/:slug/subscription", apiv1.UnsubscribeFromTag())
 
 		membersApi.Use(middlewares.IsAuthorized(enum.RoleCollaborator, enum.RoleAdministrator))
 		membersApi.Put("/api/v1/posts/:number/status", apiv1.SetResponse())
@@ -268,10 +303,30 @@ func routes(r *web.Engine) *web.Engine {
 		staffApi.Use(middlewares.BlockLockedTenants())
 		staffApi.Get("/api/v1/admin/comments/flagged", apiv1.ListFlaggedComments())
 		staffApi.Get("/api/v1/admin/posts/flagged", apiv1.ListFlaggedPosts())
+		staffApi.Get("/api/v1/admin/duplicates", apiv1.ListPostDuplicateCandidates())
+		staffApi.Delete("/api/v1/admin/duplicates/:id", apiv1.DismissPostDuplicateCandidate())
+		staffApi.Post("/api/v1/posts/:number", apiv1.BulkUpdatePosts()) // POST /api/v1/posts/bulk
 		staffApi.Post("/api/v1/posts/:number/pin", apiv1.PinPost())
+		staffApi.Post("/api/v1/posts/:number/lock", apiv1.LockPost())
 		staffApi.Post("/api/v1/posts/:number/comments/:id/pin", apiv1.PinComment())
+		staffApi.Get("/api/v1/tag-stats", apiv1.GetTagStats())
 		staffApi.Post("/api/v1/posts/:number/tags/:slug", apiv1.AssignTag())
 		staffApi.Delete("/api/v1/posts/:number/tags/:slug", apiv1.UnassignTag())
+		staffApi.Put("/api/v1/posts/:number/board", apiv1.MovePost())
+		staffApi.Put("/api/v1/posts/:number/owner", apiv1.ChangePostOwner())
+		staffApi.Post("/api/v1/posts/:number/coauthors", apiv1.AddPostCoAuthor())
+		staffApi.Delete("/api/v1/posts/:number/coauthors/:userID", apiv1.RemovePostCoAuthor())
+		staffApi.Post("/api/v1/posts/:number/votes/proxy", apiv1.AddProxyVote())
+		staffApi.Get("/api/v1/posts/:number/logs", apiv1.ListPostLogs())
+		staffApi.Get("/api/v1/posts/:number/analytics", apiv1.GetPostAnalytics())
+		staffApi.Post("/api/v1/posts/:number/links", apiv1.AddPostLink())
+		staffApi.Put("/api/v1/posts/:number/links/:id", apiv1.UpdatePostLink())
+		staffApi.Delete("/api/v1/posts/:number/links/:id", apiv1.DeletePostLink())
+		staffApi.Get("/api/v1/posts/:number/scheduled-responses", apiv1.ListScheduledResponses())
+		staffApi.Post("/api/v1/posts/:number/scheduled-responses", apiv1.ScheduleResponse())
+		staffApi.Delete("/api/v1/posts/:number/scheduled-responses/:id", apiv1.CancelScheduledResponse())
+		staffApi.Post("/api/v1/posts/:number/polls", apiv1.CreatePoll())
+		staffApi.Delete("/api/v1/posts/:number/polls/:id", apiv1.DeletePoll())
 	}
 
 	// Operations used to manage a site
@@ -286,6 +341,24 @@ func routes(r *web.Engine) *web.Engine {
 		adminApi.Post("/api/v1/tags", apiv1.CreateEditTag())
 		adminApi.Put("/api/v1/tags/:slug", apiv1.CreateEditTag())
 		adminApi.Delete("/api/v1/tags/:slug", apiv1.DeleteTag())
+		adminApi.Post("/api/v1/tags/:slug/merge", apiv1.MergeTags())
+		adminApi.Post("/api/v1/tag-groups", apiv1.CreateEditTagGroup())
+		adminApi.Put("/api/v1/tag-groups/:slug", apiv1.CreateEditTagGroup())
+		adminApi.Delete("/api/v1/tag-groups/:slug", apiv1.DeleteTagGroup())
+		adminApi.Post("/api/v1/custom-fields", apiv1.CreateEditCustomField())
+		adminApi.Put("/api/v1/custom-fields/:key", apiv1.CreateEditCustomField())
+		adminApi.Delete("/api/v1/custom-fields/:key", apiv1.DeleteCustomField())
+		adminApi.Post("/api/v1/boards", apiv1.CreateEditBoard())
+		adminApi.Put("/api/v1/boards/:slug", apiv1.CreateEditBoard())
+		adminApi.Delete("/api/v1/boards/:slug", apiv1.DeleteBoard())
+		adminApi.Post("/api/v1/post-templates", apiv1.CreateEditPostTemplate())
+		adminApi.Put("/api/v1/post-templates/:id", apiv1.CreateEditPostTemplate())
+		adminApi.Delete("/api/v1/post-templates/:id", apiv1.DeletePostTemplate())
+		adminApi.Get("/api/v1/post-rules", apiv1.ListPostRules())
+		adminApi.Post("/api/v1/post-rules", apiv1.CreateEditPostRule())
+		adminApi.Post("/api/v1/post-rules/dry-run", apiv1.DryRunPostRule())
+		adminApi.Put("/api/v1/post-rules/:id", apiv1.CreateEditPostRule())
+		adminApi.Delete("/api/v1/post-rules/:id", apiv1.DeletePostRule())
 
 		adminApi.Post("/api/v1/admin/moderation/posts/:id/approve-and-verify", apiv1.GetApprovePostAndVerifyHandler())
 		adminApi.Post("/api/v1/admin/moderation/posts/:id/decline-and-block", apiv1.GetDeclinePostAndBlockHandler())
@@ -298,6 +371,7 @@ func routes(r *web.Engine) *web.Engine {
 
 		adminApi.Use(middlewares.BlockLockedTenants())
 		adminApi.Delete("/api/v1/posts/:number", apiv1.DeletePost())
+		adminApi.Post("/api/v1/posts/:number/reveal-author", apiv1.RevealPostAuthor())
 		adminApi.Delete("/api/v1/admin/posts/:number/flags", apiv1.ClearPostFlags())
 		adminApi.Delete("/api/v1/admin/comments/:id/flags", apiv1.ClearCommentFlags())
 	}
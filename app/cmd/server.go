@@ -7,14 +7,20 @@ import (
 	"path"
 	"syscall"
 
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers/webhooks"
 	"github.com/getfider/fider/app/jobs"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/dbx"
 	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/rand"
+	"github.com/getfider/fider/app/pkg/smtpd"
 	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/services/email"
 	"github.com/robfig/cron"
 
 	_ "github.com/getfider/fider/app/services/blob/fs"
@@ -47,6 +53,7 @@ func RunServer() int {
 
 	copyEtcFiles(ctx)
 	startJobs(ctx)
+	startInboundEmail(ctx)
 
 	e := routes(web.New())
 	// Listen on 0.0.0.0 when HOST is unset so the app is reachable from other containers (e.g. nginx)
@@ -63,10 +70,84 @@ func startJobs(ctx context.Context) {
 	c := cron.New()
 	_ = c.AddJob(jobs.NewJob(ctx, "PurgeExpiredNotificationsJob", jobs.PurgeExpiredNotificationsJobHandler{}))
 	_ = c.AddJob(jobs.NewJob(ctx, "EmailSupressionJob", jobs.EmailSupressionJobHandler{}))
+	_ = c.AddJob(jobs.NewJob(ctx, "StalePostsJob", jobs.StalePostsJobHandler{}))
+	_ = c.AddJob(jobs.NewJob(ctx, "ScheduledResponsesJob", jobs.ScheduledResponsesJobHandler{}))
+	_ = c.AddJob(jobs.NewJob(ctx, "DuplicatePostsJob", jobs.DuplicatePostsJobHandler{}))
 
 	c.Start()
 }
 
+// Starts the local SMTP listener that receives replies to notification emails
+func startInboundEmail(ctx context.Context) {
+	inbound := env.Config.Email.Inbound
+	if inbound.SMTPAddress == "" || inbound.Domain == "" {
+		return
+	}
+
+	server := &smtpd.Server{
+		Addr:    inbound.SMTPAddress,
+		Domain:  inbound.Domain,
+		Handler: receiveInboundEmail,
+	}
+
+	go func() {
+		log.Infof(ctx, "Inbound email listener started on @{Address}", dto.Props{
+			"Address": inbound.SMTPAddress,
+		})
+		if err := server.ListenAndServe(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+}
+
+func receiveInboundEmail(ctx context.Context, from string, to []string, data []byte) (err error) {
+	ctx = log.WithProperties(ctx, dto.Props{
+		log.PropertyKeyContextID: rand.String(32),
+		log.PropertyKeyTag:       "INBOUND",
+	})
+
+	msg, err := email.ParseInboundMessage(data)
+	if err != nil {
+		log.Warnf(ctx, "Invalid inbound email: @{Error}", dto.Props{
+			"Error": err.Error(),
+		})
+		return errors.Wrap(smtpd.ErrRejected, "invalid message")
+	}
+	msg.Recipients = to
+
+	trx, err := dbx.BeginTx(ctx)
+	if err != nil {
+		log.Error(ctx, err)
+		return err
+	}
+	ctx = context.WithValue(ctx, app.TransactionCtxKey, trx)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Panicked(r)
+			log.Error(ctx, err)
+			trx.MustRollback()
+		}
+	}()
+
+	err = webhooks.ReceiveEmailReply(ctx, msg)
+	if errors.Cause(err) == webhooks.ErrReplyRejected {
+		log.Warnf(ctx, "Inbound email from '@{From}' was ignored: @{Error}", dto.Props{
+			"From":  msg.From,
+			"Error": err.Error(),
+		})
+		trx.MustRollback()
+		return errors.Wrap(smtpd.ErrRejected, "reply rejected")
+	} else if err != nil {
+		log.Error(ctx, err)
+		trx.MustRollback()
+		return err
+	}
+
+	trx.MustCommit()
+	return nil
+}
+
 // on startup, copy all etc/ files from configured blob storage into local etc/ folder
 // this can be used to avoid having to mount volumes on ephemeral environments
 func copyEtcFiles(ctx context.Context) {
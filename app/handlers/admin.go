@@ -38,6 +38,7 @@ func AdvancedSettingsPage() web.HandlerFunc {
 			Data: web.Map{
 				"customCSS":              c.Tenant().CustomCSS,
 				"allowedSchemes":         c.Tenant().AllowedSchemes,
+				"issueWebhookSecret":     c.Tenant().IssueWebhookSecret,
 				"licenseKey":             billingState.Result.LicenseKey,
 				"hasCommercialFeatures": c.Tenant().HasCommercialFeatures,
 			},
@@ -92,8 +93,9 @@ func UpdateAdvancedSettings() web.HandlerFunc {
 		}
 
 		if err := bus.Dispatch(c, &cmd.UpdateTenantAdvancedSettings{
-			CustomCSS:      action.CustomCSS,
-			AllowedSchemes: action.AllowedSchemes,
+			CustomCSS:          action.CustomCSS,
+			AllowedSchemes:     action.AllowedSchemes,
+			IssueWebhookSecret: action.IssueWebhookSecret,
 		}); err != nil {
 			return c.Failure(err)
 		}
@@ -111,9 +113,10 @@ func UpdatePrivacySettings() web.HandlerFunc {
 		}
 
 		updateSettings := &cmd.UpdateTenantPrivacySettings{
-			IsPrivate:           action.IsPrivate,
-			IsFeedEnabled:       action.IsFeedEnabled,
-			IsModerationEnabled: action.IsModerationEnabled,
+			IsPrivate:                 action.IsPrivate,
+			IsFeedEnabled:             action.IsFeedEnabled,
+			IsModerationEnabled:       action.IsModerationEnabled,
+			IsAnonymousPostingEnabled: action.IsAnonymousPostingEnabled,
 		}
 		if err := bus.Dispatch(c, updateSettings); err != nil {
 			return c.Failure(err)
@@ -123,6 +126,95 @@ func UpdatePrivacySettings() web.HandlerFunc {
 	}
 }
 
+// UpdateVotingSettings update current tenant's voting settings
+func UpdateVotingSettings() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UpdateTenantVotingSettings)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		updateSettings := &cmd.UpdateTenantVotingSettings{
+			VoteBudget:              action.VoteBudget,
+			IsVoteImportanceEnabled: action.IsVoteImportanceEnabled,
+			VoteRoleWeights:         action.VoteRoleWeights,
+		}
+		if err := bus.Dispatch(c, updateSettings); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// UpdateReactionSettings update the reaction palette of current tenant
+func UpdateReactionSettings() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UpdateTenantReactionSettings)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		images := make([]*dto.ImageUpload, len(action.Reactions))
+		for i, reaction := range action.Reactions {
+			images[i] = reaction.Image
+		}
+		if err := bus.Dispatch(c, &cmd.UploadImages{Images: images, Folder: "reactions"}); err != nil {
+			return c.Failure(err)
+		}
+
+		reactions := make([]*entity.ReactionType, len(action.Reactions))
+		for i, reaction := range action.Reactions {
+			reactions[i] = &entity.ReactionType{Emoji: reaction.Emoji, Name: reaction.Name}
+			if !reaction.Image.Remove {
+				reactions[i].ImageBlobKey = reaction.Image.BlobKey
+			}
+		}
+
+		if err := bus.Dispatch(c, &cmd.UpdateTenantReactionSettings{Reactions: reactions}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// GetStalePostRules returns current tenant's rules to close inactive posts
+func GetStalePostRules() web.HandlerFunc {
+	return func(c *web.Context) error {
+		getRules := &query.GetStalePostRules{}
+		if err := bus.Dispatch(c, getRules); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getRules.Result)
+	}
+}
+
+// UpdateStalePostRules update current tenant's rules to close inactive posts
+func UpdateStalePostRules() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UpdateStalePostRules)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		saveRules := &cmd.SaveStalePostRules{
+			InactiveDays:  action.InactiveDays,
+			GraceDays:     action.GraceDays,
+			CloseStatus:   action.CloseStatus,
+			Response:      action.Response,
+			ExemptTags:    action.ExemptTags,
+			LockAfterDays: action.LockAfterDays,
+		}
+		if err := bus.Dispatch(c, saveRules); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
 // UpdateEmailAuthAllowed update current tenant's allow email auth settings
 func UpdateEmailAuthAllowed() web.HandlerFunc {
 	return func(c *web.Context) error {
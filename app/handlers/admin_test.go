@@ -198,6 +198,116 @@ func TestUpdatePrivacySettingsHandler(t *testing.T) {
 	Expect(updateCmd.IsFeedEnabled).IsTrue()
 }
 
+func TestUpdateReactionSettingsHandler(t *testing.T) {
+	RegisterT(t)
+
+	var updateCmd *cmd.UpdateTenantReactionSettings
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateTenantReactionSettings) error {
+		updateCmd = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.UploadImages) error {
+		for _, image := range c.Images {
+			if image.Upload != nil {
+				image.BlobKey = c.Folder + "/" + image.Upload.FileName
+			}
+		}
+		return nil
+	})
+
+	logoBytes, _ := os.ReadFile(env.Etc("logo.png"))
+	logoB64 := base64.StdEncoding.EncodeToString(logoBytes)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(
+			handlers.UpdateReactionSettings(), `{
+				"reactions": [
+					{ "emoji": " 👍 ", "name": "Like" },
+					{
+						"emoji": "fider",
+						"name": "Fider",
+						"image": {
+							"upload": {
+								"fileName": "fider.png",
+								"contentType": "image/png",
+								"content": "`+logoB64+`"
+							}
+						}
+					}
+				]
+			}`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(updateCmd.Reactions).Equals([]*entity.ReactionType{
+		{Emoji: "👍", Name: "Like"},
+		{Emoji: "fider", Name: "Fider", ImageBlobKey: "reactions/fider.png"},
+	})
+
+	for _, body := range []string{
+		`{ "reactions": [] }`,
+		`{ "reactions": [{ "emoji": "🔥", "name": "Fire" }, { "emoji": "🔥", "name": "Hot" }] }`,
+		`{ "reactions": [{ "emoji": "not an emoji", "name": "Fire" }] }`,
+		`{ "reactions": [{ "emoji": "🔥", "name": "" }] }`,
+	} {
+		updateCmd = nil
+		code, _ = mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			AsUser(mock.JonSnow).
+			ExecutePost(handlers.UpdateReactionSettings(), body)
+
+		Expect(code).Equals(http.StatusBadRequest)
+		Expect(updateCmd).IsNil()
+	}
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(handlers.UpdateReactionSettings(), `{ "reactions": [{ "emoji": "🔥", "name": "Fire" }] }`)
+
+	Expect(code).Equals(http.StatusForbidden)
+}
+
+func TestUpdateReactionSettingsHandler_KeepImage(t *testing.T) {
+	RegisterT(t)
+
+	var updateCmd *cmd.UpdateTenantReactionSettings
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateTenantReactionSettings) error {
+		updateCmd = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.UploadImages) error {
+		return nil
+	})
+
+	tenant := *mock.DemoTenant
+	tenant.Reactions = []*entity.ReactionType{
+		{Emoji: "fider", Name: "Fider", ImageBlobKey: "reactions/fider.png"},
+		{Emoji: "party", Name: "Party", ImageBlobKey: "reactions/party.png"},
+	}
+
+	// Blob keys sent by the client are ignored, images are only kept or removed
+	code, _ := mock.NewServer().
+		OnTenant(&tenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(
+			handlers.UpdateReactionSettings(), `{
+				"reactions": [
+					{ "emoji": "fider", "name": "Fider!" },
+					{ "emoji": "party", "name": "Party", "image": { "remove": true } },
+					{ "emoji": "other", "name": "Other", "image": { "bkey": "logos/someone-else.png" } }
+				]
+			}`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(updateCmd.Reactions).Equals([]*entity.ReactionType{
+		{Emoji: "fider", Name: "Fider!", ImageBlobKey: "reactions/fider.png"},
+		{Emoji: "party", Name: "Party"},
+		{Emoji: "other", Name: "Other"},
+	})
+}
+
 func TestManageMembersHandler(t *testing.T) {
 	RegisterT(t)
 
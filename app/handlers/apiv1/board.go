@@ -0,0 +1,94 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ListBoards returns all boards of current tenant
+func ListBoards() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetAllBoards{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// CreateEditBoard creates a new board or updates an existing one
+func CreateEditBoard() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditBoard)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if action.Board != nil {
+			updateBoard := &cmd.UpdateBoard{
+				BoardID:             action.Board.ID,
+				Name:                action.Name,
+				WelcomeMessage:      action.WelcomeMessage,
+				DefaultTags:         action.DefaultTags,
+				IsModerationEnabled: action.IsModerationEnabled,
+				IsPrivate:           action.IsPrivate,
+				Position:            action.Position,
+				StaffIDs:            action.StaffIDs,
+			}
+			if err := bus.Dispatch(c, updateBoard); err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(updateBoard.Result)
+		}
+
+		addNewBoard := &cmd.AddNewBoard{
+			Name:                action.Name,
+			WelcomeMessage:      action.WelcomeMessage,
+			DefaultTags:         action.DefaultTags,
+			IsModerationEnabled: action.IsModerationEnabled,
+			IsPrivate:           action.IsPrivate,
+			Position:            action.Position,
+			StaffIDs:            action.StaffIDs,
+		}
+		if err := bus.Dispatch(c, addNewBoard); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(addNewBoard.Result)
+	}
+}
+
+// DeleteBoard deletes an existing board, its posts are kept without a board
+func DeleteBoard() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeleteBoard)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeleteBoard{Board: action.Board}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// MovePost moves a post to another board
+func MovePost() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.MovePost)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.SetPostBoard{Post: action.Post, Board: action.Board}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -0,0 +1,76 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ListCustomFields returns all custom fields visible to current user
+func ListCustomFields() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetAllCustomFields{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// CreateEditCustomField creates a new custom field or updates an existing one
+func CreateEditCustomField() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditCustomField)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if action.Field != nil {
+			updateField := &cmd.UpdateCustomField{
+				FieldID:    action.Field.ID,
+				Name:       action.Name,
+				Options:    action.Options,
+				IsRequired: action.IsRequired,
+				IsPublic:   action.IsPublic,
+				Position:   action.Position,
+			}
+			if err := bus.Dispatch(c, updateField); err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(updateField.Result)
+		}
+
+		addField := &cmd.AddCustomField{
+			Key:        action.NewKey,
+			Name:       action.Name,
+			Type:       action.Type,
+			Options:    action.Options,
+			IsRequired: action.IsRequired,
+			IsPublic:   action.IsPublic,
+			Position:   action.Position,
+		}
+		if err := bus.Dispatch(c, addField); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(addField.Result)
+	}
+}
+
+// DeleteCustomField deletes an existing custom field and all of its values
+func DeleteCustomField() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeleteCustomField)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeleteCustomField{Field: action.Field}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -0,0 +1,93 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ListPolls returns the polls of a post with their results
+func ListPolls() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		getPolls := &query.GetPollsByPost{PostID: getPost.Result.ID}
+		if err := bus.Dispatch(c, getPolls); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getPolls.Result)
+	}
+}
+
+// CreatePoll adds a new poll to a post or to a staff comment
+func CreatePoll() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.AddNewPoll)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		addPoll := &cmd.AddNewPoll{
+			Post:             action.Post,
+			Comment:          action.Comment,
+			Question:         action.Question,
+			Options:          action.Options,
+			IsMultipleChoice: action.IsMultipleChoice,
+			IsVotersOnly:     action.IsVotersOnly,
+			ClosesAt:         action.ClosesAt,
+		}
+		if err := bus.Dispatch(c, addPoll); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(addPoll.Result)
+	}
+}
+
+// AnswerPoll records the answers of current user and returns the updated results
+func AnswerPoll() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.AnswerPoll)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.AnswerPoll{Poll: action.Poll, OptionIDs: action.Options}); err != nil {
+			return c.Failure(err)
+		}
+
+		getPoll := &query.GetPollByID{PollID: action.Poll.ID}
+		if err := bus.Dispatch(c, getPoll); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getPoll.Result)
+	}
+}
+
+// DeletePoll removes a poll and all of its answers
+func DeletePoll() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeletePoll)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeletePoll{Poll: action.Poll}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
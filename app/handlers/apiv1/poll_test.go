@@ -0,0 +1,87 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestCreatePollHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	var addedPoll *cmd.AddNewPoll
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPoll) error {
+		addedPoll = c
+		c.Result = &entity.Poll{ID: 1, PostID: c.Post.ID, PostNumber: c.Post.Number, Question: c.Question}
+		return nil
+	})
+
+	code, json := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		ExecutePostAsJSON(apiv1.CreatePoll(), `{ "question": "Which one?", "options": ["A", "B", "C"], "isMultipleChoice": true }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(addedPoll.Post).Equals(post)
+	Expect(addedPoll.Options).Equals([]string{"A", "B", "C"})
+	Expect(addedPoll.IsMultipleChoice).IsTrue()
+	Expect(json.String("question")).Equals("Which one?")
+}
+
+func TestCreatePollHandler_RequiresCollaborator(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		ExecutePost(apiv1.CreatePoll(), `{ "question": "Which one?", "options": ["A", "B"] }`)
+	Expect(code).Equals(http.StatusForbidden)
+}
+
+func TestAnswerPollHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1"}
+	poll := &entity.Poll{ID: 5, PostID: 1, PostNumber: 1, Options: []*entity.PollOption{{ID: 1}, {ID: 2}}}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPollByID) error {
+		q.Result = poll
+		return nil
+	})
+
+	var answered *cmd.AnswerPoll
+	bus.AddHandler(func(ctx context.Context, c *cmd.AnswerPoll) error {
+		answered = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", post.Number).
+		AddParam("id", poll.ID).
+		ExecutePost(apiv1.AnswerPoll(), `{ "options": [2] }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(answered.Poll).Equals(poll)
+	Expect(answered.OptionIDs).Equals([]int{2})
+}
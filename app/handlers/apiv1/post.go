@@ -1,6 +1,10 @@
 package apiv1
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/metrics"
 	"github.com/getfider/fider/app/models/cmd"
@@ -9,6 +13,8 @@ import (
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/validate"
 	"github.com/getfider/fider/app/pkg/web"
 	"github.com/getfider/fider/app/pkg/webhook"
 	"github.com/getfider/fider/app/tasks"
@@ -27,6 +33,10 @@ func SearchPosts() web.HandlerFunc {
 			Limit:            c.QueryParam("limit"),
 			Tags:             c.QueryParamAsArray("tags"),
 			ModerationFilter: c.QueryParam("moderation"),
+			Board:            c.QueryParam("board"),
+			LinkType:         c.QueryParam("linktype"),
+			LinkState:        c.QueryParam("linkstate"),
+			Answered:         c.QueryParam("answered"),
 		}
 		if myVotesOnly, err := c.QueryParamAsBool("myvotes"); err == nil {
 			searchPosts.MyVotesOnly = myVotesOnly
@@ -39,6 +49,15 @@ func SearchPosts() web.HandlerFunc {
 		}
 		searchPosts.SetStatusesFromStrings(c.QueryParamAsArray("statuses"))
 
+		for name, values := range c.Request.URL.Query() {
+			if key, ok := strings.CutPrefix(name, "field."); ok && key != "" && len(values) > 0 {
+				if searchPosts.CustomFields == nil {
+					searchPosts.CustomFields = make(map[string]string)
+				}
+				searchPosts.CustomFields[key] = values[0]
+			}
+		}
+
 		if err := bus.Dispatch(c, searchPosts); err != nil {
 			return c.Failure(err)
 		}
@@ -75,6 +94,8 @@ func CreatePost() web.HandlerFunc {
 		newPost := &cmd.AddNewPost{
 			Title:       action.Title,
 			Description: action.Description,
+			Board:       action.Board,
+			IsAnonymous: action.IsAnonymous,
 		}
 		err := bus.Dispatch(c, newPost)
 		if err != nil {
@@ -82,10 +103,27 @@ func CreatePost() web.HandlerFunc {
 		}
 
 		setAttachments := &cmd.SetAttachments{Post: newPost.Result, Attachments: action.Attachments}
-		addVote := &cmd.AddVote{Post: newPost.Result, User: c.User()}
-		if err = bus.Dispatch(c, setAttachments, addVote); err != nil {
+		if err = bus.Dispatch(c, setAttachments); err != nil {
+			return c.Failure(err)
+		}
+
+		// Authors only vote on their own post when they still have votes left
+		hasVoteBudget, err := actions.HasVoteBudget(c, c.User(), newPost.Result)
+		if err != nil {
 			return c.Failure(err)
 		}
+		if hasVoteBudget {
+			if err = bus.Dispatch(c, &cmd.AddVote{Post: newPost.Result, User: c.User()}); err != nil {
+				return c.Failure(err)
+			}
+		}
+
+		if len(action.CustomFields) > 0 {
+			setCustomFields := &cmd.SetPostCustomFields{Post: newPost.Result, Values: action.CustomFields}
+			if err := bus.Dispatch(c, setCustomFields); err != nil {
+				return c.Failure(err)
+			}
+		}
 
 		if env.Config.PostCreationWithTagsEnabled {
 			for _, tag := range action.Tags {
@@ -96,6 +134,32 @@ func CreatePost() web.HandlerFunc {
 			}
 		}
 
+		if action.Board != nil {
+			for _, tagSlug := range action.Board.DefaultTags {
+				getTag := &query.GetTagBySlug{Slug: tagSlug}
+				if err := bus.Dispatch(c, getTag); err != nil {
+					// Default tags may have been deleted since the board was configured
+					continue
+				}
+				if err := bus.Dispatch(c, &cmd.AssignTag{Tag: getTag.Result, Post: newPost.Result}); err != nil {
+					return c.Failure(err)
+				}
+			}
+		}
+
+		if action.Template != nil && action.Template.TagSlug != "" {
+			getTag := &query.GetTagBySlug{Slug: action.Template.TagSlug}
+			if err := bus.Dispatch(c, getTag); err == nil {
+				if err := bus.Dispatch(c, &cmd.AssignTag{Tag: getTag.Result, Post: newPost.Result}); err != nil {
+					return c.Failure(err)
+				}
+			}
+		}
+
+		if err := applyPostRules(c, newPost.Result, true); err != nil {
+			return c.Failure(err)
+		}
+
 		c.Enqueue(tasks.NotifyAboutNewPost(newPost.Result))
 
 		metrics.TotalPosts.Inc()
@@ -156,6 +220,17 @@ func UpdatePost() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		if action.CustomFields != nil {
+			setCustomFields := &cmd.SetPostCustomFields{Post: action.Post, Values: action.CustomFields}
+			if err := bus.Dispatch(c, setCustomFields); err != nil {
+				return c.Failure(err)
+			}
+		}
+
+		if err := applyPostRules(c, action.Post, false); err != nil {
+			return c.Failure(err)
+		}
+
 		// Notify about mentions in the updated post
 		c.Enqueue(tasks.NotifyAboutUpdatedPost(updatePost.Result))
 
@@ -235,7 +310,9 @@ func ListComments() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		getComments := &query.GetCommentsByPost{Post: getPost.Result}
+		// Flat mode keeps the chronological list of comments, without tombstones, as returned before replies existed
+		isFlat := c.QueryParam("mode") == "flat"
+		getComments := &query.GetCommentsByPost{Post: getPost.Result, IncludeTombstones: !isFlat}
 		if err := bus.Dispatch(c, getComments); err != nil {
 			return c.Failure(err)
 		}
@@ -257,7 +334,10 @@ func ListComments() web.HandlerFunc {
 			comment.Content = commentString.SanitizeMentions()
 		}
 
-		return c.Ok(getComments.Result)
+		if isFlat {
+			return c.Ok(getComments.Result)
+		}
+		return c.Ok(entity.NestComments(getComments.Result))
 	}
 }
 
@@ -294,6 +374,11 @@ func ToggleReaction() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		// Internal notes can't be reacted to by those who can't see them
+		if getComment.Result.IsInternal && !c.User().IsCollaborator() {
+			return c.NotFound()
+		}
+
 		toggleReaction := &cmd.ToggleCommentReaction{
 			Comment: getComment.Result,
 			Emoji:   action.Reaction,
@@ -309,6 +394,28 @@ func ToggleReaction() web.HandlerFunc {
 	}
 }
 
+// TogglePostReaction adds or removes a reaction on a post
+func TogglePostReaction() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.TogglePostReaction)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		toggleReaction := &cmd.TogglePostReaction{
+			Post:  action.Post,
+			Emoji: action.Reaction,
+		}
+		if err := bus.Dispatch(c, toggleReaction); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"added": toggleReaction.Result,
+		})
+	}
+}
+
 // PostComment creates a new comment on given post
 func PostComment() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -327,8 +434,10 @@ func PostComment() web.HandlerFunc {
 		}
 
 		addNewComment := &cmd.AddNewComment{
-			Post:    getPost.Result,
-			Content: action.Content,
+			Post:       getPost.Result,
+			Content:    action.Content,
+			ParentID:   action.ParentID,
+			IsInternal: action.IsInternal,
 		}
 		if err := bus.Dispatch(c, addNewComment); err != nil {
 			return c.Failure(err)
@@ -368,8 +477,9 @@ func UpdateComment() web.HandlerFunc {
 		}
 
 		comment := &entity.Comment{
-			ID:      action.ID,
-			Content: action.Content,
+			ID:         action.ID,
+			Content:    action.Content,
+			IsInternal: action.Comment.IsInternal,
 		}
 
 		err := bus.Dispatch(c,
@@ -422,6 +532,30 @@ func PinPost() web.HandlerFunc {
 	}
 }
 
+// LockPost locks or unlocks a post (staff-only)
+func LockPost() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.SetPostLocked)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		logAction := entity.PostLogUnlocked
+		if action.Locked {
+			logAction = entity.PostLogLocked
+		}
+
+		if err := bus.Dispatch(c,
+			&cmd.SetPostLocked{Post: action.Post, Locked: action.Locked, Reason: action.Reason},
+			&cmd.AddPostLog{Post: action.Post, Action: logAction, Details: action.Reason},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
 // PinComment pins or unpins a comment (moderator-only)
 func PinComment() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -455,6 +589,34 @@ func PinComment() web.HandlerFunc {
 	}
 }
 
+// AcceptAnswer marks a comment as the accepted answer of a post, or clears it (staff or post author)
+func AcceptAnswer() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.SetAcceptedAnswer)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		logAction := entity.PostLogAnswerCleared
+		if action.Accepted {
+			logAction = entity.PostLogAnswerAccepted
+		}
+
+		if err := bus.Dispatch(c,
+			&cmd.SetAcceptedAnswer{Post: action.Post, CommentID: action.CommentID, Accepted: action.Accepted},
+			&cmd.AddPostLog{Post: action.Post, Action: logAction, Details: fmt.Sprintf("#comment-%d", action.CommentID)},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		if action.Accepted {
+			c.Enqueue(tasks.NotifyAboutAcceptedAnswer(action.Post, action.Comment))
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
 // TopIdeasLeaderboard returns top posts by vote count (public)
 func TopIdeasLeaderboard() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -462,7 +624,7 @@ func TopIdeasLeaderboard() web.HandlerFunc {
 		if l, err := c.QueryParamAsInt("limit"); err == nil && l > 0 && l <= 100 {
 			limit = l
 		}
-		q := &query.GetTopPostsByVotes{Limit: limit}
+		q := &query.GetTopPostsByVotes{Limit: limit, Board: c.QueryParam("board")}
 		if err := bus.Dispatch(c, q); err != nil {
 			return c.Failure(err)
 		}
@@ -477,7 +639,7 @@ func TopUsersLeaderboard() web.HandlerFunc {
 		if l, err := c.QueryParamAsInt("limit"); err == nil && l > 0 && l <= 100 {
 			limit = l
 		}
-		q := &query.GetTopUsersByVotes{Limit: limit}
+		q := &query.GetTopUsersByVotes{Limit: limit, Board: c.QueryParam("board")}
 		if err := bus.Dispatch(c, q); err != nil {
 			return c.Failure(err)
 		}
@@ -507,6 +669,33 @@ func ListFlaggedPosts() web.HandlerFunc {
 	}
 }
 
+// ListPostDuplicateCandidates returns the pairs of possible duplicates found by the duplicate detection job
+func ListPostDuplicateCandidates() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetPostDuplicateCandidates{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(q.Result)
+	}
+}
+
+// DismissPostDuplicateCandidate removes a pair of posts that are not duplicates from the report
+func DismissPostDuplicateCandidate() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DismissPostDuplicateCandidate)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DismissPostDuplicateCandidate{CandidateID: action.Candidate.ID}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
 // ClearPostFlags removes all flags from a post (admin-only)
 func ClearPostFlags() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -654,24 +843,67 @@ func DeleteComment() web.HandlerFunc {
 // AddVote adds current user to given post list of votes
 func AddVote() web.HandlerFunc {
 	return func(c *web.Context) error {
-		err := addOrRemove(c, func(post *entity.Post, user *entity.User) bus.Msg {
-			return &cmd.AddVote{Post: post, User: user}
-		})
+		action := new(actions.AddVote)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		addVote := &cmd.AddVote{Post: action.Post, User: c.User(), Importance: action.Importance}
+		if err := bus.Dispatch(c, addVote); err != nil {
+			return c.Failure(err)
+		}
+
+		metrics.TotalVotes.Inc()
+		return c.Ok(web.Map{})
+	}
+}
+
+// AddProxyVote adds a vote on behalf of another user, registering them first when it's a new contact
+func AddProxyVote() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.AddProxyVote)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
 
-		if err == nil {
-			metrics.TotalVotes.Inc()
+		if action.User.ID == 0 {
+			action.User.Tenant = c.Tenant()
+			if err := bus.Dispatch(c, &cmd.RegisterUser{User: action.User}); err != nil {
+				return c.Failure(err)
+			}
+		}
+
+		addVote := &cmd.AddVote{
+			Post:       action.Post,
+			User:       action.User,
+			Importance: action.Importance,
+			ProxiedBy:  c.User(),
+			ProxyNote:  action.Note,
+		}
+		if err := bus.Dispatch(c, addVote); err != nil {
+			return c.Failure(err)
 		}
 
-		return err
+		metrics.TotalVotes.Inc()
+		return c.Ok(web.Map{
+			"userId": action.User.ID,
+		})
 	}
 }
 
 // RemoveVote removes current user from given post list of votes
 func RemoveVote() web.HandlerFunc {
 	return func(c *web.Context) error {
-		return addOrRemove(c, func(post *entity.Post, user *entity.User) bus.Msg {
-			return &cmd.RemoveVote{Post: post, User: user}
-		})
+		action := new(actions.RemoveVote)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.RemoveVote{Post: action.Post, User: c.User()}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
 	}
 }
 
@@ -691,6 +923,10 @@ func ToggleVote() web.HandlerFunc {
 			return c.NotFound()
 		}
 
+		if getPost.Result.IsLocked() {
+			return c.HandleValidation(validate.Failed(i18n.T(c, "validation.custom.postlocked")))
+		}
+
 		listVotes := &query.ListPostVotes{PostID: getPost.Result.ID}
 		if err := bus.Dispatch(c, listVotes); err != nil {
 			return c.Failure(err)
@@ -712,6 +948,14 @@ func ToggleVote() web.HandlerFunc {
 			return c.Ok(web.Map{"voted": false})
 		}
 
+		hasVoteBudget, err := actions.HasVoteBudget(c, c.User(), getPost.Result)
+		if err != nil {
+			return c.Failure(err)
+		}
+		if !hasVoteBudget {
+			return c.HandleValidation(validate.Failed(i18n.T(c, "validation.custom.votebudgetexhausted", i18n.Params{"budget": c.Tenant().VoteBudget})))
+		}
+
 		err = bus.Dispatch(c, &cmd.AddVote{Post: getPost.Result, User: c.User()})
 		if err != nil {
 			return c.Failure(err)
@@ -762,6 +1006,97 @@ func ListVotes() web.HandlerFunc {
 	}
 }
 
+// ListPostReactions returns who reacted to given post, paginated
+func ListPostReactions() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return listReactions(c, 0)
+	}
+}
+
+// ListCommentReactions returns who reacted to given comment, paginated
+func ListCommentReactions() web.HandlerFunc {
+	return func(c *web.Context) error {
+		commentID, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+		return listReactions(c, commentID)
+	}
+}
+
+func listReactions(c *web.Context, commentID int) error {
+	number, err := c.ParamAsInt("number")
+	if err != nil {
+		return c.NotFound()
+	}
+
+	getPost := &query.GetPostByNumber{Number: number}
+	if err := bus.Dispatch(c, getPost); err != nil {
+		return c.Failure(err)
+	}
+
+	page, _ := c.QueryParamAsInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	limit, _ := c.QueryParamAsInt("limit")
+	if limit <= 0 {
+		limit = 20
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	listReactions := &query.ListReactions{
+		PostID:    getPost.Result.ID,
+		CommentID: commentID,
+		Emoji:     c.QueryParam("reaction"),
+		Page:      page,
+		Limit:     limit,
+	}
+	if err := bus.Dispatch(c, listReactions); err != nil {
+		return c.Failure(err)
+	}
+
+	return c.Ok(web.Map{
+		"reactions":  listReactions.Result,
+		"totalCount": listReactions.TotalCount,
+		"totalPages": (listReactions.TotalCount + limit - 1) / limit,
+		"page":       page,
+		"limit":      limit,
+	})
+}
+
+// ListPostLogs returns the activity log of given post
+func ListPostLogs() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		getLogs := &query.GetPostLogs{PostID: getPost.Result.ID}
+		if err := bus.Dispatch(c, getLogs); err != nil {
+			return c.Failure(err)
+		}
+
+		// Changes to the authors of an anonymous post name them, so only administrators see those
+		logs := getLogs.Result
+		if getPost.Result.IsAnonymous && !c.User().IsAdministrator() {
+			logs = slices.DeleteFunc(logs, func(l *entity.PostLog) bool {
+				return l.Action == entity.PostLogOwnerChanged || l.Action == entity.PostLogCoAuthorAdded || l.Action == entity.PostLogCoAuthorRemoved
+			})
+		}
+
+		return c.Ok(logs)
+	}
+}
+
 func addOrRemove(c *web.Context, getCommand func(post *entity.Post, user *entity.User) bus.Msg) error {
 	number, err := c.ParamAsInt("number")
 	if err != nil {
@@ -780,3 +1115,123 @@ func addOrRemove(c *web.Context, getCommand func(post *entity.Post, user *entity
 
 	return c.Ok(web.Map{})
 }
+
+// ListScheduledResponses returns pending scheduled responses and drafts of a post
+func ListScheduledResponses() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		getResponses := &query.GetScheduledResponses{PostID: getPost.Result.ID}
+		if err := bus.Dispatch(c, getResponses); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getResponses.Result)
+	}
+}
+
+// ScheduleResponse schedules a status change and response of a post or saves it as a draft
+func ScheduleResponse() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.ScheduleResponse)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		addResponse := &cmd.AddScheduledResponse{
+			Post:        action.Post,
+			Status:      action.Status,
+			Text:        action.Text,
+			ScheduledAt: action.ScheduledAt,
+			BaseURL:     c.BaseURL(),
+		}
+		if err := bus.Dispatch(c, addResponse); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(addResponse.Result)
+	}
+}
+
+// CancelScheduledResponse cancels a pending scheduled response or discards a draft
+func CancelScheduledResponse() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CancelScheduledResponse)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeleteScheduledResponse{ID: action.Response.ID}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// bulkPostBatchSize is the number of posts changed within a single transaction by a bulk operation
+const bulkPostBatchSize = 50
+
+// BulkUpdatePosts applies a status change, tag change, board move or deletion to many posts at once
+func BulkUpdatePosts() web.HandlerFunc {
+	return func(c *web.Context) error {
+		// Routed as /api/v1/posts/:number because the router doesn't allow a static segment next to it
+		if c.Param("number") != "bulk" {
+			return c.NotFound()
+		}
+
+		action := new(actions.BulkUpdatePosts)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		batches := 0
+		for start := 0; start < len(action.Posts); start += bulkPostBatchSize {
+			end := min(start+bulkPostBatchSize, len(action.Posts))
+			c.Enqueue(tasks.ApplyBulkPostChange(action.Posts[start:end], action.Change))
+			batches++
+		}
+
+		return c.Ok(web.Map{
+			"count":   len(action.Posts),
+			"batches": batches,
+		})
+	}
+}
+
+// RevealPostAuthor returns who wrote an anonymous post and records it on the post log
+func RevealPostAuthor() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.RevealPostAuthor)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		getAuthor := &query.GetPostAuthor{PostID: action.Post.ID}
+		if err := bus.Dispatch(c, getAuthor); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.AddPostLog{
+			Post:    action.Post,
+			Action:  entity.PostLogAuthorRevealed,
+			Details: action.Reason,
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"id":    getAuthor.Result.ID,
+			"name":  getAuthor.Result.Name,
+			"email": getAuthor.Result.Email,
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package apiv1
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// GetPostAnalytics returns the views, voters and comments of a post, along with their daily series
+func GetPostAnalytics() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		days, _ := c.QueryParamAsInt("days")
+		if days <= 0 || days > 365 {
+			days = 30
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		getAnalytics := &query.GetPostAnalytics{
+			PostID: getPost.Result.ID,
+			Since:  time.Now().AddDate(0, 0, -(days - 1)),
+		}
+		if err := bus.Dispatch(c, getAnalytics); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getAnalytics.Result)
+	}
+}
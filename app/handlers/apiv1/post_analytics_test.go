@@ -0,0 +1,51 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestGetPostAnalyticsHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	var getAnalytics *query.GetPostAnalytics
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostAnalytics) error {
+		getAnalytics = q
+		q.Result = &entity.PostAnalytics{
+			ViewsCount:     40,
+			VotersCount:    10,
+			ConversionRate: entity.VoteConversionRate(10, 40),
+			Series:         []*entity.PostActivity{{Date: time.Now(), Views: 40, Votes: 10}},
+		}
+		return nil
+	})
+
+	code, json := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		WithURL("http://demo.test.fider.io/api/v1/posts/1/analytics?days=7").
+		ExecuteAsJSON(apiv1.GetPostAnalytics())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(getAnalytics.PostID).Equals(1)
+	Expect(getAnalytics.Since.Before(time.Now().AddDate(0, 0, -5))).IsTrue()
+	Expect(getAnalytics.Since.After(time.Now().AddDate(0, 0, -7))).IsTrue()
+	Expect(json.Int32("viewsCount")).Equals(40)
+	Expect(json.Int32("votersCount")).Equals(10)
+}
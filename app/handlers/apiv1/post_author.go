@@ -0,0 +1,99 @@
+package apiv1
+
+import (
+	"slices"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ListPostCoAuthors returns the co-authors of a post
+func ListPostCoAuthors() web.HandlerFunc {
+	return func(c *web.Context) error {
+		number, err := c.ParamAsInt("number")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			return c.Failure(err)
+		}
+
+		getCoAuthors := &query.GetPostCoAuthors{PostID: getPost.Result.ID}
+		if err := bus.Dispatch(c, getCoAuthors); err != nil {
+			return c.Failure(err)
+		}
+
+		// Co-authors of anonymous posts are only listed to those who can see them on the post
+		coAuthors := getCoAuthors.Result
+		if getPost.Result.IsAnonymous {
+			coAuthors = slices.DeleteFunc(coAuthors, func(u *entity.User) bool {
+				return !getPost.Result.IsCoAuthor(u.ID)
+			})
+		}
+
+		return c.Ok(coAuthors)
+	}
+}
+
+// ChangePostOwner transfers a post to another user and records it on the post log
+func ChangePostOwner() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.ChangePostOwner)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c,
+			&cmd.ChangePostOwner{Post: action.Post, User: action.User},
+			&cmd.AddPostLog{Post: action.Post, Action: entity.PostLogOwnerChanged, Details: action.User.Name},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// AddPostCoAuthor adds a co-author to a post and records it on the post log
+func AddPostCoAuthor() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.AddPostCoAuthor)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c,
+			&cmd.AddPostCoAuthor{Post: action.Post, User: action.User},
+			&cmd.AddPostLog{Post: action.Post, Action: entity.PostLogCoAuthorAdded, Details: action.User.Name},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(action.User)
+	}
+}
+
+// RemovePostCoAuthor removes a co-author from a post and records it on the post log
+func RemovePostCoAuthor() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.RemovePostCoAuthor)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c,
+			&cmd.RemovePostCoAuthor{Post: action.Post, User: action.User},
+			&cmd.AddPostLog{Post: action.Post, Action: entity.PostLogCoAuthorRemoved, Details: action.User.Name},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
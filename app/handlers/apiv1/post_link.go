@@ -0,0 +1,71 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// AddPostLink links an external reference to a post and records it on the post log
+func AddPostLink() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.AddPostLink)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		addLink := &cmd.AddPostLink{
+			Post:       action.Post,
+			Type:       action.Type,
+			ExternalID: action.ExternalID,
+			URL:        action.URL,
+			State:      action.State,
+		}
+		if err := bus.Dispatch(c, addLink); err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.AddPostLog{Post: action.Post, Action: entity.PostLogLinkAdded, Details: action.URL}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(addLink.Result)
+	}
+}
+
+// UpdatePostLink changes the state of an external reference
+func UpdatePostLink() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UpdatePostLink)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.SetPostLinkState{Link: action.Link, State: action.State}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(action.Link)
+	}
+}
+
+// DeletePostLink removes an external reference from a post and records it on the post log
+func DeletePostLink() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeletePostLink)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c,
+			&cmd.DeletePostLink{Link: action.Link},
+			&cmd.AddPostLog{Post: action.Post, Action: entity.PostLogLinkRemoved, Details: action.Link.URL},
+		); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
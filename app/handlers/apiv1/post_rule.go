@@ -0,0 +1,189 @@
+package apiv1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/tasks"
+)
+
+// How many of the most recent posts a dry run checks
+const postRuleDryRunLimit = 1000
+
+// ListPostRules returns all post rules of current tenant
+func ListPostRules() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetAllPostRules{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// CreateEditPostRule creates a new post rule or updates an existing one
+func CreateEditPostRule() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditPostRule)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if action.ID > 0 {
+			updateRule := &cmd.UpdatePostRule{Rule: action.Rule}
+			if err := bus.Dispatch(c, updateRule); err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(updateRule.Result)
+		}
+
+		addNewRule := &cmd.AddNewPostRule{Rule: action.Rule}
+		if err := bus.Dispatch(c, addNewRule); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(addNewRule.Result)
+	}
+}
+
+// DeletePostRule deletes an existing post rule, posts it was applied to are kept as they are
+func DeletePostRule() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeletePostRule)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeletePostRule{Rule: action.Rule}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// DryRunPostRule returns the existing posts that would match given rule, without changing them
+func DryRunPostRule() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditPostRule)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		listSubjects := &query.ListPostRuleSubjects{Limit: postRuleDryRunLimit}
+		if err := bus.Dispatch(c, listSubjects); err != nil {
+			return c.Failure(err)
+		}
+
+		matches := make([]web.Map, 0)
+		for _, subject := range listSubjects.Result {
+			if action.Rule.Matches(subject) {
+				matches = append(matches, web.Map{
+					"number": subject.Number,
+					"title":  subject.Title,
+					"slug":   subject.Slug,
+				})
+			}
+		}
+
+		return c.Ok(web.Map{
+			"checked": len(listSubjects.Result),
+			"posts":   matches,
+		})
+	}
+}
+
+// applyPostRules runs the enabled post rules against a post that was just created or edited.
+// Edited posts are given as they were before the edit, rules that already matched them are skipped
+// so that their actions aren't repeated. The status of a rule is only set on new posts
+func applyPostRules(c *web.Context, post *entity.Post, isNew bool) error {
+	getRules := &query.GetAllPostRules{}
+	if err := bus.Dispatch(c, getRules); err != nil {
+		return err
+	}
+
+	if len(getRules.Result) == 0 {
+		return nil
+	}
+
+	listSubjects := &query.ListPostRuleSubjects{PostID: post.ID, Limit: 1}
+	if err := bus.Dispatch(c, listSubjects); err != nil {
+		return err
+	}
+
+	if len(listSubjects.Result) == 0 {
+		return nil
+	}
+
+	subject := listSubjects.Result[0]
+	var previous *entity.PostRuleSubject
+	if !isNew {
+		before := *subject
+		before.Title = post.Title
+		before.Description = post.Description
+		previous = &before
+	}
+
+	// Actions of rules are done by the system rather than by the author of the post
+	system := context.WithValue(c, app.UserCtxKey, (*entity.User)(nil))
+	for _, rule := range getRules.Result {
+		if !rule.IsEnabled || !rule.Matches(subject) || (previous != nil && rule.Matches(previous)) {
+			continue
+		}
+
+		for _, tagSlug := range rule.Tags {
+			getTag := &query.GetTagBySlug{Slug: tagSlug}
+			if err := bus.Dispatch(c, getTag); err != nil {
+				// Tags may have been deleted since the rule was configured
+				continue
+			}
+			assignTag := &cmd.AssignTag{Tag: getTag.Result, Post: post}
+			if err := bus.Dispatch(system, assignTag); err != nil {
+				return err
+			}
+
+			// Followers of the tags of new posts are notified about the post itself
+			if !isNew && assignTag.Result {
+				c.Enqueue(tasks.NotifyAboutAssignedTag(post, getTag.Result))
+			}
+		}
+
+		if isNew && rule.Status != nil && *rule.Status != post.Status {
+			if err := bus.Dispatch(system, &cmd.SetPostResponse{Post: post, Status: *rule.Status}); err != nil {
+				return err
+			}
+		}
+
+		for _, userID := range rule.SubscriberIDs {
+			getUser := &query.GetUserByID{UserID: userID}
+			if err := bus.Dispatch(c, getUser); err != nil || getUser.Result.Status != enum.UserActive {
+				continue
+			}
+			addSubscriber := &cmd.AddSubscriber{Post: post, User: getUser.Result, KeepUnsubscribed: true}
+			if err := bus.Dispatch(c, addSubscriber); err != nil {
+				return err
+			}
+		}
+
+		if rule.FlagForModeration {
+			flagPost := &cmd.FlagPost{PostID: post.ID, Reason: fmt.Sprintf("Matched rule '%s'", rule.Name)}
+			if err := bus.Dispatch(system, flagPost); err != nil {
+				return err
+			}
+		}
+
+		if err := bus.Dispatch(system, &cmd.AddPostLog{Post: post, Action: entity.PostLogRuleApplied, Details: rule.Name}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,315 @@
+package apiv1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers/apiv1"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestCreatePostRuleHandler_ValidRequest(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = &entity.Tag{ID: 1, Slug: q.Slug, Name: "Billing"}
+		return nil
+	})
+
+	var addNewRule *cmd.AddNewPostRule
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPostRule) error {
+		addNewRule = c
+		c.Result = c.Rule
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePost(
+			apiv1.CreateEditPostRule(),
+			`{ "name": "Billing", "isEnabled": true, "keywords": [" invoice ", "refund", "refund"], "emailDomains": ["@ACME.com"], "tags": ["billing"], "status": "planned" }`,
+		)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(addNewRule.Rule.Name).Equals("Billing")
+	Expect(addNewRule.Rule.Keywords).Equals([]string{"invoice", "refund"})
+	Expect(addNewRule.Rule.EmailDomains).Equals([]string{"acme.com"})
+	Expect(addNewRule.Rule.Tags).Equals([]string{"billing"})
+	Expect(*addNewRule.Rule.Status).Equals(enum.PostPlanned)
+}
+
+func TestCreatePostRuleHandler_InvalidRequests(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		return app.ErrNotFound
+	})
+
+	var testCases = []string{
+		`{ }`,
+		`{ "name": "No conditions", "flagForModeration": true }`,
+		`{ "name": "No actions", "keywords": ["refund"] }`,
+		`{ "name": "Bad pattern", "pattern": "([a-z", "flagForModeration": true }`,
+		`{ "name": "Bad match", "keywords": ["refund"], "matchIn": "comments", "flagForModeration": true }`,
+		`{ "name": "Bad domain", "emailDomains": ["acme"], "flagForModeration": true }`,
+		`{ "name": "Unknown tag", "keywords": ["refund"], "tags": ["billing"] }`,
+		`{ "name": "Bad status", "keywords": ["refund"], "status": "duplicate" }`,
+	}
+
+	for _, input := range testCases {
+		status, _ := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			AsUser(mock.JonSnow).
+			ExecutePost(apiv1.CreateEditPostRule(), input)
+
+		Expect(status).Equals(http.StatusBadRequest)
+	}
+}
+
+func TestCreatePostRuleHandler_NonAdministrator(t *testing.T) {
+	RegisterT(t)
+
+	status, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.CreateEditPostRule(), `{ "name": "Billing", "keywords": ["refund"], "flagForModeration": true }`)
+
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestDryRunPostRuleHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListPostRuleSubjects) error {
+		q.Result = []*entity.PostRuleSubject{
+			{PostID: 1, Number: 1, Slug: "refund-please", Title: "Refund please"},
+			{PostID: 2, Number: 2, Slug: "dark-mode", Title: "Dark mode"},
+			{PostID: 3, Number: 3, Slug: "billing-page", Title: "Billing page", Description: "I want a refund"},
+		}
+		return nil
+	})
+
+	status, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(apiv1.DryRunPostRule(), `{ "name": "Refunds", "keywords": ["refund"], "flagForModeration": true }`)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(query.Int32("checked")).Equals(3)
+	Expect(query.Int32("posts[0].number")).Equals(1)
+	Expect(query.Int32("posts[1].number")).Equals(3)
+	Expect(query.Contains("posts[2].number")).IsFalse()
+}
+
+func TestCreatePostHandler_AppliesMatchingRule(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error {
+		q.Result = []*entity.PostRule{
+			{ID: 1, Name: "Refunds", IsEnabled: true, Keywords: []string{"refund"}, Tags: []string{"billing"}, FlagForModeration: true},
+			{ID: 2, Name: "Disabled", IsEnabled: false, Keywords: []string{"refund"}, FlagForModeration: true},
+			{ID: 3, Name: "Dark mode", IsEnabled: true, Keywords: []string{"dark"}, FlagForModeration: true},
+		}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.ListPostRuleSubjects) error {
+		q.Result = []*entity.PostRuleSubject{
+			{PostID: q.PostID, Number: 1, Slug: "i-need-a-refund", Title: "I need a refund"},
+		}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = &entity.Tag{ID: 1, Slug: q.Slug, Name: "Billing"}
+		return nil
+	})
+
+	var assignTag *cmd.AssignTag
+	bus.AddHandler(func(ctx context.Context, c *cmd.AssignTag) error {
+		assignTag = c
+		return nil
+	})
+
+	flagged := make([]*cmd.FlagPost, 0)
+	var flaggedBy *entity.User
+	bus.AddHandler(func(ctx context.Context, c *cmd.FlagPost) error {
+		flaggedBy, _ = ctx.Value(app.UserCtxKey).(*entity.User)
+		flagged = append(flagged, c)
+		return nil
+	})
+
+	var addPostLog *cmd.AddPostLog
+	var loggedBy *entity.User
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddPostLog) error {
+		loggedBy, _ = ctx.Value(app.UserCtxKey).(*entity.User)
+		addPostLog = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPost) error {
+		c.Result = &entity.Post{ID: 1, Number: 1, Title: c.Title}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetAttachments) error { return nil })
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddVote) error { return nil })
+	bus.AddHandler(func(ctx context.Context, c *cmd.UploadImages) error { return nil })
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.CreatePost(), `{ "title": "I need a refund" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(assignTag.Tag.Slug).Equals("billing")
+	Expect(flagged).HasLen(1)
+	Expect(flagged[0].Reason).Equals("Matched rule 'Refunds'")
+	Expect(flaggedBy).IsNil()
+	Expect(addPostLog.Action).Equals(entity.PostLogRuleApplied)
+	Expect(addPostLog.Details).Equals("Refunds")
+	Expect(loggedBy).IsNil()
+}
+
+func TestCreatePostHandler_RuleStatusIsSetBySystem(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error {
+		status := enum.PostPlanned
+		q.Result = []*entity.PostRule{
+			{ID: 1, Name: "Roadmap", IsEnabled: true, Keywords: []string{"roadmap"}, Status: &status},
+		}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.ListPostRuleSubjects) error {
+		q.Result = []*entity.PostRuleSubject{
+			{PostID: q.PostID, Number: 1, Slug: "share-the-roadmap", Title: "Share the roadmap"},
+		}
+		return nil
+	})
+	responder := mock.AryaStark
+	var setResponse *cmd.SetPostResponse
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostResponse) error {
+		responder, _ = ctx.Value(app.UserCtxKey).(*entity.User)
+		setResponse = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddPostLog) error { return nil })
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPost) error {
+		c.Result = &entity.Post{ID: 1, Number: 1, Title: c.Title, Status: enum.PostOpen}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error {
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetAttachments) error { return nil })
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddVote) error { return nil })
+	bus.AddHandler(func(ctx context.Context, c *cmd.UploadImages) error { return nil })
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.CreatePost(), `{ "title": "Share the roadmap" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(setResponse.Status).Equals(enum.PostPlanned)
+	Expect(responder).IsNil()
+}
+
+func TestUpdatePostHandler_OnlyAppliesNewlyMatchingRules(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error {
+		q.Result = []*entity.PostRule{
+			{ID: 1, Name: "Refunds", IsEnabled: true, Keywords: []string{"refund"}, FlagForModeration: true},
+			{ID: 2, Name: "Invoices", IsEnabled: true, Keywords: []string{"invoice"}, SubscriberIDs: []int{mock.JonSnow.ID}, FlagForModeration: true},
+		}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.ListPostRuleSubjects) error {
+		q.Result = []*entity.PostRuleSubject{
+			{PostID: q.PostID, Number: 5, Slug: "refund-and-invoice", Title: "Refund and invoice"},
+		}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	var addSubscriber *cmd.AddSubscriber
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddSubscriber) error {
+		addSubscriber = c
+		return nil
+	})
+
+	flagged := make([]*cmd.FlagPost, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.FlagPost) error {
+		flagged = append(flagged, c)
+		return nil
+	})
+
+	logs := make([]*cmd.AddPostLog, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddPostLog) error {
+		logs = append(logs, c)
+		return nil
+	})
+
+	post := &entity.Post{ID: 5, Number: 5, Title: "I need a refund", Description: "please", User: mock.AryaStark}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error { return app.ErrNotFound })
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetAttachments) error { return nil })
+	bus.AddHandler(func(ctx context.Context, c *cmd.UploadImages) error { return nil })
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdatePost) error { return nil })
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		ExecutePost(apiv1.UpdatePost(), `{ "title": "Refund and invoice", "description": "please" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(flagged).HasLen(1)
+	Expect(flagged[0].Reason).Equals("Matched rule 'Invoices'")
+	Expect(logs).HasLen(1)
+	Expect(logs[0].Details).Equals("Invoices")
+	Expect(addSubscriber.User).Equals(mock.JonSnow)
+	Expect(addSubscriber.KeepUnsubscribed).IsTrue()
+}
+
+func TestPostRuleHandlers_UnknownRule(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostRuleByID) error {
+		return app.ErrNotFound
+	})
+
+	status, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 99).
+		ExecutePost(apiv1.CreateEditPostRule(), `{ "name": "Billing", "keywords": ["refund"], "flagForModeration": true }`)
+	Expect(status).Equals(http.StatusNotFound)
+
+	status, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 99).
+		Execute(apiv1.DeletePostRule())
+	Expect(status).Equals(http.StatusNotFound)
+}
@@ -0,0 +1,76 @@
+package apiv1
+
+import (
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// ListPostTemplates returns the post templates of current tenant that current user can see
+func ListPostTemplates() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetAllPostTemplates{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// CreateEditPostTemplate creates a new post template or updates an existing one
+func CreateEditPostTemplate() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditPostTemplate)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if action.Template != nil {
+			updateTemplate := &cmd.UpdatePostTemplate{
+				TemplateID:       action.Template.ID,
+				Name:             action.Name,
+				Content:          action.Content,
+				RequiredSections: action.RequiredSections,
+				TagSlug:          action.TagSlug,
+				Board:            action.Board,
+				Position:         action.Position,
+			}
+			if err := bus.Dispatch(c, updateTemplate); err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(updateTemplate.Result)
+		}
+
+		addNewTemplate := &cmd.AddNewPostTemplate{
+			Name:             action.Name,
+			Content:          action.Content,
+			RequiredSections: action.RequiredSections,
+			TagSlug:          action.TagSlug,
+			Board:            action.Board,
+			Position:         action.Position,
+		}
+		if err := bus.Dispatch(c, addNewTemplate); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(addNewTemplate.Result)
+	}
+}
+
+// DeletePostTemplate deletes an existing post template, posts created with it are kept
+func DeletePostTemplate() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeletePostTemplate)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeletePostTemplate{Template: action.Template}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -24,6 +24,10 @@ import (
 func TestCreatePostHandler(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+
 	var newPost *cmd.AddNewPost
 	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPost) error {
 		newPost = c
@@ -56,6 +60,10 @@ func TestCreatePostHandler(t *testing.T) {
 func TestCreatePostHandler_WithoutTitle(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+
 	code, _ := mock.NewServer().
 		OnTenant(mock.DemoTenant).
 		AsUser(mock.JonSnow).
@@ -68,6 +76,10 @@ func TestCreatePostHandler_WithNonExistentTag(t *testing.T) {
 	if env.Config.PostCreationWithTagsEnabled {
 		RegisterT(t)
 
+		bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
+		bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+
 		bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
 			return app.ErrNotFound
 		})
@@ -88,6 +100,10 @@ func TestCreatePostHandler_WithPrivateTagAsVisitor(t *testing.T) {
 	if env.Config.PostCreationWithTagsEnabled {
 		RegisterT(t)
 
+		bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
+		bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+
 		privateTag := &entity.Tag{
 			ID:       1,
 			Name:     "private_tag",
@@ -120,6 +136,10 @@ func TestCreatePostHandler_WithPublicTagAsVisitor(t *testing.T) {
 	if env.Config.PostCreationWithTagsEnabled {
 		RegisterT(t)
 
+		bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
+		bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+
 		var newPost *cmd.AddNewPost
 		bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPost) error {
 			newPost = c
@@ -175,6 +195,10 @@ func TestCreatePostHandler_WithPublicTagAndPrivateTagAsCollaborator(t *testing.T
 	if env.Config.PostCreationWithTagsEnabled {
 		RegisterT(t)
 
+		bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
+		bus.AddHandler(func(ctx context.Context, q *query.GetAllCustomFields) error { return nil })
+
 		var newPost *cmd.AddNewPost
 		bus.AddHandler(func(ctx context.Context, c *cmd.AddNewPost) error {
 			newPost = c
@@ -270,6 +294,8 @@ func TestGetPostHandler(t *testing.T) {
 func TestUpdatePostHandler_TenantStaff(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
 	post := &entity.Post{ID: 5, Number: 5, Title: "My First Post", Description: "With a description"}
 	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
 		if q.Number == post.Number {
@@ -304,6 +330,8 @@ func TestUpdatePostHandler_TenantStaff(t *testing.T) {
 func TestUpdatePostHandler_NonAuthorized(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
 	post := &entity.Post{
 		ID:          5,
 		Number:      5,
@@ -331,6 +359,8 @@ func TestUpdatePostHandler_NonAuthorized(t *testing.T) {
 func TestUpdatePostHandler_IsOwner_AfterGracePeriod(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
 	post := &entity.Post{
 		ID:          5,
 		Number:      5,
@@ -359,6 +389,8 @@ func TestUpdatePostHandler_IsOwner_AfterGracePeriod(t *testing.T) {
 func TestUpdatePostHandler_IsOwner_WithinGracePeriod(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
 	post := &entity.Post{
 		ID:          5,
 		Number:      5,
@@ -388,9 +420,46 @@ func TestUpdatePostHandler_IsOwner_WithinGracePeriod(t *testing.T) {
 	Expect(code).Equals(http.StatusOK)
 }
 
+func TestUpdatePostHandler_IsCoAuthor_AfterGracePeriod(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
+	post := &entity.Post{
+		ID:          5,
+		Number:      5,
+		Title:       "My First Post",
+		Description: "Such an amazing description",
+		User:        mock.JonSnow,
+		CreatedAt:   time.Now().UTC().Add(-48 * time.Hour),
+		CoAuthorIDs: []int{mock.AryaStark.ID},
+	}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		if q.Number == post.Number {
+			q.Result = post
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostBySlug) error { return app.ErrNotFound })
+	bus.AddHandler(func(ctx context.Context, cmd *cmd.UploadImages) error { return nil })
+	bus.AddHandler(func(ctx context.Context, cmd *cmd.SetAttachments) error { return nil })
+	bus.AddHandler(func(ctx context.Context, cmd *cmd.UpdatePost) error { return nil })
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", "5").
+		ExecutePost(apiv1.UpdatePost(), `{ "title": "the new title", "description": "new description" }`)
+
+	Expect(code).Equals(http.StatusOK)
+}
+
 func TestUpdatePostHandler_InvalidTitle(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
 	post := &entity.Post{ID: 5, Number: 5, Title: "My First Post", Description: "Such an amazing description"}
 	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
 		if q.Number == post.Number {
@@ -414,6 +483,8 @@ func TestUpdatePostHandler_InvalidTitle(t *testing.T) {
 func TestUpdatePostHandler_InvalidPost(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
 	post := &entity.Post{ID: 5, Number: 5, Title: "My First Post", Description: "Such an amazing description"}
 	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
 		if q.Number == post.Number {
@@ -438,6 +509,8 @@ func TestUpdatePostHandler_InvalidPost(t *testing.T) {
 func TestUpdatePostHandler_DuplicateTitle(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllPostRules) error { return nil })
+
 	post1 := &entity.Post{ID: 1, Number: 1, Title: "My First Post", Slug: "my-first-post"}
 	post2 := &entity.Post{ID: 2, Number: 2, Title: "My Second Post", Slug: "my-second-post"}
 	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
@@ -636,6 +709,115 @@ func TestAddVoteHandler_InvalidPost(t *testing.T) {
 	Expect(code).Equals(http.StatusNotFound)
 }
 
+func TestAddProxyVoteHandler_NewContact(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", Description: "The Description #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByEmail) error {
+		return app.ErrNotFound
+	})
+
+	var newUser *entity.User
+	bus.AddHandler(func(ctx context.Context, c *cmd.RegisterUser) error {
+		newUser = c.User
+		c.User.ID = 10
+		return nil
+	})
+
+	var addVote *cmd.AddVote
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddVote) error {
+		addVote = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		ExecutePost(apiv1.AddProxyVote(), `{ "email": "Customer@Acme.com", "note": "Requested by phone" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(newUser.Name).Equals("customer")
+	Expect(newUser.Email).Equals("customer@acme.com")
+	Expect(newUser.Tenant).Equals(mock.DemoTenant)
+	Expect(addVote.Post).Equals(post)
+	Expect(addVote.User).Equals(newUser)
+	Expect(addVote.ProxiedBy).Equals(mock.JonSnow)
+	Expect(addVote.ProxyNote).Equals("Requested by phone")
+}
+
+func TestAddProxyVoteHandler_ExistingUser(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", Description: "The Description #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == mock.AryaStark.ID {
+			q.Result = mock.AryaStark
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	var addVote *cmd.AddVote
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddVote) error {
+		addVote = c
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		ExecutePost(apiv1.AddProxyVote(), fmt.Sprintf(`{ "userId": %d }`, mock.AryaStark.ID))
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(addVote.User).Equals(mock.AryaStark)
+	Expect(addVote.ProxiedBy).Equals(mock.JonSnow)
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		ExecutePost(apiv1.AddProxyVote(), `{ "userId": 999 }`)
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestAddVoteHandler_LockedPost(t *testing.T) {
+	RegisterT(t)
+
+	lockedAt := time.Now()
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", LockedAt: &lockedAt}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", post.Number).
+		Execute(apiv1.AddVote())
+	Expect(code).Equals(http.StatusBadRequest)
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", post.Number).
+		Execute(apiv1.RemoveVote())
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
 func TestRemoveVoteHandler(t *testing.T) {
 	RegisterT(t)
 
@@ -875,6 +1057,45 @@ func TestListCommentHandler(t *testing.T) {
 	Expect(query.ArrayLength()).Equals(2)
 }
 
+func TestListCommentHandler_Threads(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", Description: "The Description #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	var getComments *query.GetCommentsByPost
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentsByPost) error {
+		getComments = q
+		q.Result = []*entity.Comment{
+			{ID: 2, Content: "A reply", ParentID: 1},
+			{ID: 1, Content: "First Comment"},
+		}
+		return nil
+	})
+
+	code, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", post.Number).
+		ExecuteAsJSON(apiv1.ListComments())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(getComments.IncludeTombstones).IsTrue()
+	Expect(query.ArrayLength()).Equals(1)
+
+	code, query = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", post.Number).
+		WithURL("http://demo.test.fider.io/api/v1/posts/1/comments?mode=flat").
+		ExecuteAsJSON(apiv1.ListComments())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(getComments.IncludeTombstones).IsFalse()
+	Expect(query.ArrayLength()).Equals(2)
+}
+
 func TestCommentReactionToggleHandler(t *testing.T) {
 	RegisterT(t)
 
@@ -885,6 +1106,11 @@ func TestCommentReactionToggleHandler(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number, Title: "The Post #1"}
+		return nil
+	})
+
 	testCases := []struct {
 		name     string
 		user     *entity.User
@@ -918,6 +1144,69 @@ func TestCommentReactionToggleHandler(t *testing.T) {
 	}
 }
 
+func TestCommentReactionToggleHandler_LockedPost(t *testing.T) {
+	RegisterT(t)
+
+	lockedAt := time.Now()
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number, Title: "The Post #1", LockedAt: &lockedAt}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		AddParam("id", 5).
+		AddParam("reaction", "👍").
+		ExecutePost(apiv1.ToggleReaction(), ``)
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestCommentReactionToggleHandler_InternalComment(t *testing.T) {
+	RegisterT(t)
+
+	comment := &entity.Comment{ID: 5, Content: "Staff only", User: mock.JonSnow, IsInternal: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
+		q.Result = comment
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number, Title: "The Post #1"}
+		return nil
+	})
+
+	var toggleReaction *cmd.ToggleCommentReaction
+	bus.AddHandler(func(ctx context.Context, c *cmd.ToggleCommentReaction) error {
+		toggleReaction = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		AddParam("id", comment.ID).
+		AddParam("reaction", "👍").
+		ExecutePost(apiv1.ToggleReaction(), ``)
+
+	Expect(code).Equals(http.StatusNotFound)
+	Expect(toggleReaction).IsNil()
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", 1).
+		AddParam("id", comment.ID).
+		AddParam("reaction", "👍").
+		ExecutePost(apiv1.ToggleReaction(), ``)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(toggleReaction.Comment).Equals(comment)
+}
+
 func TestCommentReactionToggleHandler_InvalidEmoji(t *testing.T) {
 	RegisterT(t)
 
@@ -968,6 +1257,11 @@ func TestCommentReactionToggleHandler_UnAuthorised(t *testing.T) {
 func TestCommentReactionToggleHandler_MismatchingTenantAndComment(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number, Title: "The Post #1"}
+		return nil
+	})
+
 	bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
 		return app.ErrNotFound
 	})
@@ -986,3 +1280,410 @@ func TestCommentReactionToggleHandler_MismatchingTenantAndComment(t *testing.T)
 
 	Expect(code).Equals(http.StatusNotFound)
 }
+
+func TestPostReactionToggleHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	var toggleReaction *cmd.TogglePostReaction
+	bus.AddHandler(func(ctx context.Context, c *cmd.TogglePostReaction) error {
+		toggleReaction = c
+		c.Result = true
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		AddParam("reaction", "🚀").
+		ExecutePost(apiv1.TogglePostReaction(), ``)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(toggleReaction.Post).Equals(post)
+	Expect(toggleReaction.Emoji).Equals("🚀")
+}
+
+func TestPostReactionToggleHandler_TenantReactions(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number, Title: "The Post #1"}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.TogglePostReaction) error {
+		return nil
+	})
+
+	tenant := *mock.DemoTenant
+	tenant.Reactions = []*entity.ReactionType{
+		{Emoji: "🔥", Name: "Fire"},
+		{Emoji: "👍", Name: "+1"},
+		{Emoji: "party", Name: "Party", ImageBlobKey: "reactions/party.png"},
+	}
+
+	for _, testCase := range []struct {
+		reaction string
+		code     int
+	}{
+		{"🔥", http.StatusOK},
+		{"👍", http.StatusOK},
+		{"party", http.StatusOK},
+		{"🚀", http.StatusBadRequest},
+	} {
+		code, _ := mock.NewServer().
+			OnTenant(&tenant).
+			AsUser(mock.AryaStark).
+			AddParam("number", 1).
+			AddParam("reaction", testCase.reaction).
+			ExecutePost(apiv1.TogglePostReaction(), ``)
+
+		Expect(code).Equals(testCase.code)
+	}
+}
+
+func TestPostReactionToggleHandler_LockedPost(t *testing.T) {
+	RegisterT(t)
+
+	lockedAt := time.Now()
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 1, Number: q.Number, Title: "The Post #1", LockedAt: &lockedAt}
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		AddParam("reaction", "👍").
+		ExecutePost(apiv1.TogglePostReaction(), ``)
+
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestPostReactionToggleHandler_UnAuthorised(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", 1).
+		AddParam("reaction", "👍").
+		ExecutePost(apiv1.TogglePostReaction(), ``)
+
+	Expect(code).Equals(http.StatusForbidden)
+}
+
+func TestListPostReactionsHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 7, Number: q.Number, Title: "The Post #1"}
+		return nil
+	})
+
+	var listReactions *query.ListReactions
+	bus.AddHandler(func(ctx context.Context, q *query.ListReactions) error {
+		listReactions = q
+		q.Result = []*entity.Reactor{
+			{Emoji: "🎉", User: mock.AryaStark},
+		}
+		q.TotalCount = 21
+		return nil
+	})
+
+	code, json := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", 1).
+		WithURL("http://demo.test.fider.io/api/v1/posts/1/reactions?reaction=🎉&page=2&limit=500").
+		ExecuteAsJSON(apiv1.ListPostReactions())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(listReactions.PostID).Equals(7)
+	Expect(listReactions.CommentID).Equals(0)
+	Expect(listReactions.Emoji).Equals("🎉")
+	Expect(listReactions.Page).Equals(2)
+	Expect(listReactions.Limit).Equals(100)
+	Expect(json.Int32("totalCount")).Equals(21)
+	Expect(json.Int32("totalPages")).Equals(1)
+	Expect(json.String("reactions[0].emoji")).Equals("🎉")
+	Expect(json.String("reactions[0].user.name")).Equals(mock.AryaStark.Name)
+}
+
+func TestListCommentReactionsHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: 7, Number: q.Number, Title: "The Post #1"}
+		return nil
+	})
+
+	var listReactions *query.ListReactions
+	bus.AddHandler(func(ctx context.Context, q *query.ListReactions) error {
+		listReactions = q
+		q.Result = []*entity.Reactor{}
+		return nil
+	})
+
+	code, json := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddParam("number", 1).
+		AddParam("id", 5).
+		ExecuteAsJSON(apiv1.ListCommentReactions())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(listReactions.PostID).Equals(7)
+	Expect(listReactions.CommentID).Equals(5)
+	Expect(listReactions.Emoji).Equals("")
+	Expect(listReactions.Page).Equals(1)
+	Expect(listReactions.Limit).Equals(20)
+	Expect(json.Int32("page")).Equals(1)
+	Expect(json.Int32("totalCount")).Equals(0)
+}
+
+func TestBulkUpdatePostsHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: q.Number, Number: q.Number, Title: "Post", Slug: "post"}
+		return nil
+	})
+
+	code, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", "bulk").
+		ExecutePostAsJSON(apiv1.BulkUpdatePosts(), fmt.Sprintf(`{ "operation": "respond", "status": "%s", "text": "Shipped!", "numbers": [1, 2, 3] }`, enum.PostCompleted.Name()))
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(query.Int32("count")).Equals(3)
+	Expect(query.Int32("batches")).Equals(1)
+}
+
+func TestBulkUpdatePostsHandler_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		if q.Number == 1 {
+			q.Result = &entity.Post{ID: 1, Number: 1, Title: "Post", Slug: "post"}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", "bulk").
+		ExecutePost(apiv1.BulkUpdatePosts(), fmt.Sprintf(`{ "operation": "respond", "status": "%s", "numbers": [1, 99] }`, enum.PostCompleted.Name()))
+	Expect(code).Equals(http.StatusBadRequest)
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", "bulk").
+		ExecutePost(apiv1.BulkUpdatePosts(), `{ "operation": "archive", "numbers": [1] }`)
+	Expect(code).Equals(http.StatusBadRequest)
+}
+
+func TestBulkUpdatePostsHandler_DeleteRequiresAdministrator(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", "bulk").
+		ExecutePost(apiv1.BulkUpdatePosts(), `{ "operation": "delete", "numbers": [1] }`)
+	Expect(code).Equals(http.StatusForbidden)
+}
+
+func TestRevealPostAuthorHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Post", Slug: "post", IsAnonymous: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostAuthor) error {
+		q.Result = mock.AryaStark
+		return nil
+	})
+
+	var addedLog *cmd.AddPostLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddPostLog) error {
+		addedLog = c
+		return nil
+	})
+
+	code, query := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		ExecutePostAsJSON(apiv1.RevealPostAuthor(), `{ "reason": "Reported for abuse" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(query.Int32("id")).Equals(mock.AryaStark.ID)
+	Expect(addedLog.Action).Equals(entity.PostLogAuthorRevealed)
+	Expect(addedLog.Details).Equals("Reported for abuse")
+}
+
+func TestRevealPostAuthorHandler_RequiresAdministrator(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		ExecutePost(apiv1.RevealPostAuthor(), `{ "reason": "Curious" }`)
+	Expect(code).Equals(http.StatusForbidden)
+}
+
+func TestLockPostHandler(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "The Post #1", Status: enum.PostPlanned}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	var setLocked *cmd.SetPostLocked
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostLocked) error {
+		setLocked = c
+		return nil
+	})
+
+	var addedLog *cmd.AddPostLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddPostLog) error {
+		addedLog = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		ExecutePost(apiv1.LockPost(), `{ "locked": true, "reason": " Too heated " }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(setLocked.Post).Equals(post)
+	Expect(setLocked.Locked).IsTrue()
+	Expect(setLocked.Reason).Equals("Too heated")
+	Expect(addedLog.Action).Equals(entity.PostLogLocked)
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		ExecutePost(apiv1.LockPost(), `{ "locked": false, "reason": "Ignored" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(setLocked.Locked).IsFalse()
+	Expect(setLocked.Reason).Equals("")
+	Expect(addedLog.Action).Equals(entity.PostLogUnlocked)
+}
+
+func TestLockPostHandler_RequiresCollaborator(t *testing.T) {
+	RegisterT(t)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		ExecutePost(apiv1.LockPost(), `{ "locked": true }`)
+	Expect(code).Equals(http.StatusForbidden)
+}
+
+func mockAnswerQueries(post *entity.Post) {
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentPostID) error {
+		q.Result = post.ID
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
+		q.Result = &entity.Comment{ID: q.CommentID, Content: "Use the export button", User: mock.JonSnow, IsApproved: true, IsInternal: q.CommentID == 9}
+		return nil
+	})
+}
+
+func TestAcceptAnswerHandler_ByAuthor(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "How do I export my data?", User: mock.AryaStark}
+	mockAnswerQueries(post)
+
+	var setAnswer *cmd.SetAcceptedAnswer
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetAcceptedAnswer) error {
+		setAnswer = c
+		return nil
+	})
+
+	var addedLog *cmd.AddPostLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddPostLog) error {
+		addedLog = c
+		return nil
+	})
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", post.Number).
+		AddParam("id", 5).
+		ExecutePost(apiv1.AcceptAnswer(), `{ "accepted": true }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(setAnswer.Post).Equals(post)
+	Expect(setAnswer.CommentID).Equals(5)
+	Expect(setAnswer.Accepted).IsTrue()
+	Expect(addedLog.Action).Equals(entity.PostLogAnswerAccepted)
+
+	code, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", post.Number).
+		AddParam("id", 5).
+		ExecutePost(apiv1.AcceptAnswer(), `{ "accepted": false }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(setAnswer.Accepted).IsFalse()
+	Expect(addedLog.Action).Equals(entity.PostLogAnswerCleared)
+}
+
+func TestAcceptAnswerHandler_RequiresStaffOrAuthor(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "How do I export my data?", User: mock.JonSnow}
+	mockAnswerQueries(post)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", post.Number).
+		AddParam("id", 5).
+		ExecutePost(apiv1.AcceptAnswer(), `{ "accepted": true }`)
+	Expect(code).Equals(http.StatusForbidden)
+}
+
+func TestAcceptAnswerHandler_InternalNote(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "How do I export my data?", User: mock.AryaStark}
+	mockAnswerQueries(post)
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("number", post.Number).
+		AddParam("id", 9).
+		ExecutePost(apiv1.AcceptAnswer(), `{ "accepted": true }`)
+	Expect(code).Equals(http.StatusBadRequest)
+}
@@ -3,9 +3,11 @@ package apiv1
 import (
 	"github.com/getfider/fider/app/actions"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/tasks"
 )
 
 // ListTags returns all tags
@@ -28,11 +30,15 @@ func AssignTag() web.HandlerFunc {
 			return c.HandleValidation(result)
 		}
 
-		err := bus.Dispatch(c, &cmd.AssignTag{Tag: action.Tag, Post: action.Post})
-		if err != nil {
+		assignTag := &cmd.AssignTag{Tag: action.Tag, Post: action.Post}
+		if err := bus.Dispatch(c, assignTag); err != nil {
 			return c.Failure(err)
 		}
 
+		if assignTag.Result {
+			c.Enqueue(tasks.NotifyAboutAssignedTag(action.Post, action.Tag))
+		}
+
 		return c.Ok(web.Map{})
 	}
 }
@@ -68,6 +74,8 @@ func CreateEditTag() web.HandlerFunc {
 				Name:     action.Name,
 				Color:    action.Color,
 				IsPublic: action.IsPublic,
+				GroupID:  action.GroupID(),
+				ParentID: action.ParentID(),
 			}
 			if err := bus.Dispatch(c, updateTag); err != nil {
 				return c.Failure(err)
@@ -79,6 +87,8 @@ func CreateEditTag() web.HandlerFunc {
 			Name:     action.Name,
 			Color:    action.Color,
 			IsPublic: action.IsPublic,
+			GroupID:  action.GroupID(),
+			ParentID: action.ParentID(),
 		}
 		if err := bus.Dispatch(c, addNewTag); err != nil {
 			return c.Failure(err)
@@ -103,3 +113,141 @@ func DeleteTag() web.HandlerFunc {
 		return c.Ok(web.Map{})
 	}
 }
+
+// MergeTags moves all posts of a tag to another one and removes it
+func MergeTags() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.MergeTags)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.MergeTags{From: action.From, Into: action.Target})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(action.Target)
+	}
+}
+
+// GetTagStats returns usage statistics of all tags
+func GetTagStats() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetTagStats{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// ListTagGroups returns all tag groups
+func ListTagGroups() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetAllTagGroups{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// CreateEditTagGroup creates a new tag group on current tenant
+func CreateEditTagGroup() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditTagGroup)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if action.Slug != "" {
+			updateGroup := &cmd.UpdateTagGroup{
+				GroupID:        action.Group.ID,
+				Name:           action.Name,
+				IsSingleSelect: action.IsSingleSelect,
+			}
+			if err := bus.Dispatch(c, updateGroup); err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(updateGroup.Result)
+		}
+
+		addNewGroup := &cmd.AddNewTagGroup{
+			Name:           action.Name,
+			IsSingleSelect: action.IsSingleSelect,
+		}
+		if err := bus.Dispatch(c, addNewGroup); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(addNewGroup.Result)
+	}
+}
+
+// DeleteTagGroup deletes an existing tag group
+func DeleteTagGroup() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeleteTagGroup)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.DeleteTagGroup{Group: action.Group})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// ListTagSubscriptions returns the tags followed by current user
+func ListTagSubscriptions() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetTagSubscriptions{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// SubscribeToTag makes current user follow given tag, posts with the tag notify them
+func SubscribeToTag() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.SubscribeToTag)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		err := bus.Dispatch(c, &cmd.SubscribeToTag{
+			Tag:             action.Tag,
+			Channels:        enum.NotificationChannel(action.Channels),
+			IncludeComments: action.IncludeComments,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// UnsubscribeFromTag makes current user stop following given tag
+func UnsubscribeFromTag() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UnsubscribeFromTag)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.UnsubscribeFromTag{Tag: action.Tag}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
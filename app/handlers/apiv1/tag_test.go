@@ -9,6 +9,7 @@ import (
 	"github.com/getfider/fider/app/handlers/apiv1"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
@@ -337,3 +338,519 @@ func TestListTagsHandler(t *testing.T) {
 	Expect(query.IsArray()).IsTrue()
 	Expect(query.ArrayLength()).Equals(2)
 }
+
+func TestEditTagHandler_WithGroupAndParent(t *testing.T) {
+	RegisterT(t)
+
+	tag := &entity.Tag{ID: 5, Name: "Android", Slug: "android", Color: "0000FF", IsPublic: true}
+	mobile := &entity.Tag{ID: 3, Name: "Mobile", Slug: "mobile", Color: "0000FF", IsPublic: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = tag
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTags) error {
+		q.Result = []*entity.Tag{tag, mobile}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		if q.Slug == "platform" {
+			q.Result = &entity.TagGroup{ID: 7, Name: "Platform", Slug: "platform"}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	var updateTag *cmd.UpdateTag
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateTag) error {
+		updateTag = c
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		AsUser(mock.JonSnow).
+		AddParam("slug", "android").
+		ExecutePost(
+			apiv1.CreateEditTag(),
+			`{ "name": "Android", "color": "0000FF", "isPublic": true, "group": "platform", "parent": "mobile" }`,
+		)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(updateTag.TagID).Equals(5)
+	Expect(updateTag.GroupID).Equals(7)
+	Expect(updateTag.ParentID).Equals(3)
+}
+
+func TestEditTagHandler_InvalidGroupOrParent(t *testing.T) {
+	RegisterT(t)
+
+	tag := &entity.Tag{ID: 5, Name: "Mobile", Slug: "mobile", Color: "0000FF", IsPublic: true}
+	child := &entity.Tag{ID: 6, Name: "Android", Slug: "android", Color: "0000FF", IsPublic: true, ParentID: 5}
+	grandchild := &entity.Tag{ID: 8, Name: "Tablet", Slug: "tablet", Color: "0000FF", IsPublic: true, ParentID: 6}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = tag
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTags) error {
+		q.Result = []*entity.Tag{tag, child, grandchild}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		return app.ErrNotFound
+	})
+
+	var testCases = []string{
+		`{ "name": "Mobile", "color": "0000FF", "group": "unknown" }`,
+		`{ "name": "Mobile", "color": "0000FF", "parent": "unknown" }`,
+		`{ "name": "Mobile", "color": "0000FF", "parent": "mobile" }`,
+		`{ "name": "Mobile", "color": "0000FF", "parent": "android" }`,
+		`{ "name": "Mobile", "color": "0000FF", "parent": "tablet" }`,
+	}
+
+	for _, testCase := range testCases {
+		status, _ := mock.NewServer().
+			AsUser(mock.JonSnow).
+			AddParam("slug", "mobile").
+			ExecutePostAsJSON(apiv1.CreateEditTag(), testCase)
+
+		Expect(status).Equals(http.StatusBadRequest)
+	}
+}
+
+func TestCreateTagGroupHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		return app.ErrNotFound
+	})
+
+	var addNewGroup *cmd.AddNewTagGroup
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewTagGroup) error {
+		addNewGroup = c
+		c.Result = &entity.TagGroup{ID: 1, Name: c.Name, Slug: "platform", IsSingleSelect: c.IsSingleSelect}
+		return nil
+	})
+
+	status, query := mock.NewServer().
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(apiv1.CreateEditTagGroup(), `{ "name": "Platform", "isSingleSelect": true }`)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(addNewGroup.Name).Equals("Platform")
+	Expect(addNewGroup.IsSingleSelect).IsTrue()
+	Expect(query.String("slug")).Equals("platform")
+}
+
+func TestCreateTagGroupHandler_InvalidRequests(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		if q.Slug == "area" {
+			q.Result = &entity.TagGroup{ID: 1, Name: "Area", Slug: "area"}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	var testCases = []string{
+		`{ }`,
+		`{ "name": "" }`,
+		`{ "name": "123456789012345678901234567890A" }`,
+		`{ "name": "Area" }`,
+	}
+
+	for _, testCase := range testCases {
+		status, _ := mock.NewServer().
+			AsUser(mock.JonSnow).
+			ExecutePostAsJSON(apiv1.CreateEditTagGroup(), testCase)
+
+		Expect(status).Equals(http.StatusBadRequest)
+	}
+}
+
+func TestEditTagGroupHandler(t *testing.T) {
+	RegisterT(t)
+
+	group := &entity.TagGroup{ID: 4, Name: "Area", Slug: "area"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		q.Result = group
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.CountPostsWithManyGroupTags) error {
+		q.Result = 0
+		return nil
+	})
+
+	var updateGroup *cmd.UpdateTagGroup
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateTagGroup) error {
+		updateGroup = c
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		AsUser(mock.JonSnow).
+		AddParam("slug", "area").
+		ExecutePostAsJSON(apiv1.CreateEditTagGroup(), `{ "name": "Area", "isSingleSelect": true }`)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(updateGroup.GroupID).Equals(4)
+	Expect(updateGroup.IsSingleSelect).IsTrue()
+}
+
+func TestEditTagGroupHandler_SingleSelectWithConflicts(t *testing.T) {
+	RegisterT(t)
+
+	group := &entity.TagGroup{ID: 4, Name: "Area", Slug: "area"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		q.Result = group
+		return nil
+	})
+
+	var countConflicts *query.CountPostsWithManyGroupTags
+	bus.AddHandler(func(ctx context.Context, q *query.CountPostsWithManyGroupTags) error {
+		countConflicts = q
+		q.Result = 2
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		AsUser(mock.JonSnow).
+		AddParam("slug", "area").
+		ExecutePostAsJSON(apiv1.CreateEditTagGroup(), `{ "name": "Area", "isSingleSelect": true }`)
+
+	Expect(status).Equals(http.StatusBadRequest)
+	Expect(countConflicts.GroupID).Equals(4)
+	Expect(countConflicts.TagID).Equals(0)
+}
+
+func TestEditTagHandler_MoveIntoSingleSelectGroupWithConflicts(t *testing.T) {
+	RegisterT(t)
+
+	tag := &entity.Tag{ID: 5, Name: "Android", Slug: "android", Color: "0000FF", IsPublic: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if q.Slug == "android" {
+			q.Result = tag
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		q.Result = &entity.TagGroup{ID: 7, Name: "Platform", Slug: "platform", IsSingleSelect: true}
+		return nil
+	})
+
+	var countConflicts *query.CountPostsWithManyGroupTags
+	bus.AddHandler(func(ctx context.Context, q *query.CountPostsWithManyGroupTags) error {
+		countConflicts = q
+		q.Result = 1
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		AsUser(mock.JonSnow).
+		AddParam("slug", "android").
+		ExecutePost(apiv1.CreateEditTag(), `{ "name": "Android", "color": "0000FF", "isPublic": true, "group": "platform" }`)
+
+	Expect(status).Equals(http.StatusBadRequest)
+	Expect(countConflicts.GroupID).Equals(7)
+	Expect(countConflicts.TagID).Equals(5)
+}
+
+func TestDeleteTagGroupHandler(t *testing.T) {
+	RegisterT(t)
+
+	group := &entity.TagGroup{ID: 4, Name: "Area", Slug: "area"}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagGroupBySlug) error {
+		q.Result = group
+		return nil
+	})
+
+	var deleteGroup *cmd.DeleteTagGroup
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteTagGroup) error {
+		deleteGroup = c
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		AsUser(mock.JonSnow).
+		AddParam("slug", "area").
+		Execute(apiv1.DeleteTagGroup())
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(deleteGroup.Group).Equals(group)
+}
+
+func TestTagGroupHandlers_Collaborator(t *testing.T) {
+	RegisterT(t)
+
+	status, _ := mock.NewServer().
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.CreateEditTagGroup(), `{ "name": "Platform" }`)
+	Expect(status).Equals(http.StatusForbidden)
+
+	status, _ = mock.NewServer().
+		AsUser(mock.AryaStark).
+		AddParam("slug", "area").
+		Execute(apiv1.DeleteTagGroup())
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestMergeTagsHandler(t *testing.T) {
+	RegisterT(t)
+
+	mobile := &entity.Tag{ID: 3, Name: "Mobile", Slug: "mobile", Color: "0000FF", IsPublic: true}
+	mobileApp := &entity.Tag{ID: 5, Name: "Mobile App", Slug: "mobile-app", Color: "0000FF", IsPublic: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if q.Slug == "mobile" {
+			q.Result = mobile
+			return nil
+		} else if q.Slug == "mobile-app" {
+			q.Result = mobileApp
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	var mergeTags *cmd.MergeTags
+	bus.AddHandler(func(ctx context.Context, c *cmd.MergeTags) error {
+		mergeTags = c
+		return nil
+	})
+
+	status, query := mock.NewServer().
+		AsUser(mock.JonSnow).
+		AddParam("slug", "mobile").
+		ExecutePostAsJSON(apiv1.MergeTags(), `{ "into": "mobile-app" }`)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(mergeTags.From).Equals(mobile)
+	Expect(mergeTags.Into).Equals(mobileApp)
+	Expect(query.String("slug")).Equals("mobile-app")
+}
+
+func TestMergeTagsHandler_InvalidRequests(t *testing.T) {
+	RegisterT(t)
+
+	mobile := &entity.Tag{ID: 3, Name: "Mobile", Slug: "mobile", Color: "0000FF", IsPublic: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		if q.Slug == "mobile" {
+			q.Result = mobile
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	var testCases = []string{
+		`{ }`,
+		`{ "into": "" }`,
+		`{ "into": "unknown" }`,
+		`{ "into": "mobile" }`,
+	}
+
+	for _, testCase := range testCases {
+		status, _ := mock.NewServer().
+			AsUser(mock.JonSnow).
+			AddParam("slug", "mobile").
+			ExecutePostAsJSON(apiv1.MergeTags(), testCase)
+
+		Expect(status).Equals(http.StatusBadRequest)
+	}
+}
+
+func TestMergeTagsHandler_IntoDescendant(t *testing.T) {
+	RegisterT(t)
+
+	mobile := &entity.Tag{ID: 3, Name: "Mobile", Slug: "mobile", Color: "0000FF", IsPublic: true}
+	ios := &entity.Tag{ID: 4, Name: "iOS", Slug: "ios", Color: "0000FF", IsPublic: true, ParentID: 3}
+	ipad := &entity.Tag{ID: 5, Name: "iPad", Slug: "ipad", Color: "0000FF", IsPublic: true, ParentID: 4}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		for _, tag := range []*entity.Tag{mobile, ios, ipad} {
+			if tag.Slug == q.Slug {
+				q.Result = tag
+				return nil
+			}
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTags) error {
+		q.Result = []*entity.Tag{mobile, ios, ipad}
+		return nil
+	})
+
+	for _, into := range []string{"ios", "ipad"} {
+		status, _ := mock.NewServer().
+			AsUser(mock.JonSnow).
+			AddParam("slug", "mobile").
+			ExecutePostAsJSON(apiv1.MergeTags(), `{ "into": "`+into+`" }`)
+
+		Expect(status).Equals(http.StatusBadRequest)
+	}
+}
+
+func TestMergeTagsHandler_SingleSelectGroupConflict(t *testing.T) {
+	RegisterT(t)
+
+	mobile := &entity.Tag{ID: 3, Name: "Mobile", Slug: "mobile", Color: "0000FF", IsPublic: true}
+	web := &entity.Tag{ID: 5, Name: "Web", Slug: "web", Color: "0000FF", IsPublic: true, GroupID: 2}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		for _, tag := range []*entity.Tag{mobile, web} {
+			if tag.Slug == q.Slug {
+				q.Result = tag
+				return nil
+			}
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTagGroups) error {
+		q.Result = []*entity.TagGroup{{ID: 2, Name: "Platform", Slug: "platform", IsSingleSelect: true}}
+		return nil
+	})
+
+	var countConflicts *query.CountPostsWithManyGroupTags
+	bus.AddHandler(func(ctx context.Context, q *query.CountPostsWithManyGroupTags) error {
+		countConflicts = q
+		q.Result = 2
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		AsUser(mock.JonSnow).
+		AddParam("slug", "mobile").
+		ExecutePostAsJSON(apiv1.MergeTags(), `{ "into": "web" }`)
+
+	Expect(status).Equals(http.StatusBadRequest)
+	Expect(countConflicts.GroupID).Equals(2)
+	Expect(countConflicts.TagID).Equals(3)
+	Expect(countConflicts.MergedIntoID).Equals(5)
+}
+
+func TestMergeTagsHandler_Collaborator(t *testing.T) {
+	RegisterT(t)
+
+	status, _ := mock.NewServer().
+		AsUser(mock.AryaStark).
+		AddParam("slug", "mobile").
+		ExecutePost(apiv1.MergeTags(), `{ "into": "mobile-app" }`)
+
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestGetTagStatsHandler(t *testing.T) {
+	RegisterT(t)
+
+	tag := &entity.Tag{ID: 2, Name: "Bug", Slug: "bug", Color: "0000FF", IsPublic: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagStats) error {
+		q.Result = []*entity.TagStats{
+			{Tag: tag, PostCount: 4, VotesCount: 10, OpenCount: 3, CompletedCount: 1, OpenRatio: 0.75, CompletedRatio: 0.25},
+		}
+		return nil
+	})
+
+	status, query := mock.NewServer().
+		AsUser(mock.AryaStark).
+		ExecuteAsJSON(apiv1.GetTagStats())
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(query.IsArray()).IsTrue()
+	Expect(query.ArrayLength()).Equals(1)
+}
+
+func TestSubscribeToTagHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = &entity.Tag{ID: 1, Slug: "accessibility", Name: "Accessibility", IsPublic: true}
+		return nil
+	})
+
+	var subscribe *cmd.SubscribeToTag
+	bus.AddHandler(func(ctx context.Context, c *cmd.SubscribeToTag) error {
+		subscribe = c
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("slug", "accessibility").
+		ExecutePost(apiv1.SubscribeToTag(), `{ "channels": 3, "includeComments": true }`)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(subscribe.Tag.ID).Equals(1)
+	Expect(subscribe.Channels).Equals(enum.NotificationChannelWeb | enum.NotificationChannelEmail)
+	Expect(subscribe.IncludeComments).IsTrue()
+}
+
+func TestSubscribeToTagHandler_InvalidChannels(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = &entity.Tag{ID: 1, Slug: "accessibility", Name: "Accessibility", IsPublic: true}
+		return nil
+	})
+
+	for _, input := range []string{`{ }`, `{ "channels": 4 }`, `{ "channels": -1 }`} {
+		status, _ := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			AsUser(mock.AryaStark).
+			AddParam("slug", "accessibility").
+			ExecutePost(apiv1.SubscribeToTag(), input)
+
+		Expect(status).Equals(http.StatusBadRequest)
+	}
+}
+
+func TestSubscribeToTagHandler_PrivateTag(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = &entity.Tag{ID: 2, Slug: "security", Name: "Security", IsPublic: false}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.SubscribeToTag) error { return nil })
+
+	status, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("slug", "security").
+		ExecutePost(apiv1.SubscribeToTag(), `{ "channels": 1 }`)
+
+	Expect(status).Equals(http.StatusNotFound)
+
+	status, _ = mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("slug", "security").
+		ExecutePost(apiv1.SubscribeToTag(), `{ "channels": 1 }`)
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestUnsubscribeFromTagHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = &entity.Tag{ID: 1, Slug: "accessibility", Name: "Accessibility", IsPublic: true}
+		return nil
+	})
+
+	var unsubscribe *cmd.UnsubscribeFromTag
+	bus.AddHandler(func(ctx context.Context, c *cmd.UnsubscribeFromTag) error {
+		unsubscribe = c
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("slug", "accessibility").
+		ExecutePost(apiv1.UnsubscribeFromTag(), `{ }`)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(unsubscribe.Tag.ID).Equals(1)
+}
@@ -56,9 +56,10 @@ func ListUsers() web.HandlerFunc {
 	}
 }
 
+// ListTaggableUsers returns the names of users that can be mentioned, only staff on internal notes
 func ListTaggableUsers() web.HandlerFunc {
 	return func(c *web.Context) error {
-		allUsers := &query.GetAllUsersNames{}
+		allUsers := &query.GetAllUsersNames{OnlyStaff: c.QueryParam("internal") == "true"}
 		if err := bus.Dispatch(c, allUsers); err != nil {
 			return c.Failure(err)
 		}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/getfider/fider/app/handlers/apiv1"
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
@@ -41,6 +42,32 @@ func TestListUsersHandler(t *testing.T) {
 	Expect(query.Contains("users")).IsTrue()
 }
 
+func TestListTaggableUsersHandler(t *testing.T) {
+	RegisterT(t)
+
+	var getAllUsersNames *query.GetAllUsersNames
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllUsersNames) error {
+		getAllUsersNames = q
+		q.Result = []*dto.UserNames{{Name: "Jon Snow"}}
+		return nil
+	})
+
+	status, _ := mock.NewServer().
+		AsUser(mock.AryaStark).
+		ExecuteAsJSON(apiv1.ListTaggableUsers())
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(getAllUsersNames.OnlyStaff).IsFalse()
+
+	status, _ = mock.NewServer().
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/taggable-users?internal=true").
+		ExecuteAsJSON(apiv1.ListTaggableUsers())
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(getAllUsersNames.OnlyStaff).IsTrue()
+}
+
 func TestCreateUser_ExistingEmail(t *testing.T) {
 	RegisterT(t)
 
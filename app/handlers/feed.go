@@ -105,12 +105,23 @@ func generatePostContent(c *web.Context, post *entity.Post, options *generatorOp
 		responseFooter := ""
 		if (post.Response != nil) && (post.Response.Text != "") {
 			responseFooter = i18n.T(c, "feed.post.footer.response", i18n.Params{
-				"responder": post.Response.User.Name,
+				"responder": responderName(c, post.Response),
 				"date":      post.Response.RespondedAt.Format("Jan 2, 2006"),
 				"response":  strings.ReplaceAll(post.Response.Text, "\n", "\n>"),
 			})
 		}
 
+		if post.AcceptedAnswer != nil {
+			answerAuthor := ""
+			if post.AcceptedAnswer.User != nil {
+				answerAuthor = post.AcceptedAnswer.User.Name
+			}
+			responseFooter += i18n.T(c, "feed.post.footer.answer", i18n.Params{
+				"author": answerAuthor,
+				"answer": strings.ReplaceAll(entity.CommentString(post.AcceptedAnswer.Content).SanitizeMentions(), "\n", "\n>"),
+			})
+		}
+
 		footer = i18n.T(c, "feed.post.footer", i18n.Params{
 			"response_footer": responseFooter,
 			"votes":           post.VotesCount,
@@ -123,6 +134,14 @@ func generatePostContent(c *web.Context, post *entity.Post, options *generatorOp
 	return string(markdown.Full(title+post.Description+footer, true))
 }
 
+// responderName returns who gave the response, the site itself when it was given by the system
+func responderName(c *web.Context, response *entity.PostResponse) string {
+	if response.User == nil {
+		return c.Tenant().Name
+	}
+	return response.User.Name
+}
+
 func appendTags(c *web.Context, categories []*Category, post *entity.Post) ([]*Category, error) {
 	getAssignedTags := &query.GetAssignedTags{Post: post}
 	if err := bus.Dispatch(c, getAssignedTags); err != nil {
@@ -139,7 +158,8 @@ func appendTags(c *web.Context, categories []*Category, post *entity.Post) ([]*C
 	return categories, nil
 }
 
-// GlobalFeed Returns the global ATOM feed with the 30 most recent posts as entries
+// GlobalFeed Returns the global ATOM feed with the 30 most recent posts as entries.
+// When used with a board path, only posts of that board are included
 func GlobalFeed() web.HandlerFunc {
 	return func(c *web.Context) error {
 		if c.Tenant().IsPrivate || !c.Tenant().IsFeedEnabled {
@@ -152,18 +172,47 @@ func GlobalFeed() web.HandlerFunc {
 			Limit: "30",
 			Tags:  c.QueryParamAsArray("tags"),
 		}
+
+		title := c.Tenant().Name
+		welcomeMessage := c.Tenant().WelcomeMessage
+		selfLink := fmt.Sprintf("%s/feed/global.atom", web.BaseURL(c))
+		alternateLink := web.BaseURL(c)
+		if path := c.Param("path"); path != "" {
+			boardSlug, found := strings.CutSuffix(path, ".atom")
+			if !found {
+				return c.NotFound()
+			}
+
+			getBoard := &query.GetBoardBySlug{Slug: boardSlug}
+			if err := bus.Dispatch(c, getBoard); err != nil {
+				return c.Failure(err)
+			}
+			board := getBoard.Result
+			if board.IsPrivate {
+				return c.NotFound()
+			}
+
+			searchPosts.Board = board.Slug
+			title = fmt.Sprintf("%s - %s", c.Tenant().Name, board.Name)
+			if board.WelcomeMessage != "" {
+				welcomeMessage = board.WelcomeMessage
+			}
+			selfLink = fmt.Sprintf("%s/feed/boards/%s.atom", web.BaseURL(c), board.Slug)
+			alternateLink = fmt.Sprintf("%s/boards/%s", web.BaseURL(c), board.Slug)
+		}
+
 		if err := bus.Dispatch(c, searchPosts); err != nil {
 			return c.Failure(err)
 		}
 		posts := searchPosts.Result
 
 		feed := &AtomFeed{
-			Title:    c.Tenant().Name,
-			Subtitle: Content{Body: string(markdown.Full(c.Tenant().WelcomeMessage, true)), Type: "html"},
-			Id:       web.BaseURL(c),
+			Title:    title,
+			Subtitle: Content{Body: string(markdown.Full(welcomeMessage, true)), Type: "html"},
+			Id:       alternateLink,
 			Link: []Link{
-				{Href: fmt.Sprintf("%s/feed/global.atom", web.BaseURL(c)), Type: "application/atom+xml", Rel: "self"},
-				{Href: web.BaseURL(c), Type: "text/html", Rel: "alternate"},
+				{Href: selfLink, Type: "application/atom+xml", Rel: "self"},
+				{Href: alternateLink, Type: "text/html", Rel: "alternate"},
 			},
 			Entries: []*Entry{},
 		}
@@ -242,7 +291,7 @@ func CommentFeed() web.HandlerFunc {
 			return c.NotFound()
 		}
 
-		getComments := &query.GetCommentsByPost{Post: getPost.Result}
+		getComments := &query.GetCommentsByPost{Post: getPost.Result, PublicOnly: true}
 		if err := bus.Dispatch(c, getComments); err != nil {
 			return c.Failure(err)
 		}
@@ -290,9 +339,9 @@ func CommentFeed() web.HandlerFunc {
 		if (post.Response != nil) && (post.Response.Text != "") {
 			feed.Entries = append(feed.Entries, &Entry{
 				Title: i18n.T(c, "feed.comment.response", i18n.Params{
-					"author": post.Response.User.Name,
+					"author": responderName(c, post.Response),
 				}),
-				Author:    &Author{Name: post.Response.User.Name},
+				Author:    &Author{Name: responderName(c, post.Response)},
 				Published: formatTime(post.Response.RespondedAt),
 				Updated:   formatTime(post.Response.RespondedAt), // so that it shows as "updated" on edit / new response
 				Id:        fmt.Sprintf("%s/posts/%d/#response", web.BaseURL(c), post.Number),
@@ -313,8 +362,13 @@ func CommentFeed() web.HandlerFunc {
 				lastUpdate = *comment.EditedAt
 			}
 
+			titleKey := "feed.comment.title"
+			if post.AcceptedAnswer != nil && post.AcceptedAnswer.CommentID == comment.ID {
+				titleKey = "feed.comment.answer"
+			}
+
 			feed.Entries = append(feed.Entries, &Entry{
-				Title: i18n.T(c, "feed.comment.title", i18n.Params{
+				Title: i18n.T(c, titleKey, i18n.Params{
 					"author": comment.User.Name,
 				}),
 				Author:    &Author{Name: comment.User.Name},
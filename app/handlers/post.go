@@ -3,25 +3,76 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/csv"
 	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/markdown"
 	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/tasks"
 )
 
-// Index is the default home page
+// Index is the default home page, or the home page of a board when a board slug is given
 func Index() web.HandlerFunc {
 	return func(c *web.Context) error {
 		c.SetCanonicalURL("")
 
 		searchPosts := &query.SearchPosts{
-			Query: c.QueryParam("query"),
-			View:  c.QueryParam("view"),
-			Limit: c.QueryParam("limit"),
-			Tags:  c.QueryParamAsArray("tags"),
+			Query:     c.QueryParam("query"),
+			View:      c.QueryParam("view"),
+			Limit:     c.QueryParam("limit"),
+			Tags:      c.QueryParamAsArray("tags"),
+			LinkType:  c.QueryParam("linktype"),
+			LinkState: c.QueryParam("linkstate"),
+			Answered:  c.QueryParam("answered"),
+		}
+
+		// Old slugs of renamed or merged tags redirect to the current ones
+		if len(searchPosts.Tags) > 0 {
+			getRedirects := &query.GetTagSlugRedirects{Slugs: searchPosts.Tags}
+			if err := bus.Dispatch(c, getRedirects); err != nil {
+				return c.Failure(err)
+			}
+
+			if len(getRedirects.Result) > 0 {
+				tags := make([]string, len(searchPosts.Tags))
+				for i, tag := range searchPosts.Tags {
+					tags[i] = tag
+					if newSlug, ok := getRedirects.Result[tag]; ok {
+						tags[i] = newSlug
+					}
+				}
+
+				redirectURL := *c.Request.URL
+				values := redirectURL.Query()
+				values.Set("tags", strings.Join(tags, ","))
+				redirectURL.RawQuery = values.Encode()
+				return c.Redirect(redirectURL.String())
+			}
+		}
+
+		var board *entity.Board
+		if boardSlug := c.Param("slug"); boardSlug != "" {
+			getBoard := &query.GetBoardBySlug{Slug: boardSlug}
+			if err := bus.Dispatch(c, getBoard); err != nil {
+				return c.Failure(err)
+			}
+			board = getBoard.Result
+
+			// Private boards and board staff are only shown to staff, the same way boards are listed
+			isStaff := c.IsAuthenticated() && c.User().IsCollaborator()
+			if board.IsPrivate && !isStaff {
+				return c.NotFound()
+			}
+			if !isStaff {
+				board.StaffIDs = []int{}
+			}
+
+			searchPosts.Board = board.Slug
+			c.SetCanonicalURL(fmt.Sprintf("/boards/%s", board.Slug))
 		}
 
 		if myVotesOnly, err := c.QueryParamAsBool("myvotes"); err == nil {
@@ -56,13 +107,17 @@ func Index() web.HandlerFunc {
 		searchPosts.SetStatusesFromStrings(actualStatuses)
 		getAllTags := &query.GetAllTags{}
 		countPerStatus := &query.CountPostPerStatus{}
+		getAllBoards := &query.GetAllBoards{}
+		getAllTagGroups := &query.GetAllTagGroups{}
 
-		if err := bus.Dispatch(c, searchPosts, getAllTags, countPerStatus); err != nil {
+		if err := bus.Dispatch(c, searchPosts, getAllTags, countPerStatus, getAllBoards, getAllTagGroups); err != nil {
 			return c.Failure(err)
 		}
 
 		description := ""
-		if c.Tenant().WelcomeMessage != "" {
+		if board != nil && board.WelcomeMessage != "" {
+			description = markdown.PlainText(board.WelcomeMessage)
+		} else if c.Tenant().WelcomeMessage != "" {
 			description = markdown.PlainText(c.Tenant().WelcomeMessage)
 		} else {
 			description = "We'd love to hear what you're thinking about. What can we do better? This is the place for you to vote, discuss and share posts."
@@ -72,7 +127,12 @@ func Index() web.HandlerFunc {
 			"searchNoiseWords": env.SearchNoiseWords(),
 			"posts":            searchPosts.Result,
 			"tags":             getAllTags.Result,
+			"tagGroups":        getAllTagGroups.Result,
 			"countPerStatus":   countPerStatus.Result,
+			"boards":           getAllBoards.Result,
+		}
+		if board != nil {
+			data["board"] = board
 		}
 
 		return c.Page(http.StatusOK, web.Props{
@@ -102,26 +162,43 @@ func PostDetails() web.HandlerFunc {
 		}
 
 		isSubscribed := &query.UserSubscribedTo{PostID: getPost.Result.ID}
-		getComments := &query.GetCommentsByPost{Post: getPost.Result}
+		getComments := &query.GetCommentsByPost{Post: getPost.Result, IncludeTombstones: true}
 		getAllTags := &query.GetAllTags{}
 		listVotes := &query.ListPostVotes{PostID: getPost.Result.ID, Limit: 24, IncludeEmail: false}
 		getAttachments := &query.GetAttachments{Post: getPost.Result}
-		if err := bus.Dispatch(c, getAllTags, getComments, listVotes, isSubscribed, getAttachments); err != nil {
+		getAllTagGroups := &query.GetAllTagGroups{}
+		if err := bus.Dispatch(c, getAllTags, getComments, listVotes, isSubscribed, getAttachments, getAllTagGroups); err != nil {
 			return c.Failure(err)
 		}
 
+		data := web.Map{
+			"comments":    entity.NestComments(getComments.Result),
+			"subscribed":  isSubscribed.Result,
+			"post":        getPost.Result,
+			"tags":        getAllTags.Result,
+			"tagGroups":   getAllTagGroups.Result,
+			"votes":       listVotes.Result,
+			"attachments": getAttachments.Result,
+		}
+
+		// Pending scheduled responses and drafts are only visible to staff
+		if c.IsAuthenticated() && c.User().IsCollaborator() {
+			getScheduledResponses := &query.GetScheduledResponses{PostID: getPost.Result.ID}
+			if err := bus.Dispatch(c, getScheduledResponses); err != nil {
+				return c.Failure(err)
+			}
+			data["scheduledResponses"] = getScheduledResponses.Result
+		}
+
+		if c.SessionID() != "" && !c.Request.IsCrawler() {
+			c.Enqueue(tasks.RecordPostView(getPost.Result, c.SessionID()))
+		}
+
 		return c.Page(http.StatusOK, web.Props{
 			Page:        "ShowPost/ShowPost.page",
 			Title:       getPost.Result.Title,
 			Description: markdown.PlainText(getPost.Result.Description),
-			Data: web.Map{
-				"comments":    getComments.Result,
-				"subscribed":  isSubscribed.Result,
-				"post":        getPost.Result,
-				"tags":        getAllTags.Result,
-				"votes":       listVotes.Result,
-				"attachments": getAttachments.Result,
-			},
+			Data:        data,
 		})
 	}
 }
@@ -135,7 +212,12 @@ func ExportPostsToCSV() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
-		bytes, err := csv.FromPosts(allPosts.Result)
+		allFields := &query.GetAllCustomFields{}
+		if err := bus.Dispatch(c, allFields); err != nil {
+			return c.Failure(err)
+		}
+
+		bytes, err := csv.FromPosts(allPosts.Result, allFields.Result)
 		if err != nil {
 			return c.Failure(err)
 		}
@@ -143,3 +225,21 @@ func ExportPostsToCSV() web.HandlerFunc {
 		return c.Attachment("posts.csv", "text/csv", bytes)
 	}
 }
+
+// ExportPollsToCSV returns a CSV with the results of all polls
+func ExportPollsToCSV() web.HandlerFunc {
+	return func(c *web.Context) error {
+
+		allPolls := &query.GetAllPolls{}
+		if err := bus.Dispatch(c, allPolls); err != nil {
+			return c.Failure(err)
+		}
+
+		bytes, err := csv.FromPolls(allPolls.Result)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Attachment("polls.csv", "text/csv", bytes)
+	}
+}
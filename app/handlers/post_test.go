@@ -27,10 +27,18 @@ func TestIndexHandler(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTagGroups) error {
+		return nil
+	})
+
 	bus.AddHandler(func(ctx context.Context, q *query.SearchPosts) error {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllBoards) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 	code, _ := server.OnTenant(mock.DemoTenant).
 		AsUser(mock.JonSnow).
@@ -39,6 +47,97 @@ func TestIndexHandler(t *testing.T) {
 	Expect(code).Equals(http.StatusOK)
 }
 
+func TestIndexHandler_Board(t *testing.T) {
+	RegisterT(t)
+
+	board := &entity.Board{ID: 1, Name: "IT Services", Slug: "it-services", WelcomeMessage: "Report anything IT related."}
+	bus.AddHandler(func(ctx context.Context, q *query.GetBoardBySlug) error {
+		switch q.Slug {
+		case board.Slug:
+			q.Result = &entity.Board{ID: board.ID, Name: board.Name, Slug: board.Slug, WelcomeMessage: board.WelcomeMessage, StaffIDs: []int{mock.JonSnow.ID}}
+		case "hr":
+			q.Result = &entity.Board{ID: 2, Name: "HR", Slug: "hr", IsPrivate: true, StaffIDs: []int{mock.JonSnow.ID}}
+		default:
+			return app.ErrNotFound
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.CountPostPerStatus) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTags) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTagGroups) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllBoards) error {
+		q.Result = []*entity.Board{board}
+		return nil
+	})
+
+	var searchedBoard string
+	bus.AddHandler(func(ctx context.Context, q *query.SearchPosts) error {
+		searchedBoard = q.Board
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, page := server.OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("slug", "it-services").
+		ExecuteAsPage(handlers.Index())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(searchedBoard).Equals("it-services")
+	Expect(page.Description).Equals("Report anything IT related.")
+
+	code, page = mock.NewServer().OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("slug", "it-services").
+		ExecuteAsPage(handlers.Index())
+	Expect(code).Equals(http.StatusOK)
+	Expect(page.Data["board"].(map[string]any)["staffIds"]).HasLen(0)
+
+	code, _ = mock.NewServer().OnTenant(mock.DemoTenant).
+		AddParam("slug", "unknown").
+		Execute(handlers.Index())
+	Expect(code).Equals(http.StatusNotFound)
+
+	code, _ = mock.NewServer().OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("slug", "hr").
+		Execute(handlers.Index())
+	Expect(code).Equals(http.StatusNotFound)
+
+	code, _ = mock.NewServer().OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("slug", "hr").
+		Execute(handlers.Index())
+	Expect(code).Equals(http.StatusOK)
+}
+
+func TestIndexHandler_RedirectsOldTagSlugs(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagSlugRedirects) error {
+		q.Result = map[string]string{"mobile": "mobile-app"}
+		return nil
+	})
+
+	code, response := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/?tags=bug,mobile&view=trending").
+		Execute(handlers.Index())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("http://demo.test.fider.io/?tags=bug%2Cmobile-app&view=trending")
+}
+
 func TestDetailsHandler(t *testing.T) {
 	RegisterT(t)
 
@@ -65,10 +164,18 @@ func TestDetailsHandler(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetAllTagGroups) error {
+		return nil
+	})
+
 	bus.AddHandler(func(ctx context.Context, q *query.UserSubscribedTo) error {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetScheduledResponses) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 
 	code, _ := server.
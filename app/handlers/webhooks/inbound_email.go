@@ -0,0 +1,226 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/subtle"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/metrics"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/validate"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/worker"
+	"github.com/getfider/fider/app/services/email"
+	"github.com/getfider/fider/app/tasks"
+)
+
+// ErrReplyRejected is returned when an inbound email can't be added as a comment
+// Senders should not retry these emails
+var ErrReplyRejected = errors.New("email reply rejected")
+
+// IncomingEmailWebhook adds replies to notification emails as comments
+// It accepts either the raw MIME message or the form fields posted by Mailgun routes
+func IncomingEmailWebhook() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if !email.IsReplyEnabled() {
+			return c.NotFound()
+		}
+
+		secret := c.Request.GetHeader("X-Inbound-Secret")
+		if secret == "" {
+			secret = c.QueryParam("secret")
+		}
+		if !IsValidInboundSecret(env.Config.Email.Inbound.Secret, secret) {
+			return c.Unauthorized()
+		}
+
+		msg, err := readInboundEmail(c)
+		if err != nil {
+			log.Warnf(c, "Invalid inbound email: @{Error}", dto.Props{
+				"Error": err.Error(),
+			})
+			return c.BadRequest(web.Map{})
+		}
+
+		err = ReceiveEmailReply(c, msg)
+		if errors.Cause(err) == ErrReplyRejected {
+			log.Warnf(c, "Inbound email from '@{From}' was ignored: @{Error}", dto.Props{
+				"From":  msg.From,
+				"Error": err.Error(),
+			})
+			return c.Ok(web.Map{})
+		} else if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// IsValidInboundSecret returns true if secret matches the configured inbound email secret
+func IsValidInboundSecret(expected, secret string) bool {
+	if expected == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(secret)) == 1
+}
+
+// readInboundEmail parses the request body, either a raw MIME message or a Mailgun route form
+func readInboundEmail(c *web.Context) (*email.InboundMessage, error) {
+	contentType := c.Request.GetHeader("Content-Type")
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+
+	var form url.Values
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(c.Request.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse inbound form")
+		}
+		form = values
+	case "multipart/form-data":
+		reader := multipart.NewReader(strings.NewReader(c.Request.Body), params["boundary"])
+		values, err := reader.ReadForm(10 << 20)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse inbound form")
+		}
+		form = values.Value
+	default:
+		return email.ParseInboundMessage([]byte(c.Request.Body))
+	}
+
+	// Mailgun posts the full message when the route forwards to a URL ending with 'mime'
+	if raw := form.Get("body-mime"); raw != "" {
+		msg, err := email.ParseInboundMessage([]byte(raw))
+		if err != nil {
+			return nil, err
+		}
+		if recipient := form.Get("recipient"); recipient != "" {
+			msg.Recipients = []string{recipient}
+		}
+		return msg, nil
+	}
+
+	from := form.Get("sender")
+	if address, err := mail.ParseAddress(form.Get("from")); err == nil && from == "" {
+		from = address.Address
+	}
+
+	return &email.InboundMessage{
+		From:       from,
+		Recipients: strings.Split(form.Get("recipient"), ","),
+		Text:       email.StripReply(form.Get("body-plain")),
+	}, nil
+}
+
+// ReceiveEmailReply adds an inbound email as a comment on behalf of the user its reply address was signed for
+func ReceiveEmailReply(ctx context.Context, msg *email.InboundMessage) error {
+	var token *email.ReplyToken
+	for _, recipient := range msg.Recipients {
+		if t, ok := email.ParseReplyAddress(strings.TrimSpace(recipient)); ok {
+			token = t
+			break
+		}
+	}
+	if token == nil {
+		return errors.Wrap(ErrReplyRejected, "no valid reply address")
+	}
+
+	// Inbound emails only reach the tenant of the current host, if any
+	if current, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant); ok && current != nil && current.ID != token.TenantID {
+		return errors.Wrap(ErrReplyRejected, "reply address belongs to another tenant")
+	}
+
+	getTenant := &query.GetTenantByID{TenantID: token.TenantID}
+	if err := bus.Dispatch(ctx, getTenant); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return errors.Wrap(ErrReplyRejected, "tenant not found")
+		}
+		return err
+	}
+
+	tenant := getTenant.Result
+	if tenant.Status != enum.TenantActive {
+		return errors.Wrap(ErrReplyRejected, "tenant is not active")
+	}
+	ctx = context.WithValue(ctx, app.TenantCtxKey, tenant)
+	ctx = context.WithValue(ctx, app.LocaleCtxKey, tenant.Locale)
+	if _, ok := ctx.Value(app.RequestCtxKey).(web.Request); !ok {
+		ctx = context.WithValue(ctx, app.RequestCtxKey, web.Request{URL: web.TenantURL(tenant)})
+	}
+
+	// Reply addresses can be forwarded, so the sender must also be who the address was signed for
+	getUser := &query.GetUserByEmail{Email: msg.From}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return errors.Wrap(ErrReplyRejected, "sender is not a known user")
+		}
+		return err
+	}
+
+	user := getUser.Result
+	if user.ID != token.UserID || user.Status != enum.UserActive {
+		return errors.Wrap(ErrReplyRejected, "sender doesn't match reply address")
+	}
+	ctx = context.WithValue(ctx, app.UserCtxKey, user)
+
+	getPost := &query.GetPostByID{PostID: token.PostID}
+	if err := bus.Dispatch(ctx, getPost); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return errors.Wrap(ErrReplyRejected, "post not found")
+		}
+		return err
+	}
+	post := getPost.Result
+
+	action := &actions.AddNewComment{Number: post.Number, Content: msg.Text}
+	if !action.IsAuthorized(ctx, user) {
+		return errors.Wrap(ErrReplyRejected, "user can't comment on this post")
+	}
+	if result := action.Validate(ctx, user); result.Err != nil {
+		return result.Err
+	} else if !result.Ok {
+		return errors.Wrap(ErrReplyRejected, "invalid comment: %s", validationMessages(result))
+	}
+
+	// Comments are moderated as usual, depending on the user
+	addNewComment := &cmd.AddNewComment{Post: post, Content: action.Content}
+	if err := bus.Dispatch(ctx, addNewComment); err != nil {
+		return err
+	}
+
+	// For processing, restore the original content
+	addNewComment.Result.Content = action.Content
+
+	metrics.TotalComments.Inc()
+	log.Debugf(ctx, "Email reply from user '@{UserID}' added as comment on post '@{PostID}'", dto.Props{
+		"UserID": user.ID,
+		"PostID": post.ID,
+	})
+
+	task := tasks.NotifyAboutNewComment(addNewComment.Result, post)
+	task.OriginContext = ctx
+	return task.Job(worker.NewContext(ctx, "inbound-email", task))
+}
+
+func validationMessages(result *validate.Result) string {
+	messages := make([]string, 0)
+	for _, item := range result.Errors {
+		messages = append(messages, item.Message)
+	}
+	return strings.Join(messages, ", ")
+}
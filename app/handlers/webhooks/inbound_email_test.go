@@ -0,0 +1,179 @@
+package webhooks_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/handlers/webhooks"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/services/email"
+	"github.com/getfider/fider/app/services/email/emailmock"
+)
+
+func replyEmail(from, to, text string) string {
+	return strings.Join([]string{
+		"From: " + from,
+		"To: " + to,
+		"Subject: Re: [Demonstration] Add support for TypeScript",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		text,
+		"",
+		"On Mon, Oct 19, 2026 at 10:00 AM Fider <noreply@fider.io> wrote:",
+		"> Jon Snow left a comment on Add support for TypeScript",
+		"",
+	}, "\r\n")
+}
+
+func mockReplyQueries(post *entity.Post) *[]*cmd.AddNewComment {
+	bus.Init(emailmock.Service{})
+	env.Config.Email.Inbound.Domain = "reply.test.fider.io"
+	env.Config.Email.Inbound.Secret = "s3cr3t"
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantByID) error {
+		q.Result = mock.DemoTenant
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByEmail) error {
+		for _, user := range []*entity.User{mock.JonSnow, mock.AryaStark} {
+			if user.Email == q.Email {
+				q.Result = user
+				return nil
+			}
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		q.Result = post
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+
+	added := make([]*cmd.AddNewComment, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewComment) error {
+		c.Result = &entity.Comment{ID: 9, Content: c.Content, User: mock.AryaStark}
+		added = append(added, c)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetMentionNotifications) error {
+		q.Result = []*entity.MentionNotification{}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.JonSnow}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		return nil
+	})
+
+	return &added
+}
+
+func TestIncomingEmailWebhook_InvalidSecret(t *testing.T) {
+	RegisterT(t)
+	env.Config.Email.Inbound.Domain = "reply.test.fider.io"
+	env.Config.Email.Inbound.Secret = "s3cr3t"
+
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddHeader("X-Inbound-Secret", "wrong").
+		ExecutePost(webhooks.IncomingEmailWebhook(), "")
+
+	Expect(code).Equals(http.StatusUnauthorized)
+}
+
+func TestIncomingEmailWebhook_AddComment(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Add support for TypeScript", Slug: "add-support-for-typescript", User: mock.JonSnow}
+	added := mockReplyQueries(post)
+
+	to := email.ReplyAddress(mock.DemoTenant.ID, mock.AryaStark.ID, post.ID)
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddHeader("X-Inbound-Secret", "s3cr3t").
+		ExecutePost(webhooks.IncomingEmailWebhook(), replyEmail("Arya Stark <arya.stark@got.com>", to, "I agree, this would be great!"))
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(*added).HasLen(1)
+	Expect((*added)[0].Post).Equals(post)
+	Expect((*added)[0].Content).Equals("I agree, this would be great!")
+	Expect((*added)[0].IsInternal).IsFalse()
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("new_comment")
+	Expect(emailmock.MessageHistory[0].From.Name).Equals("Arya Stark")
+	Expect(emailmock.MessageHistory[0].To[0].Address).Equals(mock.JonSnow.Email)
+}
+
+func TestIncomingEmailWebhook_SenderMismatch(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Add support for TypeScript", Slug: "add-support-for-typescript", User: mock.JonSnow}
+	added := mockReplyQueries(post)
+
+	// A notification sent to Arya, forwarded to and replied by Jon
+	to := email.ReplyAddress(mock.DemoTenant.ID, mock.AryaStark.ID, post.ID)
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddHeader("X-Inbound-Secret", "s3cr3t").
+		ExecutePost(webhooks.IncomingEmailWebhook(), replyEmail("jon.snow@got.com", to, "I agree"))
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(*added).HasLen(0)
+	Expect(emailmock.MessageHistory).HasLen(0)
+}
+
+func TestIncomingEmailWebhook_InvalidToken(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Add support for TypeScript", Slug: "add-support-for-typescript", User: mock.JonSnow}
+	added := mockReplyQueries(post)
+
+	for _, to := range []string{
+		strings.Replace(email.ReplyAddress(mock.DemoTenant.ID, mock.AryaStark.ID, post.ID), "reply+1.2.1.", "reply+1.2.5.", 1),
+		email.ReplyAddress(mock.AvengersTenant.ID, mock.AryaStark.ID, post.ID),
+		"feedback@reply.test.fider.io",
+	} {
+		code, _ := mock.NewServer().
+			OnTenant(mock.DemoTenant).
+			AddHeader("X-Inbound-Secret", "s3cr3t").
+			ExecutePost(webhooks.IncomingEmailWebhook(), replyEmail("arya.stark@got.com", to, "I agree"))
+
+		Expect(code).Equals(http.StatusOK)
+	}
+	Expect(*added).HasLen(0)
+}
+
+func TestIncomingEmailWebhook_EmptyReply(t *testing.T) {
+	RegisterT(t)
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Add support for TypeScript", Slug: "add-support-for-typescript", User: mock.JonSnow}
+	added := mockReplyQueries(post)
+
+	to := email.ReplyAddress(mock.DemoTenant.ID, mock.AryaStark.ID, post.ID)
+	code, _ := mock.NewServer().
+		OnTenant(mock.DemoTenant).
+		AddHeader("X-Inbound-Secret", "s3cr3t").
+		ExecutePost(webhooks.IncomingEmailWebhook(), replyEmail("arya.stark@got.com", to, ""))
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(*added).HasLen(0)
+}
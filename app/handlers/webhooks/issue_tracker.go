@@ -0,0 +1,238 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/tasks"
+)
+
+type githubPayload struct {
+	Action     string `json:"action"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Issue struct {
+		Number int `json:"number"`
+	} `json:"issue"`
+	PullRequest struct {
+		Number int  `json:"number"`
+		Merged bool `json:"merged"`
+	} `json:"pull_request"`
+}
+
+type gitlabPayload struct {
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID    int    `json:"iid"`
+		Action string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+// IncomingGitHubWebhook updates linked posts when a GitHub issue or pull request is closed, merged or reopened
+func IncomingGitHubWebhook() web.HandlerFunc {
+	return func(c *web.Context) error {
+		tenant := c.Tenant()
+		if tenant == nil {
+			return c.NotFound()
+		}
+
+		signature := c.Request.GetHeader("X-Hub-Signature-256")
+		if !IsValidGitHubSignature(tenant.IssueWebhookSecret, []byte(c.Request.Body), signature) {
+			return c.Unauthorized()
+		}
+
+		payload := new(githubPayload)
+		if err := json.Unmarshal([]byte(c.Request.Body), payload); err != nil {
+			return c.BadRequest(web.Map{})
+		}
+
+		event := c.Request.GetHeader("X-GitHub-Event")
+		var number int
+		var state string
+		switch {
+		case event == "issues" && payload.Action == "closed":
+			number, state = payload.Issue.Number, entity.PostLinkClosed
+		case event == "issues" && payload.Action == "reopened":
+			number, state = payload.Issue.Number, entity.PostLinkOpen
+		case event == "pull_request" && payload.Action == "closed" && payload.PullRequest.Merged:
+			number, state = payload.PullRequest.Number, entity.PostLinkMerged
+		case event == "pull_request" && payload.Action == "closed":
+			number, state = payload.PullRequest.Number, entity.PostLinkClosed
+		case event == "pull_request" && payload.Action == "reopened":
+			number, state = payload.PullRequest.Number, entity.PostLinkOpen
+		default:
+			log.Debugf(c, "Ignoring GitHub webhook event: '@{EventType}'", dto.Props{
+				"EventType": event,
+			})
+			return c.Ok(web.Map{})
+		}
+
+		externalID := entity.GitHubExternalID(payload.Repository.FullName, number)
+		if err := applyPostLinkState(c, entity.PostLinkGitHub, externalID, state); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(web.Map{})
+	}
+}
+
+// IncomingGitLabWebhook updates linked posts when a GitLab issue or merge request is closed, merged or reopened
+func IncomingGitLabWebhook() web.HandlerFunc {
+	return func(c *web.Context) error {
+		tenant := c.Tenant()
+		if tenant == nil {
+			return c.NotFound()
+		}
+
+		token := c.Request.GetHeader("X-Gitlab-Token")
+		if !IsValidGitLabToken(tenant.IssueWebhookSecret, token) {
+			return c.Unauthorized()
+		}
+
+		payload := new(gitlabPayload)
+		if err := json.Unmarshal([]byte(c.Request.Body), payload); err != nil {
+			return c.BadRequest(web.Map{})
+		}
+
+		event := c.Request.GetHeader("X-Gitlab-Event")
+		isMergeRequest := event == "Merge Request Hook"
+		if event != "Issue Hook" && !isMergeRequest {
+			log.Debugf(c, "Ignoring GitLab webhook event: '@{EventType}'", dto.Props{
+				"EventType": event,
+			})
+			return c.Ok(web.Map{})
+		}
+
+		var state string
+		switch payload.ObjectAttributes.Action {
+		case "close":
+			state = entity.PostLinkClosed
+		case "merge":
+			state = entity.PostLinkMerged
+		case "reopen":
+			state = entity.PostLinkOpen
+		default:
+			return c.Ok(web.Map{})
+		}
+
+		externalID := entity.GitLabExternalID(payload.Project.PathWithNamespace, isMergeRequest, payload.ObjectAttributes.IID)
+		if err := applyPostLinkState(c, entity.PostLinkGitLab, externalID, state); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(web.Map{})
+	}
+}
+
+// IsValidGitHubSignature returns true if signature is the HMAC-SHA256 of payload using given secret
+func IsValidGitHubSignature(secret string, payload []byte, signature string) bool {
+	if secret == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// IsValidGitLabToken returns true if token matches given secret
+func IsValidGitLabToken(secret, token string) bool {
+	if secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(token)) == 1
+}
+
+// applyPostLinkState updates all links to given reference.
+// Posts still active are marked as completed once all of their tracker links are done,
+// on behalf of the staff member who linked them.
+func applyPostLinkState(c *web.Context, linkType, externalID, state string) error {
+	getLinks := &query.GetPostLinksByExternalID{Type: linkType, ExternalID: externalID}
+	if err := bus.Dispatch(c, getLinks); err != nil {
+		return err
+	}
+
+	for _, link := range getLinks.Result {
+		if link.State == state {
+			continue
+		}
+
+		if err := bus.Dispatch(c, &cmd.SetPostLinkState{Link: link, State: state}); err != nil {
+			return err
+		}
+
+		if link.IsDone() {
+			if err := completePost(c, link); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func completePost(c *web.Context, link *entity.PostLink) error {
+	getPost := &query.GetPostByID{PostID: link.PostID}
+	if err := bus.Dispatch(c, getPost); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	post := getPost.Result
+	if !post.Status.IsActive() {
+		return nil
+	}
+
+	for _, other := range post.Links {
+		if other.ID != link.ID && other.Type != entity.PostLinkURL && !other.IsDone() {
+			return nil
+		}
+	}
+
+	getUser := &query.GetUserByID{UserID: link.CreatedByID}
+	if err := bus.Dispatch(c, getUser); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+	if !getUser.Result.IsCollaborator() {
+		// Who linked the issue is no longer a staff member
+		return nil
+	}
+	c.SetUser(getUser.Result)
+
+	prevStatus := post.Status
+	text := ""
+	if post.Response != nil {
+		text = post.Response.Text
+	}
+	details := fmt.Sprintf("%s is %s", link.ExternalID, link.State)
+	if err := bus.Dispatch(c,
+		&cmd.SetPostResponse{Post: post, Text: text, Status: enum.PostCompleted},
+		&cmd.AddPostLog{Post: post, Action: entity.PostLogLinkDone, Details: details},
+	); err != nil {
+		return err
+	}
+
+	c.Enqueue(tasks.NotifyAboutStatusChange(post, prevStatus))
+	return nil
+}
@@ -0,0 +1,148 @@
+package webhooks_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/getfider/fider/app/handlers/webhooks"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func tenantWithSecret(secret string) *entity.Tenant {
+	tenant := *mock.DemoTenant
+	tenant.IssueWebhookSecret = secret
+	return &tenant
+}
+
+func TestIsValidGitHubSignature(t *testing.T) {
+	RegisterT(t)
+
+	payload := []byte(`{"action":"closed"}`)
+	Expect(webhooks.IsValidGitHubSignature("s3cr3t", payload, sign("s3cr3t", string(payload)))).IsTrue()
+	Expect(webhooks.IsValidGitHubSignature("s3cr3t", payload, sign("other", string(payload)))).IsFalse()
+	Expect(webhooks.IsValidGitHubSignature("s3cr3t", payload, "")).IsFalse()
+	Expect(webhooks.IsValidGitHubSignature("", payload, sign("", string(payload)))).IsFalse()
+}
+
+func TestIsValidGitLabToken(t *testing.T) {
+	RegisterT(t)
+
+	Expect(webhooks.IsValidGitLabToken("s3cr3t", "s3cr3t")).IsTrue()
+	Expect(webhooks.IsValidGitLabToken("s3cr3t", "other")).IsFalse()
+	Expect(webhooks.IsValidGitLabToken("", "")).IsFalse()
+}
+
+func TestIncomingGitHubWebhook_InvalidSignature(t *testing.T) {
+	RegisterT(t)
+
+	payload := `{"action":"closed","issue":{"number":1},"repository":{"full_name":"getfider/fider"}}`
+	code, _ := mock.NewServer().
+		OnTenant(tenantWithSecret("s3cr3t")).
+		AddHeader("X-GitHub-Event", "issues").
+		AddHeader("X-Hub-Signature-256", sign("wrong", payload)).
+		ExecutePost(webhooks.IncomingGitHubWebhook(), payload)
+
+	Expect(code).Equals(http.StatusUnauthorized)
+}
+
+func TestIncomingGitHubWebhook_IssueClosed(t *testing.T) {
+	RegisterT(t)
+
+	link := &entity.PostLink{ID: 5, PostID: 10, Type: entity.PostLinkGitHub, ExternalID: "getfider/fider#12", State: entity.PostLinkOpen, CreatedByID: mock.JonSnow.ID}
+	post := &entity.Post{ID: 10, Number: 1, Status: enum.PostStarted, Links: []*entity.PostLink{link}}
+
+	var getLinks *query.GetPostLinksByExternalID
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostLinksByExternalID) error {
+		getLinks = q
+		q.Result = []*entity.PostLink{link}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostLinkState) error {
+		c.Link.State = c.State
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		q.Result = post
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+	var setResponse *cmd.SetPostResponse
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostResponse) error {
+		setResponse = c
+		return nil
+	})
+	var addLog *cmd.AddPostLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddPostLog) error {
+		addLog = c
+		return nil
+	})
+
+	payload := `{"action":"closed","issue":{"number":12},"repository":{"full_name":"GetFider/Fider"}}`
+	code, _ := mock.NewServer().
+		OnTenant(tenantWithSecret("s3cr3t")).
+		AddHeader("X-GitHub-Event", "issues").
+		AddHeader("X-Hub-Signature-256", sign("s3cr3t", payload)).
+		ExecutePost(webhooks.IncomingGitHubWebhook(), payload)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(getLinks.Type).Equals(entity.PostLinkGitHub)
+	Expect(getLinks.ExternalID).Equals("getfider/fider#12")
+	Expect(link.State).Equals(entity.PostLinkClosed)
+	Expect(setResponse.Post).Equals(post)
+	Expect(setResponse.Status).Equals(enum.PostCompleted)
+	Expect(addLog.Action).Equals(entity.PostLogLinkDone)
+}
+
+func TestIncomingGitLabWebhook_MergeRequestMerged_OtherLinksOpen(t *testing.T) {
+	RegisterT(t)
+
+	link := &entity.PostLink{ID: 5, PostID: 10, Type: entity.PostLinkGitLab, ExternalID: "group/project!3", State: entity.PostLinkOpen, CreatedByID: mock.JonSnow.ID}
+	other := &entity.PostLink{ID: 6, PostID: 10, Type: entity.PostLinkJira, ExternalID: "PROJ-1", State: entity.PostLinkOpen}
+	post := &entity.Post{ID: 10, Number: 1, Status: enum.PostStarted, Links: []*entity.PostLink{link, other}}
+
+	var getLinks *query.GetPostLinksByExternalID
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostLinksByExternalID) error {
+		getLinks = q
+		q.Result = []*entity.PostLink{link}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostLinkState) error {
+		c.Link.State = c.State
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		q.Result = post
+		return nil
+	})
+
+	payload := `{"object_kind":"merge_request","project":{"path_with_namespace":"group/project"},"object_attributes":{"iid":3,"action":"merge"}}`
+	code, _ := mock.NewServer().
+		OnTenant(tenantWithSecret("s3cr3t")).
+		AddHeader("X-Gitlab-Event", "Merge Request Hook").
+		AddHeader("X-Gitlab-Token", "s3cr3t").
+		ExecutePost(webhooks.IncomingGitLabWebhook(), payload)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(getLinks.ExternalID).Equals("group/project!3")
+	Expect(link.State).Equals(entity.PostLinkMerged)
+	Expect(post.Status).Equals(enum.PostStarted)
+}
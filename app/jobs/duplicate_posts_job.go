@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/log"
+)
+
+// duplicatePostsMinScore is the lowest title similarity of a pair of posts to be reported as possible duplicates
+const duplicatePostsMinScore = 0.6
+
+type DuplicatePostsJobHandler struct {
+}
+
+func (e DuplicatePostsJobHandler) Schedule() string {
+	return "0 15 3 * * *" // every day at 03:15
+}
+
+func (e DuplicatePostsJobHandler) Run(ctx Context) error {
+	// Only posts created since last run are compared, the first run looks back 30 days
+	since := time.Now().AddDate(0, 0, -30)
+	if ctx.LastSuccessfulRun != nil {
+		since = *ctx.LastSuccessfulRun
+	}
+
+	c := &cmd.FindPostDuplicateCandidates{Since: since, MinScore: duplicatePostsMinScore}
+	if err := bus.Dispatch(ctx, c); err != nil {
+		return err
+	}
+
+	log.Debugf(ctx, "@{NumOfCandidates} possible duplicate pairs were found", dto.Props{
+		"NumOfCandidates": c.NumOfCandidates,
+	})
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/cmd"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestDuplicatePostsJob_Schedule_IsCorrect(t *testing.T) {
+	RegisterT(t)
+
+	job := &jobs.DuplicatePostsJobHandler{}
+	Expect(job.Schedule()).Equals("0 15 3 * * *")
+}
+
+func TestDuplicatePostsJob_ComparesPostsSinceLastRun(t *testing.T) {
+	RegisterT(t)
+
+	var dispatched *cmd.FindPostDuplicateCandidates
+	bus.AddHandler(func(ctx context.Context, c *cmd.FindPostDuplicateCandidates) error {
+		dispatched = c
+		return nil
+	})
+
+	job := &jobs.DuplicatePostsJobHandler{}
+	err := job.Run(jobs.Context{Context: context.Background()})
+	Expect(err).IsNil()
+	Expect(dispatched.Since).TemporarilySimilar(time.Now().AddDate(0, 0, -30), time.Minute)
+	Expect(dispatched.MinScore).Equals(0.6)
+
+	lastRun := time.Date(2026, 10, 18, 3, 15, 0, 0, time.UTC)
+	err = job.Run(jobs.Context{Context: context.Background(), LastSuccessfulRun: &lastRun})
+	Expect(err).IsNil()
+	Expect(dispatched.Since).Equals(lastRun)
+}
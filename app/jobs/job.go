@@ -93,6 +93,21 @@ func (j fiderJob) Run() {
 	}
 }
 
+// runIsolated runs a step of a job so that its failure only rolls back its own changes,
+// the error is logged and the job carries on with the next step
+func runIsolated(ctx context.Context, fn func() error) {
+	var err error
+	if trx, ok := ctx.Value(app.TransactionCtxKey).(*dbx.Trx); ok {
+		err = trx.Savepoint("job_step", fn)
+	} else {
+		err = fn()
+	}
+
+	if err != nil {
+		log.Error(ctx, err)
+	}
+}
+
 func newJobContext() (Context, *dbx.Trx, error) {
 	ctx := context.Background()
 	ctx = log.WithProperties(ctx, dto.Props{
@@ -0,0 +1,97 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/worker"
+	"github.com/getfider/fider/app/tasks"
+)
+
+type ScheduledResponsesJobHandler struct {
+}
+
+func (e ScheduledResponsesJobHandler) Schedule() string {
+	return "0 * * * * *" // every minute
+}
+
+func (e ScheduledResponsesJobHandler) Run(ctx Context) error {
+	listDue := &query.ListDueScheduledResponses{Now: time.Now()}
+	if err := bus.Dispatch(ctx, listDue); err != nil {
+		return errors.Wrap(err, "failed to list due scheduled responses")
+	}
+
+	// A failing response is retried on next run without holding back the others
+	for _, response := range listDue.Result {
+		runIsolated(ctx, func() error {
+			if err := applyScheduledResponse(ctx, response); err != nil {
+				return errors.Wrap(err, "failed to apply scheduled response '%d'", response.ID)
+			}
+			return nil
+		})
+	}
+
+	log.Debugf(ctx, "@{Count} scheduled response(s) applied", dto.Props{
+		"Count": len(listDue.Result),
+	})
+
+	return nil
+}
+
+func applyScheduledResponse(ctx context.Context, response *entity.ScheduledResponse) error {
+	getTenant := &query.GetTenantByID{TenantID: response.TenantID}
+	if err := bus.Dispatch(ctx, getTenant); err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, app.TenantCtxKey, getTenant.Result)
+	ctx = context.WithValue(ctx, app.LocaleCtxKey, getTenant.Result.Locale)
+
+	// Links on notifications are built from the request that scheduled the change
+	if baseURL, err := url.Parse(response.BaseURL); err == nil && response.BaseURL != "" {
+		ctx = context.WithValue(ctx, app.RequestCtxKey, web.Request{URL: baseURL})
+	}
+
+	// The change is applied on behalf of who scheduled it
+	getUser := &query.GetUserByID{UserID: response.CreatedBy.ID}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, app.UserCtxKey, getUser.Result)
+
+	markAsExecuted := &cmd.MarkScheduledResponseAsExecuted{ID: response.ID}
+
+	getPost := &query.GetPostByID{PostID: response.PostID}
+	err := bus.Dispatch(ctx, getPost)
+	if err != nil && errors.Cause(err) == app.ErrNotFound {
+		// Post has been deleted since the change was scheduled
+		return bus.Dispatch(ctx, markAsExecuted)
+	} else if err != nil {
+		return err
+	}
+
+	post := getPost.Result
+	prevStatus := post.Status
+	details := fmt.Sprintf("Scheduled change of status to %s applied", response.Status.Name())
+	if err := bus.Dispatch(ctx,
+		&cmd.SetPostResponse{Post: post, Text: response.Text, Status: response.Status},
+		markAsExecuted,
+		&cmd.AddPostLog{Post: post, Action: entity.PostLogScheduledResponse, Details: details},
+	); err != nil {
+		return err
+	}
+
+	task := tasks.NotifyAboutStatusChange(post, prevStatus)
+	task.OriginContext = ctx
+	return task.Job(worker.NewContext(ctx, "jobs", task))
+}
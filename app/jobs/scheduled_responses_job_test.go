@@ -0,0 +1,193 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/services/email/emailmock"
+)
+
+func TestScheduledResponsesJob_Schedule_IsCorrect(t *testing.T) {
+	RegisterT(t)
+
+	job := &jobs.ScheduledResponsesJobHandler{}
+	Expect(job.Schedule()).Equals("0 * * * * *")
+}
+
+func TestScheduledResponsesJob_ApplyDueResponses(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	scheduledAt := time.Now().Add(-1 * time.Minute)
+	response := &entity.ScheduledResponse{
+		ID:          5,
+		TenantID:    mock.DemoTenant.ID,
+		PostID:      1,
+		Status:      enum.PostCompleted,
+		Text:        "Shipped!",
+		ScheduledAt: &scheduledAt,
+		CreatedBy:   &entity.User{ID: mock.JonSnow.ID},
+		BaseURL:     "http://demo.test.fider.io:3000",
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListDueScheduledResponses) error {
+		q.Result = []*entity.ScheduledResponse{response}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantByID) error {
+		q.Result = mock.DemoTenant
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Post", Slug: "post", Status: enum.PostStarted}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		q.Result = post
+		return nil
+	})
+
+	var respondedBy *entity.User
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostResponse) error {
+		respondedBy = ctx.Value(app.UserCtxKey).(*entity.User)
+		c.Post.Status = c.Status
+		c.Post.Response = &entity.PostResponse{Text: c.Text, RespondedAt: time.Now(), User: respondedBy}
+		return nil
+	})
+
+	var executed *cmd.MarkScheduledResponseAsExecuted
+	bus.AddHandler(func(ctx context.Context, c *cmd.MarkScheduledResponseAsExecuted) error {
+		executed = c
+		return nil
+	})
+
+	var log *cmd.AddPostLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddPostLog) error {
+		log = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.AryaStark}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		return nil
+	})
+
+	job := &jobs.ScheduledResponsesJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+
+	Expect(respondedBy).Equals(mock.JonSnow)
+	Expect(post.Status).Equals(enum.PostCompleted)
+	Expect(post.Response.Text).Equals("Shipped!")
+	Expect(executed.ID).Equals(5)
+	Expect(log.Action).Equals(entity.PostLogScheduledResponse)
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("change_status")
+}
+
+func TestScheduledResponsesJob_PostNotFound(t *testing.T) {
+	RegisterT(t)
+
+	scheduledAt := time.Now().Add(-1 * time.Minute)
+	bus.AddHandler(func(ctx context.Context, q *query.ListDueScheduledResponses) error {
+		q.Result = []*entity.ScheduledResponse{
+			{ID: 7, TenantID: mock.DemoTenant.ID, PostID: 99, Status: enum.PostCompleted, ScheduledAt: &scheduledAt, CreatedBy: &entity.User{ID: mock.JonSnow.ID}},
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantByID) error {
+		q.Result = mock.DemoTenant
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		return app.ErrNotFound
+	})
+
+	var executed *cmd.MarkScheduledResponseAsExecuted
+	bus.AddHandler(func(ctx context.Context, c *cmd.MarkScheduledResponseAsExecuted) error {
+		executed = c
+		return nil
+	})
+
+	job := &jobs.ScheduledResponsesJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+	Expect(executed.ID).Equals(7)
+}
+
+func TestScheduledResponsesJob_FailingResponseDoesNotStopOthers(t *testing.T) {
+	RegisterT(t)
+
+	scheduledAt := time.Now().Add(-1 * time.Minute)
+	bus.AddHandler(func(ctx context.Context, q *query.ListDueScheduledResponses) error {
+		q.Result = []*entity.ScheduledResponse{
+			{ID: 7, TenantID: mock.DemoTenant.ID, PostID: 99, Status: enum.PostCompleted, ScheduledAt: &scheduledAt, CreatedBy: &entity.User{ID: 999}},
+			{ID: 8, TenantID: mock.DemoTenant.ID, PostID: 99, Status: enum.PostCompleted, ScheduledAt: &scheduledAt, CreatedBy: &entity.User{ID: mock.JonSnow.ID}},
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantByID) error {
+		q.Result = mock.DemoTenant
+		return nil
+	})
+
+	// Creator of the first response has been deleted
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID != mock.JonSnow.ID {
+			return app.ErrNotFound
+		}
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		return app.ErrNotFound
+	})
+
+	executed := make([]int, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.MarkScheduledResponseAsExecuted) error {
+		executed = append(executed, c.ID)
+		return nil
+	})
+
+	job := &jobs.ScheduledResponsesJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+	Expect(executed).Equals([]int{8})
+}
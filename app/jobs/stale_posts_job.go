@@ -0,0 +1,218 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/log"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/worker"
+	"github.com/getfider/fider/app/tasks"
+)
+
+type StalePostsJobHandler struct {
+}
+
+func (e StalePostsJobHandler) Schedule() string {
+	return "0 30 * * * *" // every hour at minute 30
+}
+
+func (e StalePostsJobHandler) Run(ctx Context) error {
+	listRules := &query.ListActiveStalePostRules{}
+	if err := bus.Dispatch(ctx, listRules); err != nil {
+		return errors.Wrap(err, "failed to list stale post rules")
+	}
+
+	// A failing tenant doesn't hold back the others
+	for _, rules := range listRules.Result {
+		runIsolated(ctx, func() error {
+			if err := runStalePostRules(ctx, rules); err != nil {
+				return errors.Wrap(err, "failed to run stale post rules of tenant '%d'", rules.TenantID)
+			}
+			return nil
+		})
+	}
+
+	return nil
+}
+
+func runStalePostRules(ctx context.Context, rules *entity.StalePostRules) error {
+	getTenant := &query.GetTenantByID{TenantID: rules.TenantID}
+	if err := bus.Dispatch(ctx, getTenant); err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, app.TenantCtxKey, getTenant.Result)
+	ctx = context.WithValue(ctx, app.LocaleCtxKey, getTenant.Result.Locale)
+	ctx = context.WithValue(ctx, app.RequestCtxKey, web.Request{URL: web.TenantURL(getTenant.Result)})
+
+	// Warnings, responses and locks are done by the system rather than by a user of the tenant
+	ctx = context.WithValue(ctx, app.UserCtxKey, (*entity.User)(nil))
+
+	if rules.IsEnabled() {
+		if err := closeStalePosts(ctx, rules); err != nil {
+			return err
+		}
+	}
+
+	if rules.IsAutoLockEnabled() {
+		if err := lockClosedPosts(ctx, rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func closeStalePosts(ctx context.Context, rules *entity.StalePostRules) error {
+	getStalePosts := &query.GetStalePosts{Rules: rules, Now: time.Now()}
+	if err := bus.Dispatch(ctx, getStalePosts); err != nil {
+		return err
+	}
+
+	for _, number := range getStalePosts.ToWarn {
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(ctx, getPost); err != nil {
+			return err
+		}
+
+		post := getPost.Result
+		details := fmt.Sprintf("No activity for %d days, post will be closed in %d days", rules.InactiveDays, rules.GraceDays)
+		if err := bus.Dispatch(ctx,
+			&cmd.MarkPostAsStaleWarned{Post: post},
+			&cmd.AddPostLog{Post: post, Action: entity.PostLogStaleWarning, Details: details},
+		); err != nil {
+			return err
+		}
+
+		if err := notifyStalePostSubscribers(ctx, post, rules.GraceDays); err != nil {
+			return err
+		}
+	}
+
+	for _, number := range getStalePosts.ToClose {
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(ctx, getPost); err != nil {
+			return err
+		}
+
+		post := getPost.Result
+		prevStatus := post.Status
+		details := fmt.Sprintf("No activity for %d days after warning, status changed to %s", rules.GraceDays, rules.CloseStatus.Name())
+		if err := bus.Dispatch(ctx,
+			&cmd.SetPostResponse{Post: post, Text: rules.Response, Status: rules.CloseStatus},
+			&cmd.AddPostLog{Post: post, Action: entity.PostLogStaleClosed, Details: details},
+		); err != nil {
+			return err
+		}
+
+		task := tasks.NotifyAboutStatusChange(post, prevStatus)
+		task.OriginContext = ctx
+		if err := task.Job(worker.NewContext(ctx, "jobs", task)); err != nil {
+			return err
+		}
+	}
+
+	log.Debugf(ctx, "@{Warned} post(s) warned and @{Closed} post(s) closed due to inactivity", dto.Props{
+		"Warned": len(getStalePosts.ToWarn),
+		"Closed": len(getStalePosts.ToClose),
+	})
+
+	return nil
+}
+
+func lockClosedPosts(ctx context.Context, rules *entity.StalePostRules) error {
+	getPostsToLock := &query.GetPostsToLock{Rules: rules, Now: time.Now()}
+	if err := bus.Dispatch(ctx, getPostsToLock); err != nil {
+		return err
+	}
+
+	for _, number := range getPostsToLock.Result {
+		getPost := &query.GetPostByNumber{Number: number}
+		if err := bus.Dispatch(ctx, getPost); err != nil {
+			return err
+		}
+
+		post := getPost.Result
+		reason := fmt.Sprintf("Locked automatically %d days after being closed", rules.LockAfterDays)
+		if err := bus.Dispatch(ctx,
+			&cmd.SetPostLocked{Post: post, Locked: true, Reason: reason},
+			&cmd.AddPostLog{Post: post, Action: entity.PostLogLocked, Details: reason},
+		); err != nil {
+			return err
+		}
+	}
+
+	log.Debugf(ctx, "@{Locked} closed post(s) locked", dto.Props{
+		"Locked": len(getPostsToLock.Result),
+	})
+
+	return nil
+}
+
+// notifyStalePostSubscribers warns subscribers (web and email) that the post is about to be closed
+func notifyStalePostSubscribers(ctx context.Context, post *entity.Post, graceDays int) error {
+	getWebSubscribers := &query.GetActiveSubscribers{
+		Number:  post.Number,
+		Channel: enum.NotificationChannelWeb,
+		Event:   enum.NotificationEventChangeStatus,
+	}
+	getEmailSubscribers := &query.GetActiveSubscribers{
+		Number:  post.Number,
+		Channel: enum.NotificationChannelEmail,
+		Event:   enum.NotificationEventChangeStatus,
+	}
+	if err := bus.Dispatch(ctx, getWebSubscribers, getEmailSubscribers); err != nil {
+		return err
+	}
+
+	title := fmt.Sprintf("**%s** will be closed in %d days due to inactivity", post.Title, graceDays)
+	link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+	for _, user := range getWebSubscribers.Result {
+		if err := bus.Dispatch(ctx, &cmd.AddNewNotification{
+			User:   user,
+			Title:  title,
+			Link:   link,
+			PostID: post.ID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(getEmailSubscribers.Result) == 0 {
+		return nil
+	}
+
+	to := make([]dto.Recipient, 0, len(getEmailSubscribers.Result))
+	for _, user := range getEmailSubscribers.Result {
+		to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+	}
+
+	tenant := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	baseURL := web.BaseURL(ctx)
+	bus.Publish(ctx, &cmd.SendMail{
+		From:         dto.Recipient{Name: tenant.Name},
+		To:           to,
+		TemplateName: "stale_post_warning",
+		Props: dto.Props{
+			"title":       post.Title,
+			"postLink":    fmt.Sprintf("<a href='%s%s'>#%d</a>", baseURL, link, post.Number),
+			"siteName":    tenant.Name,
+			"days":        graceDays,
+			"view":        fmt.Sprintf("<a href='%s%s'>%s</a>", baseURL, link, i18n.T(ctx, "email.subscription.view")),
+			"unsubscribe": fmt.Sprintf("<a href='%s%s'>%s</a>", baseURL, link, i18n.T(ctx, "email.subscription.unsubscribe")),
+			"change":      fmt.Sprintf("<a href='%s/settings'>%s</a>", baseURL, i18n.T(ctx, "email.subscription.change")),
+			"logo":        web.LogoURL(ctx),
+		},
+	})
+	return nil
+}
@@ -0,0 +1,213 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/jobs"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+)
+
+func TestStalePostsJob_Schedule_IsCorrect(t *testing.T) {
+	RegisterT(t)
+
+	job := &jobs.StalePostsJobHandler{}
+	Expect(job.Schedule()).Equals("0 30 * * * *")
+}
+
+func TestStalePostsJob_WarnAndClose(t *testing.T) {
+	RegisterT(t)
+
+	rules := &entity.StalePostRules{
+		TenantID:     mock.DemoTenant.ID,
+		InactiveDays: 90,
+		GraceDays:    14,
+		CloseStatus:  enum.PostDeclined,
+		Response:     "Closed due to inactivity.",
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListActiveStalePostRules) error {
+		q.Result = []*entity.StalePostRules{rules}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantByID) error {
+		q.Result = mock.DemoTenant
+		return nil
+	})
+
+	var stalePostsTenant *entity.Tenant
+	bus.AddHandler(func(ctx context.Context, q *query.GetStalePosts) error {
+		stalePostsTenant = ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+		Expect(q.Rules).Equals(rules)
+		q.ToWarn = []int{1}
+		q.ToClose = []int{2}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: q.Number, Number: q.Number, Title: "Post", Slug: "post"}
+		return nil
+	})
+
+	var warned *cmd.MarkPostAsStaleWarned
+	bus.AddHandler(func(ctx context.Context, c *cmd.MarkPostAsStaleWarned) error {
+		warned = c
+		return nil
+	})
+
+	var setResponse *cmd.SetPostResponse
+	responder := mock.JonSnow
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostResponse) error {
+		responder, _ = ctx.Value(app.UserCtxKey).(*entity.User)
+		setResponse = c
+		c.Post.Status = c.Status
+		c.Post.Response = &entity.PostResponse{Text: c.Text}
+		return nil
+	})
+
+	logs := make([]*cmd.AddPostLog, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddPostLog) error {
+		logs = append(logs, c)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.AryaStark}
+		return nil
+	})
+
+	notifications := make([]*cmd.AddNewNotification, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		notifications = append(notifications, c)
+		return nil
+	})
+
+	emails := make([]*cmd.SendMail, 0)
+	bus.AddListener(func(ctx context.Context, c *cmd.SendMail) {
+		emails = append(emails, c)
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error { return nil })
+
+	job := &jobs.StalePostsJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+
+	Expect(stalePostsTenant).Equals(mock.DemoTenant)
+	Expect(warned.Post.Number).Equals(1)
+	Expect(setResponse.Post.Number).Equals(2)
+	Expect(setResponse.Status).Equals(enum.PostDeclined)
+	Expect(setResponse.Text).Equals("Closed due to inactivity.")
+	Expect(responder).IsNil()
+
+	Expect(logs).HasLen(2)
+	Expect(logs[0].Action).Equals(entity.PostLogStaleWarning)
+	Expect(logs[1].Action).Equals(entity.PostLogStaleClosed)
+
+	Expect(notifications).HasLen(2)
+	Expect(notifications[0].User).Equals(mock.AryaStark)
+	Expect(notifications[0].Link).Equals("/posts/1/post")
+	Expect(notifications[0].Title).Equals("**Post** will be closed in 14 days due to inactivity")
+	Expect(notifications[1].Title).Equals("**Demonstration** changed status of **Post** to **declined**")
+
+	Expect(emails).HasLen(2)
+	Expect(emails[0].TemplateName).Equals("stale_post_warning")
+	Expect(emails[0].To[0].Address).Equals(mock.AryaStark.Email)
+	Expect(emails[1].TemplateName).Equals("change_status")
+}
+
+func TestStalePostsJob_FailingTenantDoesNotStopOthers(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListActiveStalePostRules) error {
+		q.Result = []*entity.StalePostRules{
+			{TenantID: 999, InactiveDays: 90, GraceDays: 14, CloseStatus: enum.PostDeclined},
+			{TenantID: mock.DemoTenant.ID, InactiveDays: 90, GraceDays: 14, CloseStatus: enum.PostDeclined},
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantByID) error {
+		if q.TenantID != mock.DemoTenant.ID {
+			return app.ErrNotFound
+		}
+		q.Result = mock.DemoTenant
+		return nil
+	})
+
+	var stalePostsTenant *entity.Tenant
+	bus.AddHandler(func(ctx context.Context, q *query.GetStalePosts) error {
+		stalePostsTenant = ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+		return nil
+	})
+
+	job := &jobs.StalePostsJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+	Expect(stalePostsTenant).Equals(mock.DemoTenant)
+}
+
+func TestStalePostsJob_LockClosedPosts(t *testing.T) {
+	RegisterT(t)
+
+	rules := &entity.StalePostRules{
+		TenantID:      mock.DemoTenant.ID,
+		LockAfterDays: 30,
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListActiveStalePostRules) error {
+		q.Result = []*entity.StalePostRules{rules}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTenantByID) error {
+		q.Result = mock.DemoTenant
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostsToLock) error {
+		Expect(q.Rules).Equals(rules)
+		q.Result = []int{3}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = &entity.Post{ID: q.Number, Number: q.Number, Title: "Post", Slug: "post", Status: enum.PostCompleted}
+		return nil
+	})
+
+	var setLocked *cmd.SetPostLocked
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostLocked) error {
+		setLocked = c
+		return nil
+	})
+
+	logs := make([]*cmd.AddPostLog, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddPostLog) error {
+		logs = append(logs, c)
+		return nil
+	})
+
+	job := &jobs.StalePostsJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+
+	Expect(setLocked.Post.Number).Equals(3)
+	Expect(setLocked.Locked).IsTrue()
+	Expect(logs).HasLen(1)
+	Expect(logs[0].Action).Equals(entity.PostLogLocked)
+}
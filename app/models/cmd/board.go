@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type AddNewBoard struct {
+	Name                string
+	WelcomeMessage      string
+	DefaultTags         []string
+	IsModerationEnabled bool
+	IsPrivate           bool
+	Position            int
+	StaffIDs            []int
+
+	Result *entity.Board
+}
+
+type UpdateBoard struct {
+	BoardID             int
+	Name                string
+	WelcomeMessage      string
+	DefaultTags         []string
+	IsModerationEnabled bool
+	IsPrivate           bool
+	Position            int
+	StaffIDs            []int
+
+	Result *entity.Board
+}
+
+type DeleteBoard struct {
+	Board *entity.Board
+}
+
+// SetPostBoard moves a post to another board
+type SetPostBoard struct {
+	Post  *entity.Post
+	Board *entity.Board
+}
@@ -7,6 +7,10 @@ import (
 type AddNewComment struct {
 	Post    *entity.Post
 	Content string
+	// ParentID is the comment being replied to, zero for top-level comments
+	ParentID int
+	// IsInternal makes the comment a staff-only note
+	IsInternal bool
 
 	Result *entity.Comment
 }
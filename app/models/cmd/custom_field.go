@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type AddCustomField struct {
+	Key        string
+	Name       string
+	Type       enum.CustomFieldType
+	Options    []string
+	IsRequired bool
+	IsPublic   bool
+	Position   int
+
+	Result *entity.CustomField
+}
+
+type UpdateCustomField struct {
+	FieldID    int
+	Name       string
+	Options    []string
+	IsRequired bool
+	IsPublic   bool
+	Position   int
+
+	Result *entity.CustomField
+}
+
+type DeleteCustomField struct {
+	Field *entity.CustomField
+}
+
+// SetPostCustomFields stores the given values on a post; an empty value removes it
+type SetPostCustomFields struct {
+	Post   *entity.Post
+	Values map[string]string
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import "time"
+
+// FindPostDuplicateCandidates stores pairs of posts of all tenants with similar titles, comparing posts created since given time
+type FindPostDuplicateCandidates struct {
+	Since    time.Time
+	MinScore float64
+
+	NumOfCandidates int64
+}
+
+type DismissPostDuplicateCandidate struct {
+	CandidateID int
+}
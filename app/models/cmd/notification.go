@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 )
 
 type MarkAllNotificationsAsRead struct{}
@@ -19,6 +20,8 @@ type AddNewNotification struct {
 	Title  string
 	Link   string
 	PostID int
+	// IsAnonymous leaves out who caused the notification, used for anonymous posts
+	IsAnonymous bool
 
 	Result *entity.Notification
 }
@@ -26,6 +29,8 @@ type AddNewNotification struct {
 type AddSubscriber struct {
 	Post *entity.Post
 	User *entity.User
+	// KeepUnsubscribed leaves users who unsubscribed from the post unsubscribed
+	KeepUnsubscribed bool
 }
 
 type RemoveSubscriber struct {
@@ -33,6 +38,16 @@ type RemoveSubscriber struct {
 	User *entity.User
 }
 
+type SubscribeToTag struct {
+	Tag             *entity.Tag
+	Channels        enum.NotificationChannel
+	IncludeComments bool
+}
+
+type UnsubscribeFromTag struct {
+	Tag *entity.Tag
+}
+
 type SupressEmail struct {
 	EmailAddresses []string
 
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type AddNewPoll struct {
+	Post             *entity.Post
+	Comment          *entity.Comment
+	Question         string
+	Options          []string
+	IsMultipleChoice bool
+	IsVotersOnly     bool
+	ClosesAt         *time.Time
+
+	Result *entity.Poll
+}
+
+// AnswerPoll replaces the answers of current user with given options
+type AnswerPoll struct {
+	Poll      *entity.Poll
+	OptionIDs []int
+}
+
+type DeletePoll struct {
+	Poll *entity.Poll
+}
@@ -8,6 +8,8 @@ import (
 type AddNewPost struct {
 	Title       string
 	Description string
+	Board       *entity.Board
+	IsAnonymous bool
 
 	Result *entity.Post
 }
@@ -32,7 +34,23 @@ type SetPostPinned struct {
 	Pinned bool
 }
 
-// FlagPost records that a user flagged a post (idempotent: one flag per user per post)
+// SetPostLocked locks or unlocks a post, the current user is recorded as who locked it
+type SetPostLocked struct {
+	Post   *entity.Post
+	Locked bool
+	Reason string
+}
+
+// SetAcceptedAnswer marks a comment as the accepted answer of a post.
+// When Accepted is false, the answer is cleared only if it is given comment
+type SetAcceptedAnswer struct {
+	Post      *entity.Post
+	CommentID int
+	Accepted  bool
+}
+
+// FlagPost records that a user flagged a post (idempotent: one flag per user per post).
+// Without a user in context the flag is raised by the system, such as by a post rule
 type FlagPost struct {
 	PostID int
 	Reason string
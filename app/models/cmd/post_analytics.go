@@ -0,0 +1,9 @@
+package cmd
+
+// RecordPostView counts a view of a post, unless the same session viewed it recently
+type RecordPostView struct {
+	PostID    int
+	SessionID string
+
+	Result bool
+}
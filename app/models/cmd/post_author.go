@@ -0,0 +1,21 @@
+package cmd
+
+import "github.com/getfider/fider/app/models/entity"
+
+// ChangePostOwner transfers the ownership of a post to another user
+type ChangePostOwner struct {
+	Post *entity.Post
+	User *entity.User
+}
+
+// AddPostCoAuthor adds a user as co-author of a post
+type AddPostCoAuthor struct {
+	Post *entity.Post
+	User *entity.User
+}
+
+// RemovePostCoAuthor removes a co-author from a post
+type RemovePostCoAuthor struct {
+	Post *entity.Post
+	User *entity.User
+}
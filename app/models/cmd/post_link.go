@@ -0,0 +1,25 @@
+package cmd
+
+import "github.com/getfider/fider/app/models/entity"
+
+// AddPostLink links an external reference to a post
+type AddPostLink struct {
+	Post       *entity.Post
+	Type       string
+	ExternalID string
+	URL        string
+	State      string
+
+	Result *entity.PostLink
+}
+
+// SetPostLinkState changes the state of an external reference
+type SetPostLinkState struct {
+	Link  *entity.PostLink
+	State string
+}
+
+// DeletePostLink removes an external reference from its post
+type DeletePostLink struct {
+	Link *entity.PostLink
+}
@@ -0,0 +1,9 @@
+package cmd
+
+import "github.com/getfider/fider/app/models/entity"
+
+type AddPostLog struct {
+	Post    *entity.Post
+	Action  string
+	Details string
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type AddNewPostRule struct {
+	Rule *entity.PostRule
+
+	Result *entity.PostRule
+}
+
+type UpdatePostRule struct {
+	Rule *entity.PostRule
+
+	Result *entity.PostRule
+}
+
+type DeletePostRule struct {
+	Rule *entity.PostRule
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type AddNewPostTemplate struct {
+	Name             string
+	Content          string
+	RequiredSections []string
+	TagSlug          string
+	Board            *entity.Board
+	Position         int
+
+	Result *entity.PostTemplate
+}
+
+type UpdatePostTemplate struct {
+	TemplateID       int
+	Name             string
+	Content          string
+	RequiredSections []string
+	TagSlug          string
+	Board            *entity.Board
+	Position         int
+
+	Result *entity.PostTemplate
+}
+
+type DeletePostTemplate struct {
+	Template *entity.PostTemplate
+}
@@ -8,3 +8,9 @@ type ToggleCommentReaction struct {
 	User    *entity.User
 	Result  bool
 }
+
+type TogglePostReaction struct {
+	Post   *entity.Post
+	Emoji  string
+	Result bool
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type AddScheduledResponse struct {
+	Post        *entity.Post
+	Status      enum.PostStatus
+	Text        string
+	ScheduledAt *time.Time
+	BaseURL     string
+
+	Result *entity.ScheduledResponse
+}
+
+type DeleteScheduledResponse struct {
+	ID int
+}
+
+type MarkScheduledResponseAsExecuted struct {
+	ID int
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type SaveStalePostRules struct {
+	InactiveDays  int
+	GraceDays     int
+	CloseStatus   enum.PostStatus
+	Response      string
+	ExemptTags    []string
+	LockAfterDays int
+}
+
+type MarkPostAsStaleWarned struct {
+	Post *entity.Post
+}
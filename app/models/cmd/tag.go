@@ -8,6 +8,8 @@ type AddNewTag struct {
 	Name     string
 	Color    string
 	IsPublic bool
+	GroupID  int
+	ParentID int
 
 	Result *entity.Tag
 }
@@ -17,6 +19,8 @@ type UpdateTag struct {
 	Name     string
 	Color    string
 	IsPublic bool
+	GroupID  int
+	ParentID int
 
 	Result *entity.Tag
 }
@@ -28,9 +32,36 @@ type DeleteTag struct {
 type AssignTag struct {
 	Tag  *entity.Tag
 	Post *entity.Post
+
+	// Result is true when the tag wasn't assigned to the post yet
+	Result bool
 }
 
 type UnassignTag struct {
 	Tag  *entity.Tag
 	Post *entity.Post
 }
+
+type AddNewTagGroup struct {
+	Name           string
+	IsSingleSelect bool
+
+	Result *entity.TagGroup
+}
+
+type UpdateTagGroup struct {
+	GroupID        int
+	Name           string
+	IsSingleSelect bool
+
+	Result *entity.TagGroup
+}
+
+type DeleteTagGroup struct {
+	Group *entity.TagGroup
+}
+
+type MergeTags struct {
+	From *entity.Tag
+	Into *entity.Tag
+}
@@ -17,9 +17,20 @@ type CreateTenant struct {
 }
 
 type UpdateTenantPrivacySettings struct {
-	IsPrivate           bool
-	IsFeedEnabled       bool
-	IsModerationEnabled bool
+	IsPrivate                 bool
+	IsFeedEnabled             bool
+	IsModerationEnabled       bool
+	IsAnonymousPostingEnabled bool
+}
+
+type UpdateTenantVotingSettings struct {
+	VoteBudget              int
+	IsVoteImportanceEnabled bool
+	VoteRoleWeights         map[string]int
+}
+
+type UpdateTenantReactionSettings struct {
+	Reactions []*entity.ReactionType
 }
 
 type UpdateTenantEmailAuthAllowedSettings struct {
@@ -37,8 +48,9 @@ type UpdateTenantSettings struct {
 }
 
 type UpdateTenantAdvancedSettings struct {
-	CustomCSS      string
-	AllowedSchemes string
+	CustomCSS          string
+	AllowedSchemes     string
+	IssueWebhookSecret string
 }
 
 type ActivateTenant struct {
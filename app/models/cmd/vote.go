@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 )
 
 type AddVote struct {
-	Post *entity.Post
-	User *entity.User
+	Post       *entity.Post
+	User       *entity.User
+	Importance enum.VoteImportance
+
+	// ProxiedBy is set when a staff member votes on behalf of User
+	ProxiedBy *entity.User
+	ProxyNote string
 }
 
 type RemoveVote struct {
@@ -7,6 +7,7 @@ type Recipient struct {
 	Name    string
 	Address string
 	Props   Props
+	ReplyTo string
 }
 
 // NewRecipient creates a new Recipient
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// Operations that can be applied to many posts at once
+const (
+	BulkPostRespond   = "respond"
+	BulkPostAddTag    = "addTag"
+	BulkPostRemoveTag = "removeTag"
+	BulkPostMove      = "move"
+	BulkPostDelete    = "delete"
+)
+
+// BulkPostChange is a change applied to a batch of posts
+type BulkPostChange struct {
+	Operation string
+	Status    enum.PostStatus
+	Text      string
+	Tag       *entity.Tag
+	Board     *entity.Board
+}
@@ -0,0 +1,26 @@
+package entity
+
+// Board is a section of a tenant with its own posts, settings and staff
+type Board struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Slug           string `json:"slug"`
+	WelcomeMessage string `json:"welcomeMessage"`
+	// DefaultTags are the slugs of tags assigned to every new post of this board
+	DefaultTags         []string `json:"defaultTags"`
+	IsModerationEnabled bool     `json:"isModerationEnabled"`
+	// IsPrivate boards only show posts to staff and to the author of each post
+	IsPrivate bool  `json:"isPrivate"`
+	Position  int   `json:"position"`
+	StaffIDs  []int `json:"staffIds"`
+}
+
+// HasStaff returns true if given user is assigned to this board
+func (b *Board) HasStaff(userID int) bool {
+	for _, id := range b.StaffIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"sort"
 	"time"
 )
 
@@ -26,4 +27,51 @@ type Comment struct {
 	// PinnedAt is set when a moderator pinned the comment; pinned comments appear first
 	PinnedAt *time.Time `json:"pinnedAt,omitempty"`
 	PinnedBy *User      `json:"pinnedBy,omitempty"`
+	// ParentID is the comment this one replies to, zero for top-level comments
+	ParentID int `json:"parentId,omitempty"`
+	// IsDeleted marks a tombstone: a deleted comment kept only because it still has replies
+	IsDeleted bool       `json:"isDeleted,omitempty"`
+	Replies   []*Comment `json:"replies,omitempty"`
+	// IsInternal marks a staff-only note, never shown to visitors
+	IsInternal bool `json:"isInternal,omitempty"`
+}
+
+// NestComments arranges a flat list of comments into threads.
+// Top-level comments keep their order while replies are sorted from oldest to newest.
+// Replies whose parent isn't visible are shown as top-level comments,
+// and tombstones left without any visible reply are dropped.
+func NestComments(comments []*Comment) []*Comment {
+	byID := make(map[int]*Comment, len(comments))
+	for _, comment := range comments {
+		comment.Replies = nil
+		byID[comment.ID] = comment
+	}
+
+	roots := make([]*Comment, 0)
+	for _, comment := range comments {
+		if parent, ok := byID[comment.ParentID]; ok && comment.ParentID != comment.ID {
+			parent.Replies = append(parent.Replies, comment)
+		} else {
+			roots = append(roots, comment)
+		}
+	}
+
+	return pruneThreads(roots)
+}
+
+func pruneThreads(comments []*Comment) []*Comment {
+	result := make([]*Comment, 0, len(comments))
+	for _, comment := range comments {
+		if len(comment.Replies) > 0 {
+			comment.Replies = pruneThreads(comment.Replies)
+			sort.SliceStable(comment.Replies, func(i, j int) bool {
+				return comment.Replies[i].CreatedAt.Before(comment.Replies[j].CreatedAt)
+			})
+		}
+		if comment.IsDeleted && len(comment.Replies) == 0 {
+			continue
+		}
+		result = append(result, comment)
+	}
+	return result
 }
@@ -0,0 +1,49 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestNestComments(t *testing.T) {
+	RegisterT(t)
+
+	now := time.Now()
+	comments := []*entity.Comment{
+		{ID: 5, CreatedAt: now.Add(5 * time.Minute), ParentID: 1},
+		{ID: 4, CreatedAt: now.Add(4 * time.Minute)},
+		{ID: 3, CreatedAt: now.Add(3 * time.Minute), ParentID: 1},
+		{ID: 2, CreatedAt: now.Add(2 * time.Minute), ParentID: 99},
+		{ID: 1, CreatedAt: now.Add(1 * time.Minute)},
+	}
+
+	threads := entity.NestComments(comments)
+	Expect(threads).HasLen(3)
+	Expect(threads[0].ID).Equals(4)
+	Expect(threads[1].ID).Equals(2)
+	Expect(threads[2].ID).Equals(1)
+	Expect(threads[2].Replies).HasLen(2)
+	Expect(threads[2].Replies[0].ID).Equals(3)
+	Expect(threads[2].Replies[1].ID).Equals(5)
+}
+
+func TestNestComments_Tombstones(t *testing.T) {
+	RegisterT(t)
+
+	comments := []*entity.Comment{
+		{ID: 4, ParentID: 3, IsDeleted: true},
+		{ID: 3, IsDeleted: true},
+		{ID: 2, ParentID: 1},
+		{ID: 1, IsDeleted: true},
+	}
+
+	threads := entity.NestComments(comments)
+	Expect(threads).HasLen(1)
+	Expect(threads[0].ID).Equals(1)
+	Expect(threads[0].IsDeleted).IsTrue()
+	Expect(threads[0].Replies).HasLen(1)
+	Expect(threads[0].Replies[0].ID).Equals(2)
+}
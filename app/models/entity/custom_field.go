@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// CustomField is an admin-defined field that can be filled in on posts
+type CustomField struct {
+	ID         int                  `json:"id"`
+	Key        string               `json:"key"`
+	Name       string               `json:"name"`
+	Type       enum.CustomFieldType `json:"type"`
+	Options    []string             `json:"options"`
+	IsRequired bool                 `json:"isRequired"`
+	// IsPublic is false when the field is only visible to staff
+	IsPublic bool `json:"isPublic"`
+	Position int  `json:"position"`
+}
+
+// SplitCustomFieldValues returns each option of a multi select value
+func SplitCustomFieldValues(value string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// NormalizeValue trims the value and, for multi select fields, removes blanks between options
+func (f *CustomField) NormalizeValue(value string) string {
+	if f.Type == enum.CustomFieldMultiSelect {
+		return strings.Join(SplitCustomFieldValues(value), ",")
+	}
+	return strings.TrimSpace(value)
+}
+
+// IsValidValue returns true if value is acceptable for this field
+func (f *CustomField) IsValidValue(value string) bool {
+	switch f.Type {
+	case enum.CustomFieldText:
+		return len(value) <= 1000
+	case enum.CustomFieldNumber:
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	case enum.CustomFieldSelect:
+		return slices.Contains(f.Options, value)
+	case enum.CustomFieldMultiSelect:
+		values := SplitCustomFieldValues(value)
+		if len(values) == 0 {
+			return false
+		}
+		for _, v := range values {
+			if !slices.Contains(f.Options, v) {
+				return false
+			}
+		}
+		return true
+	case enum.CustomFieldDate:
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case enum.CustomFieldURL:
+		u, err := url.ParseRequestURI(value)
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	case enum.CustomFieldCheckbox:
+		return value == "true" || value == "false"
+	}
+	return false
+}
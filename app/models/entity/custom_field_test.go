@@ -0,0 +1,51 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestCustomField_IsValidValue(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		fieldType enum.CustomFieldType
+		value     string
+		valid     bool
+	}{
+		{enum.CustomFieldText, "Anything goes", true},
+		{enum.CustomFieldNumber, "42", true},
+		{enum.CustomFieldNumber, "3.14", true},
+		{enum.CustomFieldNumber, "three", false},
+		{enum.CustomFieldSelect, "ios", true},
+		{enum.CustomFieldSelect, "windows", false},
+		{enum.CustomFieldMultiSelect, "ios, android", true},
+		{enum.CustomFieldMultiSelect, "ios,windows", false},
+		{enum.CustomFieldMultiSelect, ",", false},
+		{enum.CustomFieldDate, "2026-10-19", true},
+		{enum.CustomFieldDate, "19/10/2026", false},
+		{enum.CustomFieldURL, "https://github.com/getfider/fider", true},
+		{enum.CustomFieldURL, "ftp://example.com", false},
+		{enum.CustomFieldURL, "not a url", false},
+		{enum.CustomFieldCheckbox, "true", true},
+		{enum.CustomFieldCheckbox, "yes", false},
+	}
+
+	for _, testCase := range testCases {
+		field := &entity.CustomField{Type: testCase.fieldType, Options: []string{"ios", "android"}}
+		Expect(field.IsValidValue(testCase.value)).Equals(testCase.valid)
+	}
+}
+
+func TestCustomField_NormalizeValue(t *testing.T) {
+	RegisterT(t)
+
+	multi := &entity.CustomField{Type: enum.CustomFieldMultiSelect}
+	Expect(multi.NormalizeValue(" ios , android,")).Equals("ios,android")
+
+	text := &entity.CustomField{Type: enum.CustomFieldText}
+	Expect(text.NormalizeValue("  hello world ")).Equals("hello world")
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// SimilarPost is an existing post that may be a duplicate of the one being written
+type SimilarPost struct {
+	*Post
+	// Confidence is a score between 0 and 1 of how likely both posts are about the same thing
+	Confidence float64 `json:"confidence"`
+}
+
+// PostDuplicateCandidate is a pair of posts found by the duplicate detection job
+type PostDuplicateCandidate struct {
+	ID        int           `json:"id"`
+	Post      *OriginalPost `json:"post"`
+	Original  *OriginalPost `json:"original"`
+	Score     float64       `json:"score"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
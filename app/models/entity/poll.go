@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+)
+
+// Poll is a question asked by staff on a post or on one of their comments
+type Poll struct {
+	ID         int `json:"id"`
+	PostID     int `json:"-"`
+	PostNumber int `json:"postNumber"`
+	// CommentID is zero when the poll is attached to the post itself
+	CommentID        int           `json:"commentId,omitempty"`
+	Question         string        `json:"question"`
+	IsMultipleChoice bool          `json:"isMultipleChoice"`
+	IsVotersOnly     bool          `json:"isVotersOnly"`
+	ClosesAt         *time.Time    `json:"closesAt,omitempty"`
+	Options          []*PollOption `json:"options"`
+	// VotersCount is the number of people who answered the poll
+	VotersCount int `json:"votersCount"`
+	// MyAnswers are the IDs of the options chosen by current user
+	MyAnswers []int     `json:"myAnswers"`
+	CreatedBy *User     `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// PollOption is one of the choices of a poll
+type PollOption struct {
+	ID           int    `json:"id"`
+	Text         string `json:"text"`
+	AnswersCount int    `json:"answersCount"`
+}
+
+// IsClosed returns true if the poll no longer accepts answers
+func (p *Poll) IsClosed(now time.Time) bool {
+	return p.ClosesAt != nil && !p.ClosesAt.After(now)
+}
+
+// HasOption returns true if given option belongs to this poll
+func (p *Poll) HasOption(optionID int) bool {
+	for _, option := range p.Options {
+		if option.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
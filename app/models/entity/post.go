@@ -23,9 +23,35 @@ type Post struct {
 	Response      *PostResponse   `json:"response,omitempty"`
 	Tags          []string        `json:"tags"`
 	IsApproved    bool            `json:"isApproved"`
+	// BoardID is zero when the post doesn't belong to any board
+	BoardID   int    `json:"boardId,omitempty"`
+	BoardSlug string `json:"boardSlug,omitempty"`
+	// CustomFields maps custom field keys to values; staff-only fields are omitted for visitors
+	CustomFields map[string]string `json:"customFields,omitempty"`
 	// PinnedAt is set when staff pinned the post; pinned posts appear first in lists
 	PinnedAt *time.Time `json:"pinnedAt,omitempty"`
 	PinnedBy *User      `json:"pinnedBy,omitempty"`
+	// IsAnonymous hides the author from everyone but the author themselves
+	IsAnonymous bool `json:"isAnonymous"`
+	// LockedAt is set when staff locked the post; locked posts don't accept new comments, votes or reactions
+	LockedAt   *time.Time `json:"lockedAt,omitempty"`
+	LockedBy   *User      `json:"lockedBy,omitempty"`
+	LockReason string     `json:"lockReason,omitempty"`
+	// CoAuthorIDs are the users who can edit the post and get its notifications along with the owner
+	CoAuthorIDs []int `json:"coAuthorIds"`
+	// ViewsCount is the number of sessions that viewed the post, see RecordPostView
+	ViewsCount int `json:"viewsCount"`
+	// Links are the external references of the post, such as issues implementing it
+	Links []*PostLink `json:"links,omitempty"`
+	// AcceptedAnswer is the comment marked by staff or the author as the answer to the post
+	AcceptedAnswer *PostAnswer `json:"acceptedAnswer,omitempty"`
+	// ReactionCounts are the emoji reactions on the post itself, which don't count as votes
+	ReactionCounts []ReactionCounts `json:"reactionCounts,omitempty"`
+}
+
+// AnonymousAuthor is the user shown in place of the author of an anonymous post
+func AnonymousAuthor() *User {
+	return &User{Name: "Anonymous", Role: enum.RoleVisitor}
 }
 
 // CanBeVoted returns true if this post can have its vote changed
@@ -33,6 +59,21 @@ func (i *Post) CanBeVoted() bool {
 	return i.Status != enum.PostCompleted && i.Status != enum.PostDeclined && i.Status != enum.PostDuplicate
 }
 
+// IsLocked returns true if this post is frozen for new comments, votes and reactions
+func (i *Post) IsLocked() bool {
+	return i.LockedAt != nil
+}
+
+// IsCoAuthor returns true if given user is a co-author of this post
+func (i *Post) IsCoAuthor(userID int) bool {
+	for _, id := range i.CoAuthorIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
 func (i *Post) Url(baseURL string) string {
 	return fmt.Sprintf("%s/posts/%d/%s", baseURL, i.Number, i.Slug)
 }
@@ -45,6 +86,14 @@ type PostResponse struct {
 	Original    *OriginalPost `json:"original"`
 }
 
+// PostAnswer is a comment accepted as the answer to a given post
+type PostAnswer struct {
+	CommentID  int       `json:"commentId"`
+	Content    string    `json:"content"`
+	User       *User     `json:"user"`
+	AcceptedAt time.Time `json:"acceptedAt"`
+}
+
 //OriginalPost holds details of the original post of a duplicate
 type OriginalPost struct {
 	Number int             `json:"number"`
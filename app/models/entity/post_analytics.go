@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// PostAnalytics holds the engagement numbers of a post
+type PostAnalytics struct {
+	ViewsCount    int `json:"viewsCount"`
+	VotersCount   int `json:"votersCount"`
+	CommentsCount int `json:"commentsCount"`
+	// ConversionRate is the ratio of voters to views, between 0 and 1
+	ConversionRate float64         `json:"conversionRate"`
+	Series         []*PostActivity `json:"series"`
+}
+
+// PostActivity holds the number of views, votes and comments of a post on a given day
+type PostActivity struct {
+	Date     time.Time `json:"date"`
+	Views    int       `json:"views"`
+	Votes    int       `json:"votes"`
+	Comments int       `json:"comments"`
+}
+
+// VoteConversionRate returns the ratio of voters to views, capped at 1 as views started being counted after some votes were cast
+func VoteConversionRate(voters, views int) float64 {
+	if views == 0 {
+		return 0
+	}
+	rate := float64(voters) / float64(views)
+	if rate > 1 {
+		return 1
+	}
+	return rate
+}
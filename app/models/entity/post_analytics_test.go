@@ -0,0 +1,17 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestVoteConversionRate(t *testing.T) {
+	RegisterT(t)
+
+	Expect(entity.VoteConversionRate(0, 0)).Equals(0.0)
+	Expect(entity.VoteConversionRate(5, 0)).Equals(0.0)
+	Expect(entity.VoteConversionRate(5, 20)).Equals(0.25)
+	Expect(entity.VoteConversionRate(30, 20)).Equals(1.0)
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Types of external references that can be linked to a post
+const (
+	PostLinkGitHub = "github"
+	PostLinkGitLab = "gitlab"
+	PostLinkJira   = "jira"
+	PostLinkURL    = "url"
+)
+
+// States of an external reference
+const (
+	PostLinkOpen   = "open"
+	PostLinkClosed = "closed"
+	PostLinkMerged = "merged"
+)
+
+// PostLink is an external reference (issue, merge request, ticket or page) linked to a post
+type PostLink struct {
+	ID int `json:"id"`
+	// PostID isn't exposed as links are always returned as part of their post
+	PostID int    `json:"-"`
+	Type   string `json:"type"`
+	// ExternalID identifies the reference on its tracker, e.g. 'owner/repo#12', 'group/project!3' or 'PROJ-42'
+	ExternalID  string    `json:"externalId"`
+	URL         string    `json:"url"`
+	State       string    `json:"state"`
+	CreatedByID int       `json:"-"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// IsDone returns true if the referenced work is finished
+func (l *PostLink) IsDone() bool {
+	return l.State == PostLinkClosed || l.State == PostLinkMerged
+}
+
+// IsValidPostLinkType returns true if given value is a known type of link
+func IsValidPostLinkType(linkType string) bool {
+	switch linkType {
+	case PostLinkGitHub, PostLinkGitLab, PostLinkJira, PostLinkURL:
+		return true
+	}
+	return false
+}
+
+// IsValidPostLinkState returns true if given value is a known state of link
+func IsValidPostLinkState(state string) bool {
+	switch state {
+	case PostLinkOpen, PostLinkClosed, PostLinkMerged:
+		return true
+	}
+	return false
+}
+
+var (
+	githubPathRegex = regexp.MustCompile(`^/([^/]+)/([^/]+)/(?:issues|pull)/(\d+)/?$`)
+	gitlabPathRegex = regexp.MustCompile(`^/(.+?)/-/(issues|merge_requests)/(\d+)/?$`)
+	jiraKeyRegex    = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-\d+$`)
+)
+
+// ParsePostLinkExternalID returns the identifier of the reference behind given URL, used to match inbound webhooks.
+// Links of type 'url' have no identifier.
+func ParsePostLinkExternalID(linkType, rawurl string) (string, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", err
+	}
+
+	switch linkType {
+	case PostLinkGitHub:
+		if m := githubPathRegex.FindStringSubmatch(u.Path); m != nil {
+			return GitHubExternalID(m[1]+"/"+m[2], m[3]), nil
+		}
+	case PostLinkGitLab:
+		if m := gitlabPathRegex.FindStringSubmatch(u.Path); m != nil {
+			return GitLabExternalID(m[1], m[2] == "merge_requests", m[3]), nil
+		}
+	case PostLinkJira:
+		parts := strings.Split(strings.TrimSuffix(u.Path, "/"), "/")
+		if key := strings.ToUpper(parts[len(parts)-1]); jiraKeyRegex.MatchString(key) {
+			return key, nil
+		}
+	case PostLinkURL:
+		return "", nil
+	}
+	return "", fmt.Errorf("'%s' is not a valid %s link", rawurl, linkType)
+}
+
+// GitHubExternalID returns the identifier of an issue or pull request, which share the same numbering on GitHub
+func GitHubExternalID(repository string, number any) string {
+	return strings.ToLower(fmt.Sprintf("%s#%v", repository, number))
+}
+
+// GitLabExternalID returns the identifier of an issue or merge request on GitLab
+func GitLabExternalID(project string, isMergeRequest bool, iid any) string {
+	separator := "#"
+	if isMergeRequest {
+		separator = "!"
+	}
+	return strings.ToLower(fmt.Sprintf("%s%s%v", project, separator, iid))
+}
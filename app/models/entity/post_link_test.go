@@ -0,0 +1,58 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestParsePostLinkExternalID(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		linkType string
+		url      string
+		expected string
+	}{
+		{entity.PostLinkGitHub, "https://github.com/getfider/fider/issues/123", "getfider/fider#123"},
+		{entity.PostLinkGitHub, "https://github.com/GetFider/Fider/pull/45/", "getfider/fider#45"},
+		{entity.PostLinkGitLab, "https://gitlab.com/group/sub/project/-/issues/7", "group/sub/project#7"},
+		{entity.PostLinkGitLab, "https://gitlab.com/group/project/-/merge_requests/8", "group/project!8"},
+		{entity.PostLinkJira, "https://acme.atlassian.net/browse/PROJ-42", "PROJ-42"},
+		{entity.PostLinkURL, "https://example.com/roadmap", ""},
+	}
+
+	for _, testCase := range testCases {
+		externalID, err := entity.ParsePostLinkExternalID(testCase.linkType, testCase.url)
+		Expect(err).IsNil()
+		Expect(externalID).Equals(testCase.expected)
+	}
+}
+
+func TestParsePostLinkExternalID_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		linkType string
+		url      string
+	}{
+		{entity.PostLinkGitHub, "https://github.com/getfider/fider"},
+		{entity.PostLinkGitHub, "https://github.com/getfider/fider/issues/abc"},
+		{entity.PostLinkGitLab, "https://gitlab.com/group/project/issues/7"},
+		{entity.PostLinkJira, "https://acme.atlassian.net/browse/"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := entity.ParsePostLinkExternalID(testCase.linkType, testCase.url)
+		Expect(err).IsNotNil()
+	}
+}
+
+func TestPostLink_IsDone(t *testing.T) {
+	RegisterT(t)
+
+	Expect((&entity.PostLink{State: entity.PostLinkOpen}).IsDone()).IsFalse()
+	Expect((&entity.PostLink{State: entity.PostLinkClosed}).IsDone()).IsTrue()
+	Expect((&entity.PostLink{State: entity.PostLinkMerged}).IsDone()).IsTrue()
+}
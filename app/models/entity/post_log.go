@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Actions stored on the activity log of a post
+const (
+	PostLogStaleWarning      = "stale_warning"
+	PostLogStaleClosed       = "stale_closed"
+	PostLogScheduledResponse = "scheduled_response"
+	PostLogAuthorRevealed    = "author_revealed"
+	PostLogLocked            = "locked"
+	PostLogUnlocked          = "unlocked"
+	PostLogOwnerChanged      = "owner_changed"
+	PostLogCoAuthorAdded     = "coauthor_added"
+	PostLogCoAuthorRemoved   = "coauthor_removed"
+	PostLogLinkAdded         = "link_added"
+	PostLogLinkRemoved       = "link_removed"
+	PostLogLinkDone          = "link_done"
+	PostLogAnswerAccepted    = "answer_accepted"
+	PostLogAnswerCleared     = "answer_cleared"
+	PostLogRuleApplied       = "rule_applied"
+)
+
+// PostLog is an entry on the activity log of a post
+type PostLog struct {
+	ID        int       `json:"id"`
+	Action    string    `json:"action"`
+	Details   string    `json:"details"`
+	UserID    int       `json:"userId,omitempty"`
+	UserName  string    `json:"userName,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
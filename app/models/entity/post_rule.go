@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// Parts of a post the keywords and pattern of a rule are matched against
+const (
+	PostRuleMatchAny         = ""
+	PostRuleMatchTitle       = "title"
+	PostRuleMatchDescription = "description"
+)
+
+// PostRule is evaluated when a post is created or edited.
+// A rule matches when every condition that is set matches; list conditions match any of their values
+type PostRule struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	IsEnabled bool   `json:"isEnabled"`
+	Position  int    `json:"position"`
+
+	// Keywords are matched as case insensitive words on the title and/or description
+	Keywords []string `json:"keywords"`
+	// Pattern is a regular expression matched on the title and/or description
+	Pattern string `json:"pattern"`
+	// MatchIn restricts keywords and pattern to the title or the description, both when empty
+	MatchIn      string      `json:"matchIn"`
+	EmailDomains []string    `json:"emailDomains"`
+	Roles        []enum.Role `json:"roles"`
+	BoardSlugs   []string    `json:"boards"`
+
+	// Tags are the slugs of tags assigned to matching posts
+	Tags []string `json:"tags"`
+	// Status is set on matching posts when they are created
+	Status            *enum.PostStatus `json:"status,omitempty"`
+	SubscriberIDs     []int            `json:"subscriberIds"`
+	FlagForModeration bool             `json:"flagForModeration"`
+
+	compileOnce sync.Once
+	keywords    []*regexp.Regexp
+	pattern     *regexp.Regexp
+}
+
+// PostRuleSubject is what post rules are evaluated against
+type PostRuleSubject struct {
+	PostID      int
+	Number      int
+	Slug        string
+	Title       string
+	Description string
+	AuthorEmail string
+	AuthorRole  enum.Role
+	BoardSlug   string
+}
+
+// HasConditions returns true if at least one condition is set
+func (r *PostRule) HasConditions() bool {
+	return len(r.Keywords) > 0 || r.Pattern != "" || len(r.EmailDomains) > 0 || len(r.Roles) > 0 || len(r.BoardSlugs) > 0
+}
+
+// HasActions returns true if at least one action is set
+func (r *PostRule) HasActions() bool {
+	return len(r.Tags) > 0 || r.Status != nil || len(r.SubscriberIDs) > 0 || r.FlagForModeration
+}
+
+// Matches returns true if given post fulfills every condition of the rule
+func (r *PostRule) Matches(subject *PostRuleSubject) bool {
+	if !r.HasConditions() {
+		return false
+	}
+
+	text := subject.Title + "\n" + subject.Description
+	if r.MatchIn == PostRuleMatchTitle {
+		text = subject.Title
+	} else if r.MatchIn == PostRuleMatchDescription {
+		text = subject.Description
+	}
+
+	r.compileOnce.Do(r.compile)
+
+	if len(r.Keywords) > 0 && !slices.ContainsFunc(r.keywords, func(keyword *regexp.Regexp) bool {
+		return keyword.MatchString(text)
+	}) {
+		return false
+	}
+
+	// An invalid pattern never matches
+	if r.Pattern != "" && (r.pattern == nil || !r.pattern.MatchString(text)) {
+		return false
+	}
+
+	if len(r.EmailDomains) > 0 {
+		_, domain, _ := strings.Cut(strings.ToLower(subject.AuthorEmail), "@")
+		if domain == "" || !slices.Contains(r.EmailDomains, domain) {
+			return false
+		}
+	}
+
+	if len(r.Roles) > 0 && !slices.Contains(r.Roles, subject.AuthorRole) {
+		return false
+	}
+
+	if len(r.BoardSlugs) > 0 && !slices.Contains(r.BoardSlugs, subject.BoardSlug) {
+		return false
+	}
+
+	return true
+}
+
+// compile builds the regular expressions of the rule, so they are compiled once per rule instead of once per post
+func (r *PostRule) compile() {
+	r.keywords = make([]*regexp.Regexp, len(r.Keywords))
+	for i, keyword := range r.Keywords {
+		r.keywords[i] = wordPattern(keyword)
+	}
+	if r.Pattern != "" {
+		r.pattern, _ = regexp.Compile(r.Pattern)
+	}
+}
+
+// wordPattern matches keyword as a whole word, ignoring case
+func wordPattern(keyword string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)(^|\W)` + regexp.QuoteMeta(strings.TrimSpace(keyword)) + `($|\W)`)
+}
@@ -0,0 +1,58 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+var crashReport = &entity.PostRuleSubject{
+	Title:       "App crashes on startup",
+	Description: "Since the last update the iOS app crashes when I open it.",
+	AuthorEmail: "Jon.Snow@Example.com",
+	AuthorRole:  enum.RoleVisitor,
+	BoardSlug:   "mobile",
+}
+
+func TestPostRule_Matches_Keywords(t *testing.T) {
+	RegisterT(t)
+
+	Expect((&entity.PostRule{Keywords: []string{"crash", "crashes"}}).Matches(crashReport)).IsTrue()
+	Expect((&entity.PostRule{Keywords: []string{"IOS"}}).Matches(crashReport)).IsTrue()
+	Expect((&entity.PostRule{Keywords: []string{"crash"}}).Matches(crashReport)).IsFalse()
+	Expect((&entity.PostRule{Keywords: []string{"ios"}, MatchIn: entity.PostRuleMatchTitle}).Matches(crashReport)).IsFalse()
+	Expect((&entity.PostRule{Keywords: []string{"startup"}, MatchIn: entity.PostRuleMatchDescription}).Matches(crashReport)).IsFalse()
+}
+
+func TestPostRule_Matches_Pattern(t *testing.T) {
+	RegisterT(t)
+
+	Expect((&entity.PostRule{Pattern: `(?i)crash(es|ed)?`}).Matches(crashReport)).IsTrue()
+	Expect((&entity.PostRule{Pattern: `^Since`, MatchIn: entity.PostRuleMatchDescription}).Matches(crashReport)).IsTrue()
+	Expect((&entity.PostRule{Pattern: `^Since`, MatchIn: entity.PostRuleMatchTitle}).Matches(crashReport)).IsFalse()
+	Expect((&entity.PostRule{Pattern: `(unclosed`}).Matches(crashReport)).IsFalse()
+}
+
+func TestPostRule_Matches_Author(t *testing.T) {
+	RegisterT(t)
+
+	Expect((&entity.PostRule{EmailDomains: []string{"example.com"}}).Matches(crashReport)).IsTrue()
+	Expect((&entity.PostRule{EmailDomains: []string{"fider.io"}}).Matches(crashReport)).IsFalse()
+	Expect((&entity.PostRule{EmailDomains: []string{"example.com"}}).Matches(&entity.PostRuleSubject{Title: "No email"})).IsFalse()
+	Expect((&entity.PostRule{Roles: []enum.Role{enum.RoleVisitor, enum.RoleCollaborator}}).Matches(crashReport)).IsTrue()
+	Expect((&entity.PostRule{Roles: []enum.Role{enum.RoleAdministrator}}).Matches(crashReport)).IsFalse()
+}
+
+func TestPostRule_Matches_AllConditions(t *testing.T) {
+	RegisterT(t)
+
+	rule := &entity.PostRule{Keywords: []string{"crashes"}, BoardSlugs: []string{"mobile", "desktop"}}
+	Expect(rule.Matches(crashReport)).IsTrue()
+
+	rule.BoardSlugs = []string{"desktop"}
+	Expect(rule.Matches(crashReport)).IsFalse()
+
+	Expect((&entity.PostRule{}).Matches(crashReport)).IsFalse()
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+)
+
+var markdownHeading = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+
+// PostTemplate is a markdown skeleton offered when creating a post
+type PostTemplate struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	// RequiredSections are the headings of Content that can't be left empty
+	RequiredSections []string `json:"requiredSections"`
+	// TagSlug is assigned to every post created with this template
+	TagSlug string `json:"tag,omitempty"`
+	// BoardSlug restricts this template to posts of a board
+	BoardSlug string `json:"board,omitempty"`
+	BoardID   int    `json:"-"`
+	Position  int    `json:"position"`
+}
+
+// HasSection returns true if given heading exists on the template content
+func (t *PostTemplate) HasSection(name string) bool {
+	_, ok := ParseMarkdownSections(t.Content)[normalizeSection(name)]
+	return ok
+}
+
+// EmptySections returns the required sections that are missing or left untouched on given description
+func (t *PostTemplate) EmptySections(description string) []string {
+	skeleton := ParseMarkdownSections(t.Content)
+	sections := ParseMarkdownSections(description)
+
+	empty := make([]string, 0)
+	for _, name := range t.RequiredSections {
+		key := normalizeSection(name)
+		body, ok := sections[key]
+		if !ok || body == "" || body == skeleton[key] {
+			empty = append(empty, name)
+		}
+	}
+	return empty
+}
+
+// ParseMarkdownSections returns the trimmed body of each heading of given markdown, keyed by the lowercase heading
+func ParseMarkdownSections(markdown string) map[string]string {
+	sections := make(map[string]string)
+
+	current := ""
+	found := false
+	inCodeBlock := false
+	body := make([]string, 0)
+	flush := func() {
+		if found {
+			sections[current] = strings.TrimSpace(strings.Join(body, "\n"))
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = !inCodeBlock
+		} else if match := markdownHeading.FindStringSubmatch(line); match != nil && !inCodeBlock {
+			flush()
+			current = normalizeSection(match[1])
+			found = true
+			body = body[:0]
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+
+	return sections
+}
+
+func normalizeSection(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,41 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+var bugReportTemplate = &entity.PostTemplate{
+	Content:          "## Steps to reproduce\n\n1. \n\n## Expected behavior\n\n## Environment\n\n```bash\n# output of fider --version\n```\n",
+	RequiredSections: []string{"Steps to reproduce", "Expected behavior"},
+}
+
+func TestParseMarkdownSections(t *testing.T) {
+	RegisterT(t)
+
+	sections := entity.ParseMarkdownSections(bugReportTemplate.Content)
+	Expect(sections).HasLen(3)
+	Expect(sections["steps to reproduce"]).Equals("1.")
+	Expect(sections["expected behavior"]).Equals("")
+	Expect(sections["environment"]).Equals("```bash\n# output of fider --version\n```")
+}
+
+func TestPostTemplate_HasSection(t *testing.T) {
+	RegisterT(t)
+
+	Expect(bugReportTemplate.HasSection("Steps to reproduce")).IsTrue()
+	Expect(bugReportTemplate.HasSection(" expected BEHAVIOR ")).IsTrue()
+	Expect(bugReportTemplate.HasSection("output of fider --version")).IsFalse()
+	Expect(bugReportTemplate.HasSection("Screenshots")).IsFalse()
+}
+
+func TestPostTemplate_EmptySections(t *testing.T) {
+	RegisterT(t)
+
+	Expect(bugReportTemplate.EmptySections(bugReportTemplate.Content)).Equals([]string{"Steps to reproduce", "Expected behavior"})
+	Expect(bugReportTemplate.EmptySections("Something is broken")).Equals([]string{"Steps to reproduce", "Expected behavior"})
+	Expect(bugReportTemplate.EmptySections("## Steps to reproduce\n\n1. Open a post\n\n## Expected behavior\n")).Equals([]string{"Expected behavior"})
+	Expect(bugReportTemplate.EmptySections("## Steps to reproduce\r\n1. Open a post\r\n### Expected behavior\r\nIt works")).Equals([]string{})
+}
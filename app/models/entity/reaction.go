@@ -10,3 +10,18 @@ type Reaction struct {
 	User      *User     `json:"user"`
 	CreatedAt time.Time `json:"createdAt"`
 }
+
+// ReactionType is an option of the palette members can react with
+// Custom reactions have a short name as Emoji and an uploaded image to display it
+type ReactionType struct {
+	Emoji        string `json:"emoji"`
+	Name         string `json:"name"`
+	ImageBlobKey string `json:"imageBlobKey,omitempty"`
+}
+
+// Reactor is a user who reacted to a post or comment
+type Reactor struct {
+	Emoji     string    `json:"emoji"`
+	User      *User     `json:"user"`
+	CreatedAt time.Time `json:"createdAt"`
+}
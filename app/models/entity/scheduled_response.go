@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// ScheduledResponse is a status change and response that is applied to a post at a later time.
+// Without a schedule it's a draft that staff can review before it's used
+type ScheduledResponse struct {
+	ID          int             `json:"id"`
+	PostID      int             `json:"-"`
+	TenantID    int             `json:"-"`
+	Status      enum.PostStatus `json:"status"`
+	Text        string          `json:"text"`
+	ScheduledAt *time.Time      `json:"scheduledAt,omitempty"`
+	CreatedBy   *User           `json:"createdBy"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	// BaseURL of the request that scheduled the change, used to build links when notifying subscribers
+	BaseURL string `json:"-"`
+}
+
+// IsDraft returns true if this response is not going to be applied automatically
+func (r *ScheduledResponse) IsDraft() bool {
+	return r.ScheduledAt == nil
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// StalePostRules configures how open posts without any activity are warned about and closed,
+// and how long closed posts stay open for discussion
+type StalePostRules struct {
+	TenantID int `json:"-"`
+	// InactiveDays without votes, comments or responses before subscribers are warned. Zero disables the rules
+	InactiveDays int `json:"inactiveDays"`
+	// GraceDays after the warning before the post is closed
+	GraceDays   int             `json:"graceDays"`
+	CloseStatus enum.PostStatus `json:"closeStatus"`
+	Response    string          `json:"response"`
+	// ExemptTags are the slugs of tags that keep a post from ever being closed
+	ExemptTags []string `json:"exemptTags"`
+	// LockAfterDays since the last response before completed, declined and duplicate posts are locked. Zero disables it
+	LockAfterDays int `json:"lockAfterDays"`
+}
+
+// IsEnabled returns true if stale posts should be warned about and closed
+func (r *StalePostRules) IsEnabled() bool {
+	return r.InactiveDays > 0
+}
+
+// IsAutoLockEnabled returns true if closed posts should be locked
+func (r *StalePostRules) IsAutoLockEnabled() bool {
+	return r.LockAfterDays > 0
+}
@@ -1,5 +1,11 @@
 package entity
 
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
 //Tag represents a simple tag
 type Tag struct {
 	ID       int    `json:"id"`
@@ -7,4 +13,37 @@ type Tag struct {
 	Slug     string `json:"slug"`
 	Color    string `json:"color"`
 	IsPublic bool   `json:"isPublic"`
+	// GroupID is the group the tag belongs to, zero when ungrouped
+	GroupID int `json:"groupId,omitempty"`
+	// ParentID is the parent of a child tag, filtering by a parent includes its children
+	ParentID int `json:"parentId,omitempty"`
+}
+
+// TagGroup groups related tags, such as "Area" or "Platform"
+type TagGroup struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	// IsSingleSelect allows at most one tag of the group per post
+	IsSingleSelect bool `json:"isSingleSelect"`
+}
+
+// TagSubscription is a tag followed by a user, posts with the tag notify them on the given channels
+type TagSubscription struct {
+	Tag      *Tag                     `json:"tag"`
+	Channels enum.NotificationChannel `json:"channels"`
+	// IncludeComments also notifies about new comments, not only new posts and status changes
+	IncludeComments bool `json:"includeComments"`
+}
+
+// TagStats shows how much a tag is used
+type TagStats struct {
+	Tag            *Tag       `json:"tag"`
+	PostCount      int        `json:"postCount"`
+	VotesCount     int        `json:"votesCount"`
+	OpenCount      int        `json:"openCount"`
+	CompletedCount int        `json:"completedCount"`
+	OpenRatio      float64    `json:"openRatio"`
+	CompletedRatio float64    `json:"completedRatio"`
+	LastUsedAt     *time.Time `json:"lastUsedAt,omitempty"`
 }
@@ -24,12 +24,65 @@ type Tenant struct {
 	PreventIndexing     bool              `json:"preventIndexing"`
 	IsModerationEnabled      bool              `json:"isModerationEnabled"`
 	HasCommercialFeatures    bool              `json:"hasCommercialFeatures"`
+	// VoteBudget is the number of votes each user can have on open posts, zero means unlimited
+	VoteBudget              int  `json:"voteBudget"`
+	IsVoteImportanceEnabled bool `json:"isVoteImportanceEnabled"`
+	// VoteRoleWeights multiplies votes by the role of the voter, roles not listed count once
+	VoteRoleWeights map[string]int `json:"voteRoleWeights"`
+	// IsAnonymousPostingEnabled allows authors to hide their name on new posts
+	IsAnonymousPostingEnabled bool `json:"isAnonymousPostingEnabled"`
+	// IssueWebhookSecret verifies payloads sent by GitHub and GitLab to the issue tracker webhooks
+	IssueWebhookSecret string `json:"-"`
+	// Reactions is the palette members can react with, on both posts and comments
+	Reactions []*ReactionType `json:"reactions"`
+}
+
+// DefaultReactions is the palette used when a tenant hasn't defined its own
+var DefaultReactions = []*ReactionType{
+	{Emoji: "👍", Name: "+1"},
+	{Emoji: "👎", Name: "-1"},
+	{Emoji: "😄", Name: "Laugh"},
+	{Emoji: "🎉", Name: "Hooray"},
+	{Emoji: "😕", Name: "Confused"},
+	{Emoji: "❤️", Name: "Heart"},
+	{Emoji: "🚀", Name: "Rocket"},
+	{Emoji: "👀", Name: "Eyes"},
+}
+
+// ReactionPalette returns the reactions members can react with
+func (t *Tenant) ReactionPalette() []*ReactionType {
+	if len(t.Reactions) == 0 {
+		return DefaultReactions
+	}
+	return t.Reactions
+}
+
+// IsReactionAllowed returns true if members can react with given emoji
+func (t *Tenant) IsReactionAllowed(emoji string) bool {
+	for _, reaction := range t.ReactionPalette() {
+		if reaction.Emoji == emoji {
+			return true
+		}
+	}
+	return false
 }
 
 func (t *Tenant) IsDisabled() bool {
 	return t.Status == enum.TenantDisabled
 }
 
+// VoteWeight returns how many votes a vote from given user is worth
+func (t *Tenant) VoteWeight(user *User, importance enum.VoteImportance) int {
+	weight := 1
+	if t.IsVoteImportanceEnabled {
+		weight = importance.Weight()
+	}
+	if roleWeight, ok := t.VoteRoleWeights[user.Role.String()]; ok && roleWeight > 0 {
+		weight *= roleWeight
+	}
+	return weight
+}
+
 // TenantContact is a reference to an administrator account
 type TenantContact struct {
 	Name      string `json:"name"`
@@ -0,0 +1,46 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestTenant_VoteWeight(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	admin := &entity.User{ID: 2, Role: enum.RoleAdministrator}
+
+	tenant := &entity.Tenant{}
+	Expect(tenant.VoteWeight(visitor, enum.VoteMustHave)).Equals(1)
+	Expect(tenant.VoteWeight(admin, enum.VoteNiceToHave)).Equals(1)
+
+	tenant.IsVoteImportanceEnabled = true
+	Expect(tenant.VoteWeight(visitor, enum.VoteNiceToHave)).Equals(1)
+	Expect(tenant.VoteWeight(visitor, enum.VoteImportant)).Equals(2)
+	Expect(tenant.VoteWeight(visitor, enum.VoteMustHave)).Equals(3)
+
+	tenant.VoteRoleWeights = map[string]int{"administrator": 5, "visitor": 0}
+	Expect(tenant.VoteWeight(visitor, enum.VoteMustHave)).Equals(3)
+	Expect(tenant.VoteWeight(admin, enum.VoteImportant)).Equals(10)
+}
+
+func TestTenant_IsReactionAllowed(t *testing.T) {
+	RegisterT(t)
+
+	tenant := &entity.Tenant{}
+	Expect(tenant.ReactionPalette()).Equals(entity.DefaultReactions)
+	Expect(tenant.IsReactionAllowed("👍")).IsTrue()
+	Expect(tenant.IsReactionAllowed("party")).IsFalse()
+
+	tenant.Reactions = []*entity.ReactionType{
+		{Emoji: "🔥", Name: "Fire"},
+		{Emoji: "party", Name: "Party", ImageBlobKey: "reactions/party.png"},
+	}
+	Expect(tenant.IsReactionAllowed("🔥")).IsTrue()
+	Expect(tenant.IsReactionAllowed("party")).IsTrue()
+	Expect(tenant.IsReactionAllowed("👍")).IsFalse()
+}
@@ -2,6 +2,8 @@ package entity
 
 import (
 	"time"
+
+	"github.com/getfider/fider/app/models/enum"
 )
 
 //VoteUser represents a user that voted on a post
@@ -14,6 +16,10 @@ type VoteUser struct {
 
 //Vote represents a vote given by a user on a post
 type Vote struct {
-	User      *VoteUser `json:"user"`
-	CreatedAt time.Time `json:"createdAt"`
+	User       *VoteUser           `json:"user"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	Importance enum.VoteImportance `json:"importance"`
+	Weight     int                 `json:"weight"`
+	ProxiedBy  *VoteUser           `json:"proxiedBy,omitempty"`
+	ProxyNote  string              `json:"proxyNote,omitempty"`
 }
@@ -0,0 +1,66 @@
+package enum
+
+// CustomFieldType is the kind of value accepted by a custom field
+type CustomFieldType int
+
+const (
+	// CustomFieldText accepts any free-form text
+	CustomFieldText CustomFieldType = 1
+	// CustomFieldNumber accepts integer or decimal numbers
+	CustomFieldNumber CustomFieldType = 2
+	// CustomFieldSelect accepts exactly one of the configured options
+	CustomFieldSelect CustomFieldType = 3
+	// CustomFieldMultiSelect accepts one or more of the configured options
+	CustomFieldMultiSelect CustomFieldType = 4
+	// CustomFieldDate accepts a date in the YYYY-MM-DD format
+	CustomFieldDate CustomFieldType = 5
+	// CustomFieldURL accepts an absolute http or https URL
+	CustomFieldURL CustomFieldType = 6
+	// CustomFieldCheckbox accepts true or false
+	CustomFieldCheckbox CustomFieldType = 7
+)
+
+var customFieldTypeIDs = map[CustomFieldType]string{
+	CustomFieldText:        "text",
+	CustomFieldNumber:      "number",
+	CustomFieldSelect:      "select",
+	CustomFieldMultiSelect: "multiselect",
+	CustomFieldDate:        "date",
+	CustomFieldURL:         "url",
+	CustomFieldCheckbox:    "checkbox",
+}
+
+var customFieldTypeNames = map[string]CustomFieldType{
+	"text":        CustomFieldText,
+	"number":      CustomFieldNumber,
+	"select":      CustomFieldSelect,
+	"multiselect": CustomFieldMultiSelect,
+	"date":        CustomFieldDate,
+	"url":         CustomFieldURL,
+	"checkbox":    CustomFieldCheckbox,
+}
+
+// MarshalText returns the Text version of the custom field type
+func (t CustomFieldType) MarshalText() ([]byte, error) {
+	return []byte(customFieldTypeIDs[t]), nil
+}
+
+// UnmarshalText parse string into a custom field type
+func (t *CustomFieldType) UnmarshalText(text []byte) error {
+	*t = customFieldTypeNames[string(text)]
+	return nil
+}
+
+// Name returns the name of a custom field type
+func (t CustomFieldType) Name() string {
+	name, ok := customFieldTypeIDs[t]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
+
+// HasOptions returns true if values of this type must be one of the configured options
+func (t CustomFieldType) HasOptions() bool {
+	return t == CustomFieldSelect || t == CustomFieldMultiSelect
+}
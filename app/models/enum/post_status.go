@@ -58,3 +58,8 @@ func (status PostStatus) Name() string {
 	}
 	return "unknown"
 }
+
+// IsActive returns true while a post is still open for voting
+func (status PostStatus) IsActive() bool {
+	return status == PostOpen || status == PostPlanned || status == PostStarted
+}
@@ -0,0 +1,53 @@
+package enum
+
+// VoteImportance is how much a voter needs the post they voted for
+type VoteImportance int
+
+const (
+	// VoteNiceToHave is the default importance of a vote
+	VoteNiceToHave VoteImportance = 1
+	// VoteImportant counts twice as much as a nice to have vote
+	VoteImportant VoteImportance = 2
+	// VoteMustHave counts three times as much as a nice to have vote
+	VoteMustHave VoteImportance = 3
+)
+
+var voteImportanceIDs = map[VoteImportance]string{
+	VoteNiceToHave: "nice-to-have",
+	VoteImportant:  "important",
+	VoteMustHave:   "must-have",
+}
+
+var voteImportanceNames = map[string]VoteImportance{
+	"nice-to-have": VoteNiceToHave,
+	"important":    VoteImportant,
+	"must-have":    VoteMustHave,
+}
+
+// MarshalText returns the Text version of the vote importance
+func (i VoteImportance) MarshalText() ([]byte, error) {
+	return []byte(voteImportanceIDs[i]), nil
+}
+
+// UnmarshalText parse string into a vote importance
+func (i *VoteImportance) UnmarshalText(text []byte) error {
+	*i = voteImportanceNames[string(text)]
+	return nil
+}
+
+// Name returns the name of a vote importance
+func (i VoteImportance) Name() string {
+	name, ok := voteImportanceIDs[i]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Weight returns how many votes a vote of this importance is worth
+func (i VoteImportance) Weight() int {
+	if _, ok := voteImportanceIDs[i]; ok {
+		return int(i)
+	}
+	return 1
+}
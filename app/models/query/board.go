@@ -0,0 +1,21 @@
+package query
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type GetBoardBySlug struct {
+	Slug string
+
+	Result *entity.Board
+}
+
+type GetBoardByID struct {
+	BoardID int
+
+	Result *entity.Board
+}
+
+type GetAllBoards struct {
+	Result []*entity.Board
+}
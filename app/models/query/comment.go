@@ -12,6 +12,10 @@ type GetCommentByID struct {
 
 type GetCommentsByPost struct {
 	Post *entity.Post
+	// IncludeTombstones returns deleted comments that still have replies, without their content
+	IncludeTombstones bool
+	// PublicOnly leaves out internal notes even when current user is a collaborator
+	PublicOnly bool
 
 	Result []*entity.Comment
 }
@@ -0,0 +1,14 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+type GetCustomFieldByKey struct {
+	Key string
+
+	Result *entity.CustomField
+}
+
+// GetAllCustomFields returns the custom fields visible to current user, ordered by position
+type GetAllCustomFields struct {
+	Result []*entity.CustomField
+}
@@ -0,0 +1,14 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// GetPostDuplicateCandidates returns the pairs of possible duplicates that are still open and not dismissed
+type GetPostDuplicateCandidates struct {
+	Result []*entity.PostDuplicateCandidate
+}
+
+type GetPostDuplicateCandidateByID struct {
+	CandidateID int
+
+	Result *entity.PostDuplicateCandidate
+}
@@ -26,6 +26,21 @@ type GetActiveSubscribers struct {
 	Result []*entity.User
 }
 
+// GetTagFollowers returns the users to notify when given tag is assigned to given post,
+// which are those following the tag or one of its parents
+type GetTagFollowers struct {
+	Post    *entity.Post
+	Tag     *entity.Tag
+	Channel enum.NotificationChannel
+
+	Result []*entity.User
+}
+
+// GetTagSubscriptions returns the tags followed by current user
+type GetTagSubscriptions struct {
+	Result []*entity.TagSubscription
+}
+
 type GetMentionNotifications struct {
 	CommentID int
 	PostID    int
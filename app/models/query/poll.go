@@ -0,0 +1,20 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+type GetPollsByPost struct {
+	PostID int
+
+	Result []*entity.Poll
+}
+
+type GetPollByID struct {
+	PollID int
+
+	Result *entity.Poll
+}
+
+// GetAllPolls returns the polls of all posts, used to export results
+type GetAllPolls struct {
+	Result []*entity.Poll
+}
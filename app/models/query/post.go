@@ -21,6 +21,13 @@ type GetPostByID struct {
 	Result *entity.Post
 }
 
+// GetPostAuthor returns the author of a post, even when the post is anonymous
+type GetPostAuthor struct {
+	PostID int
+
+	Result *entity.User
+}
+
 type GetPostBySlug struct {
 	Slug string
 
@@ -43,6 +50,11 @@ type SearchPosts struct {
 	NoTagsOnly       bool
 	MyPostsOnly      bool
 	ModerationFilter string // "pending", "approved", or empty (all)
+	CustomFields     map[string]string
+	Board            string // board slug, or empty for posts of all boards
+	LinkType         string // link type, "any" for posts with links or "none" for posts without links
+	LinkState        string // state of the links, e.g. "open" or "closed"
+	Answered         string // "answered", "unanswered" or empty (all)
 
 	Result []*entity.Post
 }
@@ -50,7 +62,7 @@ type SearchPosts struct {
 type FindSimilarPosts struct {
 	Query string
 
-	Result []*entity.Post
+	Result []*entity.SimilarPost
 }
 
 type GetAllPosts struct {
@@ -77,12 +89,14 @@ type LeaderboardUser struct {
 // GetTopPostsByVotes returns posts ordered by votes count (for leaderboard)
 type GetTopPostsByVotes struct {
 	Limit  int
+	Board  string
 	Result []*LeaderboardPost
 }
 
 // GetTopUsersByVotes returns users ranked by total votes received on their posts (for leaderboard)
 type GetTopUsersByVotes struct {
 	Limit  int
+	Board  string
 	Result []*LeaderboardUser
 }
 
@@ -0,0 +1,15 @@
+package query
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+// GetPostAnalytics returns the engagement numbers of a post and its daily activity since given date
+type GetPostAnalytics struct {
+	PostID int
+	Since  time.Time
+
+	Result *entity.PostAnalytics
+}
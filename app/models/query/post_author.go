@@ -0,0 +1,10 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// GetPostCoAuthors returns the co-authors of a post
+type GetPostCoAuthors struct {
+	PostID int
+
+	Result []*entity.User
+}
@@ -0,0 +1,19 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// GetPostLinkByID returns an external reference of given post
+type GetPostLinkByID struct {
+	PostID int
+	LinkID int
+
+	Result *entity.PostLink
+}
+
+// GetPostLinksByExternalID returns all links to an external reference, as it can be linked to multiple posts
+type GetPostLinksByExternalID struct {
+	Type       string
+	ExternalID string
+
+	Result []*entity.PostLink
+}
@@ -0,0 +1,9 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+type GetPostLogs struct {
+	PostID int
+
+	Result []*entity.PostLog
+}
@@ -0,0 +1,23 @@
+package query
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type GetPostRuleByID struct {
+	RuleID int
+
+	Result *entity.PostRule
+}
+
+type GetAllPostRules struct {
+	Result []*entity.PostRule
+}
+
+// ListPostRuleSubjects returns given post, or the most recent posts when PostID is zero, as rules see them
+type ListPostRuleSubjects struct {
+	PostID int
+	Limit  int
+
+	Result []*entity.PostRuleSubject
+}
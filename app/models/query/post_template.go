@@ -0,0 +1,15 @@
+package query
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type GetPostTemplateByID struct {
+	TemplateID int
+
+	Result *entity.PostTemplate
+}
+
+type GetAllPostTemplates struct {
+	Result []*entity.PostTemplate
+}
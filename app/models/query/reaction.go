@@ -0,0 +1,15 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// ListReactions returns who reacted to a post, or to one of its comments when CommentID is set
+type ListReactions struct {
+	PostID    int
+	CommentID int
+	Emoji     string
+	Page      int
+	Limit     int
+
+	Result     []*entity.Reactor
+	TotalCount int
+}
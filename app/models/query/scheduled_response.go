@@ -0,0 +1,26 @@
+package query
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type GetScheduledResponses struct {
+	PostID int
+
+	Result []*entity.ScheduledResponse
+}
+
+type GetScheduledResponseByID struct {
+	ID int
+
+	Result *entity.ScheduledResponse
+}
+
+// ListDueScheduledResponses returns scheduled responses of all tenants that should be applied by now
+type ListDueScheduledResponses struct {
+	Now time.Time
+
+	Result []*entity.ScheduledResponse
+}
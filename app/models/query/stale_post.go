@@ -0,0 +1,33 @@
+package query
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type GetStalePostRules struct {
+	Result *entity.StalePostRules
+}
+
+// ListActiveStalePostRules returns the enabled rules of all active tenants
+type ListActiveStalePostRules struct {
+	Result []*entity.StalePostRules
+}
+
+// GetStalePosts returns the numbers of open posts that need a warning and of those that can be closed
+type GetStalePosts struct {
+	Rules *entity.StalePostRules
+	Now   time.Time
+
+	ToWarn  []int
+	ToClose []int
+}
+
+// GetPostsToLock returns the numbers of closed posts that were responded to long enough ago to be locked
+type GetPostsToLock struct {
+	Rules *entity.StalePostRules
+	Now   time.Time
+
+	Result []int
+}
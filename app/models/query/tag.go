@@ -19,3 +19,34 @@ type GetAssignedTags struct {
 type GetAllTags struct {
 	Result []*entity.Tag
 }
+
+type GetTagGroupBySlug struct {
+	Slug string
+
+	Result *entity.TagGroup
+}
+
+type GetAllTagGroups struct {
+	Result []*entity.TagGroup
+}
+
+// CountPostsWithManyGroupTags counts posts with more than one tag of the group,
+// as if the tag with given TagID, when set, was part of it.
+// When MergedIntoID is set, TagID counts as that tag, as it does once merged into it
+type CountPostsWithManyGroupTags struct {
+	GroupID      int
+	TagID        int
+	MergedIntoID int
+
+	Result int
+}
+
+type GetTagSlugRedirects struct {
+	Slugs []string
+
+	Result map[string]string
+}
+
+type GetTagStats struct {
+	Result []*entity.TagStats
+}
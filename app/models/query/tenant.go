@@ -36,6 +36,13 @@ type GetVerificationByEmailAndCode struct {
 	Result *entity.EmailVerification
 }
 
+type GetTenantByID struct {
+	TenantID int
+
+	// Output
+	Result *entity.Tenant
+}
+
 type GetFirstTenant struct {
 
 	// Output
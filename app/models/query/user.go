@@ -49,6 +49,9 @@ type GetAllUsers struct {
 }
 
 type GetAllUsersNames struct {
+	// OnlyStaff leaves visitors out, for mentions on internal notes
+	OnlyStaff bool
+
 	Result []*dto.UserNames
 }
 
@@ -2,6 +2,14 @@ package query
 
 import "github.com/getfider/fider/app/models/entity"
 
+// CountActiveVotes returns how many votes the user has on posts that are still open for voting
+type CountActiveVotes struct {
+	UserID        int
+	ExcludePostID int
+
+	Result int
+}
+
 type ListPostVotes struct {
 	PostID       int
 	Limit        int
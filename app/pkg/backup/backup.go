@@ -19,14 +19,34 @@ func Create(ctx context.Context) (*bytes.Buffer, error) {
 
 	for _, tableName := range []string{
 		"attachments",
+		"boards",
+		"board_staff",
 		"comments",
+		"custom_fields",
 		"email_verifications",
 		"notifications",
 		"oauth_providers",
+		"polls",
+		"poll_answers",
+		"poll_options",
 		"posts",
+		"post_coauthors",
+		"post_custom_field_values",
+		"post_duplicate_candidates",
+		"post_links",
+		"post_logs",
+		"post_reactions",
+		"post_rules",
+		"post_scheduled_responses",
 		"post_subscribers",
 		"post_tags",
+		"post_templates",
+		"post_views",
 		"post_votes",
+		"stale_post_rules",
+		"tag_groups",
+		"tag_slug_redirects",
+		"tag_subscribers",
 		"tags",
 		"tenants",
 		"user_providers",
@@ -10,8 +10,8 @@ import (
 	"github.com/getfider/fider/app/models/entity"
 )
 
-//FromPosts return a byte array of CSV file containing all posts
-func FromPosts(posts []*entity.Post) ([]byte, error) {
+//FromPosts return a byte array of CSV file containing all posts, with one extra column per custom field
+func FromPosts(posts []*entity.Post, fields []*entity.CustomField) ([]byte, error) {
 	buffer := &bytes.Buffer{}
 	writer := gocsv.NewWriter(buffer)
 
@@ -30,6 +30,10 @@ func FromPosts(posts []*entity.Post) ([]byte, error) {
 		"original_number",
 		"original_title",
 		"tags",
+		"reactions",
+	}
+	for _, field := range fields {
+		header = append(header, field.Key)
 	}
 	if err := writer.Write(header); err != nil {
 		return nil, err
@@ -45,7 +49,9 @@ func FromPosts(posts []*entity.Post) ([]byte, error) {
 		)
 
 		if post.Response != nil {
-			respondedBy = post.Response.User.Name
+			if post.Response.User != nil {
+				respondedBy = post.Response.User.Name
+			}
 			respondedAt = post.Response.RespondedAt.Format(time.RFC3339)
 			response = post.Response.Text
 			if post.Response.Original != nil {
@@ -69,6 +75,10 @@ func FromPosts(posts []*entity.Post) ([]byte, error) {
 			originalNumber,
 			originalTitle,
 			strings.Join(post.Tags, ", "),
+			reactionCounts(post.ReactionCounts),
+		}
+		for _, field := range fields {
+			record = append(record, post.CustomFields[field.Key])
 		}
 		if err := writer.Write(record); err != nil {
 			return nil, err
@@ -83,3 +93,72 @@ func FromPosts(posts []*entity.Post) ([]byte, error) {
 
 	return buffer.Bytes(), nil
 }
+
+// reactionCounts describes the reactions on a post, such as "👍 3, 🎉 1"
+func reactionCounts(reactions []entity.ReactionCounts) string {
+	counts := make([]string, len(reactions))
+	for i, reaction := range reactions {
+		counts[i] = reaction.Emoji + " " + strconv.Itoa(reaction.Count)
+	}
+	return strings.Join(counts, ", ")
+}
+
+//FromPolls return a byte array of CSV file containing the results of all polls, with one row per option
+func FromPolls(polls []*entity.Poll) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	writer := gocsv.NewWriter(buffer)
+
+	header := []string{
+		"post_number",
+		"comment_id",
+		"question",
+		"is_multiple_choice",
+		"is_voters_only",
+		"closes_at",
+		"voters_count",
+		"option",
+		"answers_count",
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, poll := range polls {
+		var (
+			commentID string
+			closesAt  string
+		)
+
+		if poll.CommentID > 0 {
+			commentID = strconv.Itoa(poll.CommentID)
+		}
+		if poll.ClosesAt != nil {
+			closesAt = poll.ClosesAt.Format(time.RFC3339)
+		}
+
+		for _, option := range poll.Options {
+			record := []string{
+				strconv.Itoa(poll.PostNumber),
+				commentID,
+				poll.Question,
+				strconv.FormatBool(poll.IsMultipleChoice),
+				strconv.FormatBool(poll.IsVotersOnly),
+				closesAt,
+				strconv.Itoa(poll.VotersCount),
+				option.Text,
+				strconv.Itoa(option.AnswersCount),
+			}
+			if err := writer.Write(record); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -17,7 +17,7 @@ func TestExportPostsToCSV_Empty(t *testing.T) {
 	posts := []*entity.Post{}
 	expected, err := os.ReadFile("./testdata/empty.csv")
 	Expect(err).IsNil()
-	actual, err := csv.FromPosts(posts)
+	actual, err := csv.FromPosts(posts, nil)
 	Expect(err).IsNil()
 	Expect(actual).Equals(expected)
 }
@@ -31,7 +31,7 @@ func TestExportPostsToCSV_OnePost(t *testing.T) {
 
 	expected, err := os.ReadFile("./testdata/one-post.csv")
 	Expect(err).IsNil()
-	actual, err := csv.FromPosts(posts)
+	actual, err := csv.FromPosts(posts, nil)
 	Expect(err).IsNil()
 	Expect(actual).Equals(expected)
 }
@@ -47,7 +47,62 @@ func TestExportPostsToCSV_MorePosts(t *testing.T) {
 
 	expected, err := os.ReadFile("./testdata/more-posts.csv")
 	Expect(err).IsNil()
-	actual, err := csv.FromPosts(posts)
+	actual, err := csv.FromPosts(posts, nil)
+	Expect(err).IsNil()
+	Expect(actual).Equals(expected)
+}
+
+func TestExportPostsToCSV_CustomFields(t *testing.T) {
+	RegisterT(t)
+
+	posts := []*entity.Post{
+		declinedPost,
+		openPost,
+	}
+	fields := []*entity.CustomField{
+		{Key: "platform", Type: enum.CustomFieldMultiSelect},
+		{Key: "severity", Type: enum.CustomFieldNumber},
+	}
+
+	expected, err := os.ReadFile("./testdata/custom-fields.csv")
+	Expect(err).IsNil()
+	actual, err := csv.FromPosts(posts, fields)
+	Expect(err).IsNil()
+	Expect(actual).Equals(expected)
+}
+
+func TestExportPollsToCSV(t *testing.T) {
+	RegisterT(t)
+
+	closesAt := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	polls := []*entity.Poll{
+		{
+			PostNumber:  10,
+			Question:    "Which platform do you use?",
+			VotersCount: 3,
+			Options: []*entity.PollOption{
+				{Text: "iOS", AnswersCount: 1},
+				{Text: "Android", AnswersCount: 2},
+			},
+		},
+		{
+			PostNumber:       15,
+			CommentID:        42,
+			Question:         "What should we build next?",
+			IsMultipleChoice: true,
+			IsVotersOnly:     true,
+			ClosesAt:         &closesAt,
+			VotersCount:      2,
+			Options: []*entity.PollOption{
+				{Text: "Dark mode", AnswersCount: 2},
+				{Text: "Export, to PDF", AnswersCount: 1},
+			},
+		},
+	}
+
+	expected, err := os.ReadFile("./testdata/polls.csv")
+	Expect(err).IsNil()
+	actual, err := csv.FromPolls(polls)
 	Expect(err).IsNil()
 	Expect(actual).Equals(expected)
 }
@@ -71,6 +126,14 @@ var declinedPost = &entity.Post{
 		},
 	},
 	Tags: []string{"easy", "ignored"},
+	ReactionCounts: []entity.ReactionCounts{
+		{Emoji: "👍", Count: 3},
+		{Emoji: "🎉", Count: 1},
+	},
+	CustomFields: map[string]string{
+		"platform": "ios,android",
+		"severity": "3",
+	},
 }
 
 var openPost = &entity.Post{
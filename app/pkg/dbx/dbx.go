@@ -302,6 +302,24 @@ func (trx *Trx) Rollback() error {
 	return nil
 }
 
+// Savepoint runs fn inside a savepoint of current transaction.
+// When fn fails, only its changes are rolled back and the transaction remains usable
+func (trx *Trx) Savepoint(name string, fn func() error) error {
+	if _, err := trx.Execute("SAVEPOINT " + name); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		if _, rbErr := trx.Execute("ROLLBACK TO SAVEPOINT " + name); rbErr != nil {
+			return rbErr
+		}
+		return err
+	}
+
+	_, err := trx.Execute("RELEASE SAVEPOINT " + name)
+	return err
+}
+
 // MustRollback current transaction
 func (trx *Trx) MustRollback() {
 	err := trx.Rollback()
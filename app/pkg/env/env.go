@@ -56,6 +56,7 @@ type config struct {
 	JWTSecret                   string `env:"JWT_SECRET,required"`
 	PostCreationWithTagsEnabled bool   `env:"POST_CREATION_WITH_TAGS_ENABLED,default=false"`
 	AllowAllowedSchemes         bool   `env:"ALLOW_ALLOWED_SCHEMES,default=true"`
+	CommentReplyMaxDepth        int    `env:"COMMENT_REPLY_MAX_DEPTH,default=1"`
 	Stripe                      struct {
 		SecretKey     string `env:"STRIPE_SECRET_KEY"`
 		WebhookSecret string `env:"STRIPE_WEBHOOK_SECRET"`
@@ -139,6 +140,11 @@ type config struct {
 		BackupPassword       string `env:"EMAIL_SMTP_BACKUP_PASSWORD"`
 		BackupEnableStartTLS bool   `env:"EMAIL_SMTP_BACKUP_ENABLE_STARTTLS,default=true"`
 		}
+		Inbound struct {
+			Domain      string `env:"EMAIL_INBOUND_DOMAIN"`       // e.g. reply.example.com, enables replying to notifications by email
+			Secret      string `env:"EMAIL_INBOUND_SECRET"`       // shared secret expected by the inbound email webhook
+			SMTPAddress string `env:"EMAIL_INBOUND_SMTP_ADDRESS"` // e.g. :2525, starts a local SMTP listener for inbound email
+		}
 	}
 	BlobStorage struct {
 		Type string `env:"BLOB_STORAGE,default=sql"` // possible values: sql, fs or s3
@@ -0,0 +1,195 @@
+package smtpd
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+// ErrRejected should be returned by handlers when a message must not be retried by the sender
+var ErrRejected = errors.New("message rejected")
+
+// Handler processes a message received by the server
+type Handler func(ctx context.Context, from string, to []string, data []byte) error
+
+// Server is a minimal SMTP server that receives messages for local processing
+// It doesn't relay messages and only accepts recipients of given domain
+type Server struct {
+	Addr    string
+	Domain  string
+	Handler Handler
+
+	// MaxSize is the maximum size of a message in bytes, defaults to 10MB
+	MaxSize int64
+
+	listener net.Listener
+	closed   atomic.Bool
+}
+
+const maxRecipients = 50
+const commandTimeout = 5 * time.Minute
+
+// ListenAndServe listens on the server address and handles incoming connections
+func (s *Server) ListenAndServe() error {
+	l, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen on '%s'", s.Addr)
+	}
+	return s.Serve(l)
+}
+
+// Serve handles incoming connections on given listener until it's closed
+func (s *Server) Serve(l net.Listener) error {
+	s.listener = l
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.closed.Load() {
+				return nil
+			}
+			return errors.Wrap(err, "failed to accept connection")
+		}
+		go s.serve(conn)
+	}
+}
+
+// Close stops listening for new connections
+func (s *Server) Close() error {
+	if s.listener == nil {
+		return nil
+	}
+	s.closed.Store(true)
+	return s.listener.Close()
+}
+
+type session struct {
+	started bool
+	from    string
+	to      []string
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	text := textproto.NewConn(conn)
+	reply := func(code int, message string) {
+		_ = text.PrintfLine("%d %s", code, message)
+	}
+
+	maxSize := s.MaxSize
+	if maxSize <= 0 {
+		maxSize = 10 << 20
+	}
+
+	reply(220, s.Domain+" ESMTP ready")
+	current := &session{}
+	for {
+		_ = conn.SetDeadline(time.Now().Add(commandTimeout))
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			reply(250, s.Domain)
+		case "EHLO":
+			_ = text.PrintfLine("250-%s", s.Domain)
+			_ = text.PrintfLine("250-SIZE %d", maxSize)
+			reply(250, "8BITMIME")
+		case "MAIL":
+			address, ok := parsePath(arg, "FROM:")
+			if !ok {
+				reply(501, "Syntax: MAIL FROM:<address>")
+				continue
+			}
+			current = &session{started: true, from: address}
+			reply(250, "OK")
+		case "RCPT":
+			address, ok := parsePath(arg, "TO:")
+			if !ok {
+				reply(501, "Syntax: RCPT TO:<address>")
+			} else if !current.started {
+				reply(503, "Need MAIL command")
+			} else if !s.accepts(address) {
+				reply(550, "Relay not permitted")
+			} else if len(current.to) >= maxRecipients {
+				reply(452, "Too many recipients")
+			} else {
+				current.to = append(current.to, address)
+				reply(250, "OK")
+			}
+		case "DATA":
+			if len(current.to) == 0 {
+				reply(503, "Need RCPT command")
+				continue
+			}
+			reply(354, "End data with <CR><LF>.<CR><LF>")
+
+			data, err := io.ReadAll(io.LimitReader(text.DotReader(), maxSize+1))
+			if err != nil {
+				return
+			}
+			if int64(len(data)) > maxSize {
+				// Drain the rest of the message before replying
+				_, _ = io.Copy(io.Discard, text.DotReader())
+				reply(552, "Message too large")
+			} else {
+				reply(s.handle(current, data))
+			}
+			current = &session{}
+		case "RSET":
+			current = &session{}
+			reply(250, "OK")
+		case "NOOP":
+			reply(250, "OK")
+		case "VRFY":
+			reply(252, "Cannot verify user")
+		case "QUIT":
+			reply(221, "Bye")
+			return
+		default:
+			reply(502, "Command not implemented")
+		}
+	}
+}
+
+func (s *Server) handle(current *session, data []byte) (int, string) {
+	err := s.Handler(context.Background(), current.from, current.to, data)
+	if err == nil {
+		return 250, "OK"
+	}
+	if errors.Cause(err) == ErrRejected {
+		return 550, "Message rejected"
+	}
+	return 451, "Temporary failure, try again later"
+}
+
+// accepts returns true if address belongs to the domain served by this server
+func (s *Server) accepts(address string) bool {
+	at := strings.LastIndex(address, "@")
+	return at > 0 && strings.EqualFold(address[at+1:], s.Domain)
+}
+
+// parsePath returns the address of a 'FROM:<address>' or 'TO:<address>' argument
+func parsePath(arg, prefix string) (string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	path := strings.TrimSpace(arg[len(prefix):])
+	if i := strings.Index(path, ">"); i >= 0 && strings.HasPrefix(path, "<") {
+		return path[1:i], true
+	}
+
+	// Some clients don't use angle brackets, parameters such as SIZE= follow a space
+	address, _, _ := strings.Cut(path, " ")
+	return address, address != ""
+}
@@ -0,0 +1,81 @@
+package smtpd_test
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/pkg/smtpd"
+)
+
+type received struct {
+	from string
+	to   []string
+	data string
+}
+
+func startServer(t *testing.T, handler smtpd.Handler) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).IsNil()
+
+	server := &smtpd.Server{Domain: "reply.test.fider.io", Handler: handler}
+	go func() { _ = server.Serve(l) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	return l.Addr().String()
+}
+
+func TestServer_ReceiveMessage(t *testing.T) {
+	RegisterT(t)
+
+	messages := make(chan received, 1)
+	addr := startServer(t, func(ctx context.Context, from string, to []string, data []byte) error {
+		messages <- received{from: from, to: to, data: string(data)}
+		return nil
+	})
+
+	body := "From: jon.snow@got.com\r\nSubject: Hello\r\n\r\nHello World\r\n.leading dot\r\n"
+	err := smtp.SendMail(addr, nil, "jon.snow@got.com", []string{"reply+1.2.3.abc@reply.test.fider.io"}, []byte(body))
+	Expect(err).IsNil()
+
+	msg := <-messages
+	Expect(msg.from).Equals("jon.snow@got.com")
+	Expect(msg.to).Equals([]string{"reply+1.2.3.abc@reply.test.fider.io"})
+	Expect(strings.Contains(msg.data, "Hello World\n.leading dot")).IsTrue()
+}
+
+func TestServer_RejectOtherDomains(t *testing.T) {
+	RegisterT(t)
+
+	addr := startServer(t, func(ctx context.Context, from string, to []string, data []byte) error {
+		return nil
+	})
+
+	err := smtp.SendMail(addr, nil, "jon.snow@got.com", []string{"arya.stark@got.com"}, []byte("Subject: Hello\r\n\r\nHello"))
+	Expect(err).IsNotNil()
+	Expect(err.Error()).ContainsSubstring("550")
+}
+
+func TestServer_HandlerErrors(t *testing.T) {
+	RegisterT(t)
+
+	var handlerErr error
+	addr := startServer(t, func(ctx context.Context, from string, to []string, data []byte) error {
+		return handlerErr
+	})
+	to := []string{"reply+1.2.3.abc@reply.test.fider.io"}
+
+	handlerErr = errors.Wrap(smtpd.ErrRejected, "unknown user")
+	err := smtp.SendMail(addr, nil, "jon.snow@got.com", to, []byte("Subject: Hello\r\n\r\nHello"))
+	Expect(err).IsNotNil()
+	Expect(err.Error()).ContainsSubstring("550")
+
+	handlerErr = errors.New("database is down")
+	err = smtp.SendMail(addr, nil, "jon.snow@got.com", to, []byte("Subject: Hello\r\n\r\nHello"))
+	Expect(err).IsNotNil()
+	Expect(err.Error()).ContainsSubstring("451")
+}
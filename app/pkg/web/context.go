@@ -582,6 +582,21 @@ func TenantBaseURL(ctx context.Context, tenant *entity.Tenant) string {
 	return address
 }
 
+// TenantURL returns the address of given tenant, used when there's no request to build links from
+func TenantURL(tenant *entity.Tenant) *url.URL {
+	if env.IsSingleHostMode() {
+		if u, err := url.Parse(env.Config.BaseURL); err == nil {
+			return u
+		}
+	}
+
+	host := tenant.Subdomain + env.MultiTenantDomain()
+	if tenant.CNAME != "" {
+		host = tenant.CNAME
+	}
+	return &url.URL{Scheme: "https", Host: host}
+}
+
 // AssetsURL return the full URL to a tenant-specific static asset
 // It should always return an absolute URL
 func AssetsURL(ctx context.Context, path string, a ...any) string {
@@ -205,20 +205,21 @@ func (r *Renderer) Render(w io.Writer, statusCode int, props Props, ctx *Context
 	public["tenant"] = tenant
 	public["props"] = props.Data
 	public["settings"] = &Map{
-		"mode":                env.Config.HostMode,
-		"locale":              locale,
-		"localeDirection":     localeDirection,
-		"environment":         env.Config.Environment,
-		"googleAnalytics":     env.Config.GoogleAnalytics,
-		"domain":              env.MultiTenantDomain(),
-		"hasLegal":            env.HasLegal(),
-		"isBillingEnabled":    env.IsBillingEnabled(),
-		"casEnabled":          cas.IsConfigured(), // Add this line
-		"baseURL":             ctx.BaseURL(),
-		"assetsURL":           AssetsURL(ctx, ""),
-		"oauth":               oauthProviders.Result,
-		"postWithTags":        env.Config.PostCreationWithTagsEnabled,
-		"allowAllowedSchemes": env.Config.AllowAllowedSchemes,
+		"mode":                 env.Config.HostMode,
+		"locale":               locale,
+		"localeDirection":      localeDirection,
+		"environment":          env.Config.Environment,
+		"googleAnalytics":      env.Config.GoogleAnalytics,
+		"domain":               env.MultiTenantDomain(),
+		"hasLegal":             env.HasLegal(),
+		"isBillingEnabled":     env.IsBillingEnabled(),
+		"casEnabled":           cas.IsConfigured(), // Add this line
+		"baseURL":              ctx.BaseURL(),
+		"assetsURL":            AssetsURL(ctx, ""),
+		"oauth":                oauthProviders.Result,
+		"postWithTags":         env.Config.PostCreationWithTagsEnabled,
+		"allowAllowedSchemes":  env.Config.AllowAllowedSchemes,
+		"commentReplyMaxDepth": env.Config.CommentReplyMaxDepth,
 	}
 
 	if ctx.IsAuthenticated() {
@@ -45,7 +45,9 @@ func (p Props) SetPost(post *entity.Post, keyPrefix, baseURL string, includeAllF
 		p[keyPrefix+"_created_at"] = post.CreatedAt
 		p[keyPrefix+"_url"] = post.Url(baseURL)
 
-		if includeAuthor {
+		if includeAuthor && post.IsAnonymous {
+			p.SetUser(entity.AnonymousAuthor(), keyPrefix+"_author")
+		} else if includeAuthor {
 			p.SetUser(post.User, keyPrefix+"_author")
 		}
 
@@ -55,8 +57,16 @@ func (p Props) SetPost(post *entity.Post, keyPrefix, baseURL string, includeAllF
 			p[keyPrefix+"_comments"] = post.CommentsCount
 			p[keyPrefix+"_status"] = post.Status.Name()
 			p[keyPrefix+"_tags"] = post.Tags
+			p[keyPrefix+"_custom_fields"] = post.CustomFields
+			p[keyPrefix+"_board"] = post.BoardSlug
 			p[keyPrefix+"_response"] = postResponse != nil
 
+			reactions := make(map[string]int, len(post.ReactionCounts))
+			for _, reaction := range post.ReactionCounts {
+				reactions[reaction.Emoji] = reaction.Count
+			}
+			p[keyPrefix+"_reactions"] = reactions
+
 			if postResponse != nil {
 				keyPrefix := keyPrefix + "_response"
 				p[keyPrefix+"_text"] = postResponse.Text
@@ -116,6 +116,9 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 			},
 			EmailTags: tags,
 		}
+		if to.ReplyTo != "" {
+			input.ReplyToAddresses = []*string{aws.String(to.ReplyTo)}
+		}
 
 		result, err := sesClient.SendEmailWithContext(ctx, input)
 		if err != nil {
@@ -49,7 +49,6 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 
 	form := url.Values{}
 	form.Add("from", c.From.String())
-	form.Add("h:Reply-To", c.From.Address)
 	form.Add("subject", email.EncodeSubject(message.Subject))
 	form.Add("html", message.Body)
 	form.Add("o:tag", fmt.Sprintf("template:%s", c.TemplateName))
@@ -80,6 +79,24 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 		return
 	}
 
+	// Each recipient may have its own address to reply to
+	if isBatch && hasReplyTo(c.To) {
+		for _, r := range c.To {
+			if vars, ok := recipientVariables[r.Address]; ok {
+				replyTo := r.ReplyTo
+				if replyTo == "" {
+					replyTo = c.From.Address
+				}
+				recipientVariables[r.Address] = vars.Merge(dto.Props{"replyTo": replyTo})
+			}
+		}
+		form.Add("h:Reply-To", "%recipient.replyTo%")
+	} else if !isBatch && c.To[0].ReplyTo != "" {
+		form.Add("h:Reply-To", c.To[0].ReplyTo)
+	} else {
+		form.Add("h:Reply-To", c.From.Address)
+	}
+
 	if isBatch {
 		json, err := json.Marshal(recipientVariables)
 		if err != nil {
@@ -121,3 +138,12 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 		"StatusCode": req.ResponseStatusCode,
 	})
 }
+
+func hasReplyTo(recipients []dto.Recipient) bool {
+	for _, r := range recipients {
+		if r.ReplyTo != "" {
+			return true
+		}
+	}
+	return false
+}
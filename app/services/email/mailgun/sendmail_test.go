@@ -319,3 +319,36 @@ func TestGetBaseURL(t *testing.T) {
 	Expect(httpclientmock.RequestsHistory[5].URL.String()).Equals("https://api.mailgun.net/v3/mydomain.com/messages")
 
 }
+
+func TestBatch_WithReplyTo(t *testing.T) {
+	RegisterT(t)
+	reset()
+	email.SetAllowlist("")
+
+	bus.Publish(ctx, &cmd.SendMail{
+		From: dto.Recipient{Name: "Fider Test"},
+		To: []dto.Recipient{
+			{
+				Name:    "Jon Sow",
+				Address: "jon.snow@got.com",
+				Props:   dto.Props{"name": "Jon"},
+				ReplyTo: "reply+1.1.1.abc@reply.random.org",
+			},
+			{
+				Name:    "Arya Stark",
+				Address: "arya.start@got.com",
+				Props:   dto.Props{"name": "Arya"},
+			},
+		},
+		TemplateName: "echo_test",
+	})
+
+	Expect(httpclientmock.RequestsHistory).HasLen(1)
+
+	bytes, err := io.ReadAll(httpclientmock.RequestsHistory[0].Body)
+	Expect(err).IsNil()
+	values, err := url.ParseQuery(string(bytes))
+	Expect(err).IsNil()
+	Expect(values.Get("h:Reply-To")).Equals("%recipient.replyTo%")
+	Expect(values.Get("recipient-variables")).Equals("{\"arya.start@got.com\":{\"name\":\"Arya\",\"replyTo\":\"noreply@random.org\"},\"jon.snow@got.com\":{\"name\":\"Jon\",\"replyTo\":\"reply+1.1.1.abc@reply.random.org\"}}")
+}
@@ -0,0 +1,244 @@
+package email
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+const replyPrefix = "reply+"
+
+// ReplyToken identifies who is replying to which post, as signed in a reply-to address
+type ReplyToken struct {
+	TenantID int
+	UserID   int
+	PostID   int
+}
+
+// InboundMessage is the relevant content of an email received by Fider
+type InboundMessage struct {
+	From       string
+	Recipients []string
+	Text       string
+}
+
+// IsReplyEnabled returns true if notifications can be replied to by email
+func IsReplyEnabled() bool {
+	return env.Config.Email.Inbound.Domain != ""
+}
+
+// ReplyAddress returns a signed address that can be used to reply to a post by email
+// It returns an empty string when inbound email is not configured
+func ReplyAddress(tenantID, userID, postID int) string {
+	if !IsReplyEnabled() {
+		return ""
+	}
+	payload := fmt.Sprintf("%d.%d.%d", tenantID, userID, postID)
+	return fmt.Sprintf("%s%s.%s@%s", replyPrefix, payload, signReply(payload), env.Config.Email.Inbound.Domain)
+}
+
+// ParseReplyAddress returns the token of a reply-to address, as long as its signature is valid
+func ParseReplyAddress(address string) (*ReplyToken, bool) {
+	if parsed, err := mail.ParseAddress(address); err == nil {
+		address = parsed.Address
+	}
+
+	at := strings.LastIndex(address, "@")
+	if at < 0 || !IsReplyEnabled() || !strings.EqualFold(address[at+1:], env.Config.Email.Inbound.Domain) {
+		return nil, false
+	}
+
+	local := strings.ToLower(address[:at])
+	if !strings.HasPrefix(local, replyPrefix) {
+		return nil, false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(local, replyPrefix), ".")
+	if len(parts) != 4 {
+		return nil, false
+	}
+
+	payload := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(signReply(payload)), []byte(parts[3])) {
+		return nil, false
+	}
+
+	ids := make([]int, 3)
+	for i := range ids {
+		id, err := strconv.Atoi(parts[i])
+		if err != nil || id <= 0 {
+			return nil, false
+		}
+		ids[i] = id
+	}
+
+	return &ReplyToken{TenantID: ids[0], UserID: ids[1], PostID: ids[2]}, true
+}
+
+func signReply(payload string) string {
+	mac := hmac.New(sha256.New, []byte(env.Config.JWTSecret))
+	mac.Write([]byte("reply:" + payload))
+	return hex.EncodeToString(mac.Sum(nil)[:10])
+}
+
+// ParseInboundMessage reads a raw MIME message and returns its sender, recipients and reply text
+func ParseInboundMessage(raw []byte) (*InboundMessage, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read inbound message")
+	}
+
+	result := &InboundMessage{}
+	if from, err := mail.ParseAddress(msg.Header.Get("From")); err == nil {
+		result.From = from.Address
+	}
+
+	for _, key := range []string{"To", "Cc"} {
+		if list, err := msg.Header.AddressList(key); err == nil {
+			for _, addr := range list {
+				result.Recipients = append(result.Recipients, addr.Address)
+			}
+		}
+	}
+
+	text, err := readTextBody(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return nil, err
+	}
+	result.Text = StripReply(text)
+	return result, nil
+}
+
+// readTextBody returns the plain text of a message body, preferring text/plain over text/html
+func readTextBody(contentType, encoding string, body io.Reader) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		htmlText := ""
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", errors.Wrap(err, "failed to read multipart message")
+			}
+
+			partType := part.Header.Get("Content-Type")
+			if partType == "" {
+				partType = "text/plain"
+			}
+			text, err := readTextBody(partType, part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return "", err
+			}
+
+			if strings.HasPrefix(partType, "text/html") {
+				if htmlText == "" {
+					htmlText = text
+				}
+			} else if text != "" {
+				return text, nil
+			}
+		}
+		return htmlText, nil
+	}
+
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read message body")
+	}
+
+	if mediaType == "text/html" {
+		return htmlToText(string(content)), nil
+	}
+	return string(content), nil
+}
+
+var htmlBreakRegex = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</blockquote>`)
+var htmlQuoteRegex = regexp.MustCompile(`(?is)<blockquote.*?</blockquote>`)
+var htmlTagRegex = regexp.MustCompile(`(?s)<[^>]*>`)
+
+func htmlToText(content string) string {
+	content = htmlQuoteRegex.ReplaceAllString(content, "")
+	content = htmlBreakRegex.ReplaceAllString(content, "\n")
+	content = htmlTagRegex.ReplaceAllString(content, "")
+	return html.UnescapeString(content)
+}
+
+var quoteHeaderRegex = regexp.MustCompile(`^On\s.+\swrote:$`)
+var signOffRegex = regexp.MustCompile(`^Sent from my\s`)
+
+// StripReply removes quoted history and signatures from the text of an email reply
+func StripReply(text string) string {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(strings.NewReader(strings.ReplaceAll(text, "\r\n", "\n")))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	prev := ""
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		trimmed := strings.TrimSpace(line)
+
+		if line == "--" || signOffRegex.MatchString(trimmed) ||
+			strings.HasPrefix(trimmed, "-----Original Message-----") ||
+			strings.HasPrefix(trimmed, "________________________________") {
+			break
+		}
+
+		// Quote headers may be wrapped across two lines by some clients
+		if quoteHeaderRegex.MatchString(trimmed) {
+			break
+		}
+		if strings.HasPrefix(prev, "On ") && strings.HasSuffix(trimmed, "wrote:") {
+			lines = lines[:len(lines)-1]
+			break
+		}
+
+		// Outlook quotes start with the original headers
+		if strings.HasPrefix(prev, "From:") && (strings.HasPrefix(trimmed, "Sent:") || strings.HasPrefix(trimmed, "Date:")) {
+			lines = lines[:len(lines)-1]
+			break
+		}
+
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+
+		lines = append(lines, line)
+		prev = trimmed
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,137 @@
+package email_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getfider/fider/app/pkg/env"
+	"github.com/getfider/fider/app/services/email"
+
+	. "github.com/getfider/fider/app/pkg/assert"
+)
+
+func TestReplyAddress_Disabled(t *testing.T) {
+	RegisterT(t)
+	env.Config.Email.Inbound.Domain = ""
+
+	Expect(email.IsReplyEnabled()).IsFalse()
+	Expect(email.ReplyAddress(1, 2, 3)).Equals("")
+}
+
+func TestReplyAddress_RoundTrip(t *testing.T) {
+	RegisterT(t)
+	env.Config.Email.Inbound.Domain = "reply.test.fider.io"
+
+	address := email.ReplyAddress(1, 2, 3)
+	Expect(strings.HasPrefix(address, "reply+1.2.3.")).IsTrue()
+	Expect(strings.HasSuffix(address, "@reply.test.fider.io")).IsTrue()
+
+	token, ok := email.ParseReplyAddress(address)
+	Expect(ok).IsTrue()
+	Expect(token.TenantID).Equals(1)
+	Expect(token.UserID).Equals(2)
+	Expect(token.PostID).Equals(3)
+
+	// Some mail servers change the case of addresses
+	token, ok = email.ParseReplyAddress("Jon Snow <" + strings.ToUpper(address) + ">")
+	Expect(ok).IsTrue()
+	Expect(token.PostID).Equals(3)
+}
+
+func TestParseReplyAddress_Invalid(t *testing.T) {
+	RegisterT(t)
+	env.Config.Email.Inbound.Domain = "reply.test.fider.io"
+
+	address := email.ReplyAddress(1, 2, 3)
+	tampered := strings.Replace(address, "reply+1.2.3.", "reply+1.9.3.", 1)
+
+	for _, value := range []string{
+		tampered,
+		strings.Replace(address, "@reply.test.fider.io", "@other.fider.io", 1),
+		"reply+1.2.3@reply.test.fider.io",
+		"jon.snow@reply.test.fider.io",
+		"not an address",
+		"",
+	} {
+		token, ok := email.ParseReplyAddress(value)
+		Expect(ok).IsFalse()
+		Expect(token).IsNil()
+	}
+}
+
+func TestStripReply(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{"Sounds good to me!", "Sounds good to me!"},
+		{"Sounds good to me!\r\n\r\nOn Mon, Oct 19, 2026 at 10:00 AM Fider <noreply@fider.io> wrote:\r\n> Jon Snow left a comment", "Sounds good to me!"},
+		{"Sounds good to me!\n\nOn Mon, Oct 19, 2026 at 10:00 AM Fider\n<noreply@fider.io> wrote:\n> Jon Snow left a comment", "Sounds good to me!"},
+		{"Sounds good to me!\n\n-- \nJon Snow\nLord Commander", "Sounds good to me!"},
+		{"Sounds good to me!\n\nSent from my iPhone", "Sounds good to me!"},
+		{"Sounds good to me!\n\nFrom: Fider <noreply@fider.io>\nSent: Monday, October 19, 2026\nSubject: New comment", "Sounds good to me!"},
+		{"First line\n> quoted\nSecond line", "First line\nSecond line"},
+		{"> only quoted", ""},
+	}
+
+	for _, testCase := range testCases {
+		Expect(email.StripReply(testCase.text)).Equals(testCase.expected)
+	}
+}
+
+func TestParseInboundMessage_Multipart(t *testing.T) {
+	RegisterT(t)
+
+	raw := strings.Join([]string{
+		"From: Jon Snow <jon.snow@got.com>",
+		"To: reply+1.2.3.abc@reply.test.fider.io",
+		"Cc: Arya Stark <arya.stark@got.com>",
+		"Subject: Re: [Demonstration] Add support for TypeScript",
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/alternative; boundary="xyz"`,
+		"",
+		"--xyz",
+		`Content-Type: text/plain; charset="UTF-8"`,
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"I agree, this would be gr=",
+		"eat!",
+		"",
+		"On Mon, Oct 19, 2026 at 10:00 AM Fider <noreply@fider.io> wrote:",
+		"> Arya Stark left a comment",
+		"--xyz",
+		`Content-Type: text/html; charset="UTF-8"`,
+		"",
+		"<div>I agree, this would be great!</div>",
+		"--xyz--",
+		"",
+	}, "\r\n")
+
+	msg, err := email.ParseInboundMessage([]byte(raw))
+	Expect(err).IsNil()
+	Expect(msg.From).Equals("jon.snow@got.com")
+	Expect(msg.Recipients).Equals([]string{"reply+1.2.3.abc@reply.test.fider.io", "arya.stark@got.com"})
+	Expect(msg.Text).Equals("I agree, this would be great!")
+}
+
+func TestParseInboundMessage_HTMLOnly(t *testing.T) {
+	RegisterT(t)
+
+	raw := strings.Join([]string{
+		"From: jon.snow@got.com",
+		"To: reply+1.2.3.abc@reply.test.fider.io",
+		"Content-Type: text/html; charset=UTF-8",
+		"Content-Transfer-Encoding: base64",
+		"",
+		"PHA+TG9va3MgZ29vZCAmYW1wOyByZWFkeSE8L3A+PGJsb2NrcXVvdGU+T2xkIGNvbW1lbnQ8L2Js",
+		"b2NrcXVvdGU+",
+		"",
+	}, "\r\n")
+
+	msg, err := email.ParseInboundMessage([]byte(raw))
+	Expect(err).IsNil()
+	Expect(msg.From).Equals("jon.snow@got.com")
+	Expect(msg.Text).Equals("Looks good & ready!")
+}
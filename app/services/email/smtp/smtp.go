@@ -92,10 +92,15 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 			})
 		}
 
+		replyTo := c.From.Address
+		if to.ReplyTo != "" {
+			replyTo = to.ReplyTo
+		}
+
 		message := email.RenderMessage(ctx, c.TemplateName, c.From.Address, c.Props.Merge(to.Props))
 		b := builder{}
 		b.Set("From", c.From.String())
-		b.Set("Reply-To", c.From.Address)
+		b.Set("Reply-To", replyTo)
 		b.Set("To", to.String())
 		b.Set("Subject", email.EncodeSubject(message.Subject))
 		b.Set("MIME-version", "1.0")
@@ -73,6 +73,29 @@ func TestSend_Success(t *testing.T) {
 	var validID = regexp.MustCompile(`.*Message-ID: <[a-z0-9\-].*\.[0-9].*@.*>.*`)
 	Expect(validID.MatchString(string(requests[0].body))).IsTrue()
 }
+
+func TestSend_WithReplyTo(t *testing.T) {
+	RegisterT(t)
+	reset()
+
+	bus.Publish(ctx, &cmd.SendMail{
+		From: dto.Recipient{Name: "Fider Test"},
+		To: []dto.Recipient{
+			{
+				Name:    "Jon Sow",
+				Address: "jon.snow@got.com",
+				ReplyTo: "reply+1.1.1.abc@reply.random.org",
+			},
+		},
+		TemplateName: "echo_test",
+		Props: dto.Props{
+			"name": "Hello",
+		},
+	})
+
+	Expect(requests).HasLen(1)
+	Expect(string(requests[0].body)).ContainsSubstring("From: \"Fider Test\" <noreply@random.org>\r\nReply-To: reply+1.1.1.abc@reply.random.org\r\nTo: \"Jon Sow\" <jon.snow@got.com>\r\n")
+}
 func TestSend_SkipEmptyAddress(t *testing.T) {
 	RegisterT(t)
 	reset()
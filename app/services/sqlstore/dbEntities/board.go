@@ -0,0 +1,40 @@
+package dbEntities
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/lib/pq"
+)
+
+type Board struct {
+	ID                  int            `db:"id"`
+	Name                string         `db:"name"`
+	Slug                string         `db:"slug"`
+	WelcomeMessage      dbx.NullString `db:"welcome_message"`
+	DefaultTags         pq.StringArray `db:"default_tags"`
+	IsModerationEnabled bool           `db:"is_moderation_enabled"`
+	IsPrivate           bool           `db:"is_private"`
+	Position            int            `db:"position"`
+	StaffIDs            pq.Int64Array  `db:"staff_ids"`
+}
+
+func (b *Board) ToModel() *entity.Board {
+	board := &entity.Board{
+		ID:                  b.ID,
+		Name:                b.Name,
+		Slug:                b.Slug,
+		WelcomeMessage:      b.WelcomeMessage.String,
+		DefaultTags:         []string(b.DefaultTags),
+		IsModerationEnabled: b.IsModerationEnabled,
+		IsPrivate:           b.IsPrivate,
+		Position:            b.Position,
+		StaffIDs:            make([]int, len(b.StaffIDs)),
+	}
+	if board.DefaultTags == nil {
+		board.DefaultTags = []string{}
+	}
+	for i, id := range b.StaffIDs {
+		board.StaffIDs[i] = int(id)
+	}
+	return board
+}
@@ -21,6 +21,9 @@ type Comment struct {
 	IsApproved     bool           `db:"is_approved"`
 	PinnedAt       dbx.NullTime   `db:"pinned_at"`
 	PinnedBy       *User          `db:"pinned_by"`
+	ParentID       dbx.NullInt    `db:"parent_id"`
+	DeletedAt      dbx.NullTime   `db:"deleted_at"`
+	IsInternal     bool           `db:"is_internal"`
 }
 
 func (c *Comment) ToModel(ctx context.Context) *entity.Comment {
@@ -31,6 +34,7 @@ func (c *Comment) ToModel(ctx context.Context) *entity.Comment {
 		User:        c.User.ToModel(ctx),
 		Attachments: c.Attachments,
 		IsApproved:  c.IsApproved,
+		IsInternal:  c.IsInternal,
 	}
 	if c.EditedAt.Valid {
 		comment.EditedBy = c.EditedBy.ToModel(ctx)
@@ -46,5 +50,18 @@ func (c *Comment) ToModel(ctx context.Context) *entity.Comment {
 			comment.PinnedBy = c.PinnedBy.ToModel(ctx)
 		}
 	}
+	if c.ParentID.Valid {
+		comment.ParentID = int(c.ParentID.Int64)
+	}
+	if c.DeletedAt.Valid {
+		// Tombstones only keep their place in the thread
+		comment.IsDeleted = true
+		comment.Content = ""
+		comment.Attachments = nil
+		comment.ReactionCounts = nil
+		comment.EditedAt = nil
+		comment.EditedBy = nil
+		comment.User = nil
+	}
 	return comment
 }
@@ -0,0 +1,35 @@
+package dbEntities
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/lib/pq"
+)
+
+type CustomField struct {
+	ID         int            `db:"id"`
+	Key        string         `db:"key"`
+	Name       string         `db:"name"`
+	Type       int            `db:"type"`
+	Options    pq.StringArray `db:"options"`
+	IsRequired bool           `db:"is_required"`
+	IsPublic   bool           `db:"is_public"`
+	Position   int            `db:"position"`
+}
+
+func (f *CustomField) ToModel() *entity.CustomField {
+	options := []string(f.Options)
+	if options == nil {
+		options = []string{}
+	}
+	return &entity.CustomField{
+		ID:         f.ID,
+		Key:        f.Key,
+		Name:       f.Name,
+		Type:       enum.CustomFieldType(f.Type),
+		Options:    options,
+		IsRequired: f.IsRequired,
+		IsPublic:   f.IsPublic,
+		Position:   f.Position,
+	}
+}
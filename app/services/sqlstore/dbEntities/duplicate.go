@@ -0,0 +1,47 @@
+package dbEntities
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type SimilarPost struct {
+	ID         int     `db:"id"`
+	Confidence float64 `db:"confidence"`
+}
+
+type PostDuplicateCandidate struct {
+	ID             int       `db:"id"`
+	PostNumber     int       `db:"post_number"`
+	PostTitle      string    `db:"post_title"`
+	PostSlug       string    `db:"post_slug"`
+	PostStatus     int       `db:"post_status"`
+	OriginalNumber int       `db:"original_number"`
+	OriginalTitle  string    `db:"original_title"`
+	OriginalSlug   string    `db:"original_slug"`
+	OriginalStatus int       `db:"original_status"`
+	Score          float64   `db:"score"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+func (c *PostDuplicateCandidate) ToModel() *entity.PostDuplicateCandidate {
+	return &entity.PostDuplicateCandidate{
+		ID: c.ID,
+		Post: &entity.OriginalPost{
+			Number: c.PostNumber,
+			Title:  c.PostTitle,
+			Slug:   c.PostSlug,
+			Status: enum.PostStatus(c.PostStatus),
+		},
+		Original: &entity.OriginalPost{
+			Number: c.OriginalNumber,
+			Title:  c.OriginalTitle,
+			Slug:   c.OriginalSlug,
+			Status: enum.PostStatus(c.OriginalStatus),
+		},
+		Score:     c.Score,
+		CreatedAt: c.CreatedAt,
+	}
+}
@@ -0,0 +1,64 @@
+package dbEntities
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/dbx"
+)
+
+type Poll struct {
+	ID               int          `db:"id"`
+	PostID           int          `db:"post_id"`
+	PostNumber       int          `db:"post_number"`
+	CommentID        dbx.NullInt  `db:"comment_id"`
+	Question         string       `db:"question"`
+	IsMultipleChoice bool         `db:"is_multiple_choice"`
+	IsVotersOnly     bool         `db:"is_voters_only"`
+	ClosesAt         dbx.NullTime `db:"closes_at"`
+	VotersCount      int          `db:"voters_count"`
+	CreatedBy        *User        `db:"created_by"`
+	CreatedAt        time.Time    `db:"created_at"`
+}
+
+func (p *Poll) ToModel(ctx context.Context) *entity.Poll {
+	poll := &entity.Poll{
+		ID:               p.ID,
+		PostID:           p.PostID,
+		PostNumber:       p.PostNumber,
+		CommentID:        int(p.CommentID.Int64),
+		Question:         p.Question,
+		IsMultipleChoice: p.IsMultipleChoice,
+		IsVotersOnly:     p.IsVotersOnly,
+		VotersCount:      p.VotersCount,
+		Options:          []*entity.PollOption{},
+		MyAnswers:        []int{},
+		CreatedBy:        p.CreatedBy.ToModel(ctx),
+		CreatedAt:        p.CreatedAt,
+	}
+	if p.ClosesAt.Valid {
+		poll.ClosesAt = &p.ClosesAt.Time
+	}
+	return poll
+}
+
+type PollOption struct {
+	ID           int    `db:"id"`
+	PollID       int    `db:"poll_id"`
+	Text         string `db:"text"`
+	AnswersCount int    `db:"answers_count"`
+}
+
+func (o *PollOption) ToModel() *entity.PollOption {
+	return &entity.PollOption{
+		ID:           o.ID,
+		Text:         o.Text,
+		AnswersCount: o.AnswersCount,
+	}
+}
+
+type PollAnswer struct {
+	PollID   int `db:"poll_id"`
+	OptionID int `db:"option_id"`
+}
@@ -2,8 +2,11 @@ package dbEntities
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
 	"time"
 
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/pkg/dbx"
@@ -33,9 +36,24 @@ type Post struct {
 	OriginalSlug   dbx.NullString `db:"original_slug"`
 	OriginalStatus dbx.NullInt    `db:"original_status"`
 	Tags           pq.StringArray `db:"tags"`
+	CustomFields   dbx.NullString `db:"custom_fields"`
+	BoardID        dbx.NullInt    `db:"board_id"`
+	BoardSlug      dbx.NullString `db:"board_slug"`
 	IsApproved     bool           `db:"is_approved"`
 	PinnedAt       dbx.NullTime   `db:"pinned_at"`
 	PinnedBy       *User          `db:"pinned_by"`
+	IsAnonymous    bool           `db:"is_anonymous"`
+	LockedAt       dbx.NullTime   `db:"locked_at"`
+	LockedBy       *User          `db:"locked_by"`
+	LockReason     string         `db:"lock_reason"`
+	CoAuthorIDs    pq.Int64Array  `db:"co_author_ids"`
+	ViewsCount     int            `db:"views_count"`
+	Links          dbx.NullString `db:"links"`
+	AnswerID       dbx.NullInt    `db:"answer_comment_id"`
+	AnswerContent  dbx.NullString `db:"answer_content"`
+	AnswerAt       dbx.NullTime   `db:"answer_accepted_at"`
+	AnswerUser     *User          `db:"answer_user"`
+	ReactionCounts dbx.NullString `db:"reaction_counts"`
 }
 
 func (i *Post) ToModel(ctx context.Context) *entity.Post {
@@ -53,6 +71,43 @@ func (i *Post) ToModel(ctx context.Context) *entity.Post {
 		Status:        enum.PostStatus(i.Status),
 		Tags:          i.Tags,
 		IsApproved:    i.IsApproved,
+		BoardID:       int(i.BoardID.Int64),
+		BoardSlug:     i.BoardSlug.String,
+		IsAnonymous:   i.IsAnonymous,
+		CoAuthorIDs:   make([]int, len(i.CoAuthorIDs)),
+		ViewsCount:    i.ViewsCount,
+	}
+
+	for j, id := range i.CoAuthorIDs {
+		post.CoAuthorIDs[j] = int(id)
+	}
+
+	if i.IsAnonymous {
+		viewer, _ := ctx.Value(app.UserCtxKey).(*entity.User)
+		if viewer == nil || post.User == nil || viewer.ID != post.User.ID {
+			post.User = entity.AnonymousAuthor()
+			post.User.AvatarType = enum.AvatarTypeLetter
+			post.User.AvatarURL = buildAvatarURL(ctx, enum.AvatarTypeLetter, 0, post.User.Name, "")
+
+			// Co-authors could give away who the author is, so those who can't see it only see themselves
+			if viewer == nil || !viewer.IsAdministrator() {
+				post.CoAuthorIDs = slices.DeleteFunc(post.CoAuthorIDs, func(id int) bool {
+					return viewer == nil || id != viewer.ID
+				})
+			}
+		}
+	}
+
+	if i.Links.Valid {
+		_ = json.Unmarshal([]byte(i.Links.String), &post.Links)
+	}
+
+	if i.ReactionCounts.Valid {
+		_ = json.Unmarshal([]byte(i.ReactionCounts.String), &post.ReactionCounts)
+	}
+
+	if i.CustomFields.Valid {
+		_ = json.Unmarshal([]byte(i.CustomFields.String), &post.CustomFields)
 	}
 
 	if i.PinnedAt.Valid {
@@ -62,11 +117,31 @@ func (i *Post) ToModel(ctx context.Context) *entity.Post {
 		}
 	}
 
+	if i.LockedAt.Valid {
+		post.LockedAt = &i.LockedAt.Time
+		post.LockReason = i.LockReason
+		if i.LockedBy != nil && i.LockedBy.ID.Valid {
+			post.LockedBy = i.LockedBy.ToModel(ctx)
+		}
+	}
+
+	if i.AnswerID.Valid {
+		post.AcceptedAnswer = &entity.PostAnswer{
+			CommentID:  int(i.AnswerID.Int64),
+			Content:    i.AnswerContent.String,
+			AcceptedAt: i.AnswerAt.Time,
+			User:       i.AnswerUser.ToModel(ctx),
+		}
+	}
+
 	if i.Response.Valid {
 		post.Response = &entity.PostResponse{
 			Text:        i.Response.String,
 			RespondedAt: i.RespondedAt.Time,
-			User:        i.ResponseUser.ToModel(ctx),
+		}
+		// Responses given by the system have no user
+		if i.ResponseUser != nil && i.ResponseUser.ID.Valid {
+			post.Response.User = i.ResponseUser.ToModel(ctx)
 		}
 
 		if i.OriginalNumber.Valid {
@@ -0,0 +1,29 @@
+package dbEntities
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type PostActivity struct {
+	Date     time.Time `db:"date"`
+	Views    int       `db:"views"`
+	Votes    int       `db:"votes"`
+	Comments int       `db:"comments"`
+}
+
+func (a *PostActivity) ToModel() *entity.PostActivity {
+	return &entity.PostActivity{
+		Date:     a.Date,
+		Views:    a.Views,
+		Votes:    a.Votes,
+		Comments: a.Comments,
+	}
+}
+
+type PostTotals struct {
+	Views    int `db:"views"`
+	Voters   int `db:"voters"`
+	Comments int `db:"comments"`
+}
@@ -0,0 +1,33 @@
+package dbEntities
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type PostLink struct {
+	ID          int       `db:"id"`
+	PostID      int       `db:"post_id"`
+	Type        string    `db:"type"`
+	ExternalID  string    `db:"external_id"`
+	URL         string    `db:"url"`
+	State       string    `db:"state"`
+	CreatedByID int       `db:"created_by_id"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (l *PostLink) ToModel() *entity.PostLink {
+	return &entity.PostLink{
+		ID:          l.ID,
+		PostID:      l.PostID,
+		Type:        l.Type,
+		ExternalID:  l.ExternalID,
+		URL:         l.URL,
+		State:       l.State,
+		CreatedByID: l.CreatedByID,
+		CreatedAt:   l.CreatedAt,
+		UpdatedAt:   l.UpdatedAt,
+	}
+}
@@ -0,0 +1,28 @@
+package dbEntities
+
+import (
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/dbx"
+)
+
+type PostLog struct {
+	ID        int            `db:"id"`
+	Action    string         `db:"action"`
+	Details   string         `db:"details"`
+	UserID    dbx.NullInt    `db:"user_id"`
+	UserName  dbx.NullString `db:"user_name"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (l *PostLog) ToModel() *entity.PostLog {
+	return &entity.PostLog{
+		ID:        l.ID,
+		Action:    l.Action,
+		Details:   l.Details,
+		UserID:    int(l.UserID.Int64),
+		UserName:  l.UserName.String,
+		CreatedAt: l.CreatedAt,
+	}
+}
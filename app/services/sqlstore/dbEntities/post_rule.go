@@ -0,0 +1,90 @@
+package dbEntities
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/lib/pq"
+)
+
+type PostRule struct {
+	ID                int            `db:"id"`
+	Name              string         `db:"name"`
+	IsEnabled         bool           `db:"is_enabled"`
+	Keywords          pq.StringArray `db:"keywords"`
+	Pattern           string         `db:"pattern"`
+	MatchIn           string         `db:"match_in"`
+	EmailDomains      pq.StringArray `db:"email_domains"`
+	Roles             pq.Int64Array  `db:"roles"`
+	BoardSlugs        pq.StringArray `db:"board_slugs"`
+	Tags              pq.StringArray `db:"tags"`
+	Status            dbx.NullInt    `db:"status"`
+	SubscriberIDs     pq.Int64Array  `db:"subscriber_ids"`
+	FlagForModeration bool           `db:"flag_for_moderation"`
+	Position          int            `db:"position"`
+}
+
+func (r *PostRule) ToModel() *entity.PostRule {
+	rule := &entity.PostRule{
+		ID:                r.ID,
+		Name:              r.Name,
+		IsEnabled:         r.IsEnabled,
+		Keywords:          []string(r.Keywords),
+		Pattern:           r.Pattern,
+		MatchIn:           r.MatchIn,
+		EmailDomains:      []string(r.EmailDomains),
+		Roles:             make([]enum.Role, len(r.Roles)),
+		BoardSlugs:        []string(r.BoardSlugs),
+		Tags:              []string(r.Tags),
+		SubscriberIDs:     make([]int, len(r.SubscriberIDs)),
+		FlagForModeration: r.FlagForModeration,
+		Position:          r.Position,
+	}
+	for i, role := range r.Roles {
+		rule.Roles[i] = enum.Role(role)
+	}
+	for i, id := range r.SubscriberIDs {
+		rule.SubscriberIDs[i] = int(id)
+	}
+	if r.Status.Valid {
+		status := enum.PostStatus(r.Status.Int64)
+		rule.Status = &status
+	}
+	if rule.Keywords == nil {
+		rule.Keywords = []string{}
+	}
+	if rule.EmailDomains == nil {
+		rule.EmailDomains = []string{}
+	}
+	if rule.BoardSlugs == nil {
+		rule.BoardSlugs = []string{}
+	}
+	if rule.Tags == nil {
+		rule.Tags = []string{}
+	}
+	return rule
+}
+
+type PostRuleSubject struct {
+	PostID      int            `db:"id"`
+	Number      int            `db:"number"`
+	Slug        string         `db:"slug"`
+	Title       string         `db:"title"`
+	Description string         `db:"description"`
+	AuthorEmail dbx.NullString `db:"author_email"`
+	AuthorRole  int            `db:"author_role"`
+	BoardSlug   dbx.NullString `db:"board_slug"`
+}
+
+func (s *PostRuleSubject) ToModel() *entity.PostRuleSubject {
+	return &entity.PostRuleSubject{
+		PostID:      s.PostID,
+		Number:      s.Number,
+		Slug:        s.Slug,
+		Title:       s.Title,
+		Description: s.Description,
+		AuthorEmail: s.AuthorEmail.String,
+		AuthorRole:  enum.Role(s.AuthorRole),
+		BoardSlug:   s.BoardSlug.String,
+	}
+}
@@ -0,0 +1,35 @@
+package dbEntities
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/lib/pq"
+)
+
+type PostTemplate struct {
+	ID               int            `db:"id"`
+	Name             string         `db:"name"`
+	Content          string         `db:"content"`
+	RequiredSections pq.StringArray `db:"required_sections"`
+	TagSlug          dbx.NullString `db:"tag_slug"`
+	BoardID          dbx.NullInt    `db:"board_id"`
+	BoardSlug        dbx.NullString `db:"board_slug"`
+	Position         int            `db:"position"`
+}
+
+func (t *PostTemplate) ToModel() *entity.PostTemplate {
+	template := &entity.PostTemplate{
+		ID:               t.ID,
+		Name:             t.Name,
+		Content:          t.Content,
+		RequiredSections: []string(t.RequiredSections),
+		TagSlug:          t.TagSlug.String,
+		BoardID:          int(t.BoardID.Int64),
+		BoardSlug:        t.BoardSlug.String,
+		Position:         t.Position,
+	}
+	if template.RequiredSections == nil {
+		template.RequiredSections = []string{}
+	}
+	return template
+}
@@ -0,0 +1,22 @@
+package dbEntities
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type Reactor struct {
+	Emoji     string    `db:"emoji"`
+	CreatedAt time.Time `db:"created_at"`
+	User      *User     `db:"user"`
+}
+
+func (r *Reactor) ToModel(ctx context.Context) *entity.Reactor {
+	return &entity.Reactor{
+		Emoji:     r.Emoji,
+		CreatedAt: r.CreatedAt,
+		User:      r.User.ToModel(ctx),
+	}
+}
@@ -0,0 +1,39 @@
+package dbEntities
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/dbx"
+)
+
+type ScheduledResponse struct {
+	ID          int          `db:"id"`
+	TenantID    int          `db:"tenant_id"`
+	PostID      int          `db:"post_id"`
+	Status      int          `db:"status"`
+	Response    string       `db:"response"`
+	ScheduledAt dbx.NullTime `db:"scheduled_at"`
+	BaseURL     string       `db:"base_url"`
+	CreatedBy   *User        `db:"created_by"`
+	CreatedAt   time.Time    `db:"created_at"`
+}
+
+func (r *ScheduledResponse) ToModel(ctx context.Context) *entity.ScheduledResponse {
+	response := &entity.ScheduledResponse{
+		ID:        r.ID,
+		TenantID:  r.TenantID,
+		PostID:    r.PostID,
+		Status:    enum.PostStatus(r.Status),
+		Text:      r.Response,
+		BaseURL:   r.BaseURL,
+		CreatedBy: r.CreatedBy.ToModel(ctx),
+		CreatedAt: r.CreatedAt,
+	}
+	if r.ScheduledAt.Valid {
+		response.ScheduledAt = &r.ScheduledAt.Time
+	}
+	return response
+}
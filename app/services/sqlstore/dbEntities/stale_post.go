@@ -0,0 +1,38 @@
+package dbEntities
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/lib/pq"
+)
+
+type StalePostRules struct {
+	TenantID      int            `db:"tenant_id"`
+	InactiveDays  int            `db:"inactive_days"`
+	GraceDays     int            `db:"grace_days"`
+	CloseStatus   int            `db:"close_status"`
+	Response      string         `db:"response"`
+	ExemptTags    pq.StringArray `db:"exempt_tags"`
+	LockAfterDays int            `db:"lock_after_days"`
+}
+
+func (r *StalePostRules) ToModel() *entity.StalePostRules {
+	rules := &entity.StalePostRules{
+		TenantID:      r.TenantID,
+		InactiveDays:  r.InactiveDays,
+		GraceDays:     r.GraceDays,
+		CloseStatus:   enum.PostStatus(r.CloseStatus),
+		Response:      r.Response,
+		ExemptTags:    []string(r.ExemptTags),
+		LockAfterDays: r.LockAfterDays,
+	}
+	if rules.ExemptTags == nil {
+		rules.ExemptTags = []string{}
+	}
+	return rules
+}
+
+type StalePost struct {
+	Number   int  `db:"number"`
+	IsWarned bool `db:"is_warned"`
+}
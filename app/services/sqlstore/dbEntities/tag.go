@@ -2,14 +2,18 @@ package dbEntities
 
 import (
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/dbx"
 )
 
 type Tag struct {
-	ID       int    `db:"id"`
-	Name     string `db:"name"`
-	Slug     string `db:"slug"`
-	Color    string `db:"color"`
-	IsPublic bool   `db:"is_public"`
+	ID       int         `db:"id"`
+	Name     string      `db:"name"`
+	Slug     string      `db:"slug"`
+	Color    string      `db:"color"`
+	IsPublic bool        `db:"is_public"`
+	GroupID  dbx.NullInt `db:"group_id"`
+	ParentID dbx.NullInt `db:"parent_id"`
 }
 
 func (t *Tag) ToModel() *entity.Tag {
@@ -19,5 +23,64 @@ func (t *Tag) ToModel() *entity.Tag {
 		Slug:     t.Slug,
 		Color:    t.Color,
 		IsPublic: t.IsPublic,
+		GroupID:  int(t.GroupID.Int64),
+		ParentID: int(t.ParentID.Int64),
 	}
 }
+
+type TagGroup struct {
+	ID             int    `db:"id"`
+	Name           string `db:"name"`
+	Slug           string `db:"slug"`
+	IsSingleSelect bool   `db:"is_single_select"`
+}
+
+func (g *TagGroup) ToModel() *entity.TagGroup {
+	return &entity.TagGroup{
+		ID:             g.ID,
+		Name:           g.Name,
+		Slug:           g.Slug,
+		IsSingleSelect: g.IsSingleSelect,
+	}
+}
+
+type TagSubscription struct {
+	Tag             *Tag `db:"tag"`
+	Channels        int  `db:"channels"`
+	IncludeComments bool `db:"include_comments"`
+}
+
+func (s *TagSubscription) ToModel() *entity.TagSubscription {
+	return &entity.TagSubscription{
+		Tag:             s.Tag.ToModel(),
+		Channels:        enum.NotificationChannel(s.Channels),
+		IncludeComments: s.IncludeComments,
+	}
+}
+
+type TagStats struct {
+	Tag            *Tag         `db:"tag"`
+	PostCount      int          `db:"post_count"`
+	VotesCount     int          `db:"votes_count"`
+	OpenCount      int          `db:"open_count"`
+	CompletedCount int          `db:"completed_count"`
+	LastUsedAt     dbx.NullTime `db:"last_used_at"`
+}
+
+func (s *TagStats) ToModel() *entity.TagStats {
+	stats := &entity.TagStats{
+		Tag:            s.Tag.ToModel(),
+		PostCount:      s.PostCount,
+		VotesCount:     s.VotesCount,
+		OpenCount:      s.OpenCount,
+		CompletedCount: s.CompletedCount,
+	}
+	if s.PostCount > 0 {
+		stats.OpenRatio = float64(s.OpenCount) / float64(s.PostCount)
+		stats.CompletedRatio = float64(s.CompletedCount) / float64(s.PostCount)
+	}
+	if s.LastUsedAt.Valid {
+		stats.LastUsedAt = &s.LastUsedAt.Time
+	}
+	return stats
+}
@@ -1,6 +1,8 @@
 package dbEntities
 
 import (
+	"encoding/json"
+
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/pkg/env"
@@ -27,6 +29,12 @@ type Tenant struct {
 	IsModerationEnabled  bool   `db:"is_moderation_enabled"`
 	IsPro                bool   `db:"is_pro"`
 	HasPaddleSubscription bool  `db:"has_paddle_subscription"`
+	VoteBudget              int    `db:"vote_budget"`
+	IsVoteImportanceEnabled bool   `db:"is_vote_importance_enabled"`
+	VoteRoleWeights         string `db:"vote_role_weights"`
+	IsAnonymousPostingEnabled bool `db:"is_anonymous_posting_enabled"`
+	IssueWebhookSecret        string `db:"issue_webhook_secret"`
+	Reactions                 string `db:"reactions"`
 }
 
 func (t *Tenant) ToModel() *entity.Tenant {
@@ -63,6 +71,22 @@ func (t *Tenant) ToModel() *entity.Tenant {
 		PreventIndexing:       t.PreventIndexing,
 		IsModerationEnabled:   t.IsModerationEnabled,
 		HasCommercialFeatures: hasCommercialFeatures,
+		VoteBudget:              t.VoteBudget,
+		IsVoteImportanceEnabled: t.IsVoteImportanceEnabled,
+		VoteRoleWeights:         make(map[string]int),
+		IsAnonymousPostingEnabled: t.IsAnonymousPostingEnabled,
+		IssueWebhookSecret:        t.IssueWebhookSecret,
+	}
+
+	if t.VoteRoleWeights != "" {
+		_ = json.Unmarshal([]byte(t.VoteRoleWeights), &tenant.VoteRoleWeights)
+	}
+
+	if t.Reactions != "" {
+		_ = json.Unmarshal([]byte(t.Reactions), &tenant.Reactions)
+	}
+	if len(tenant.Reactions) == 0 {
+		tenant.Reactions = entity.DefaultReactions
 	}
 
 	return tenant
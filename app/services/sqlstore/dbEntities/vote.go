@@ -6,6 +6,7 @@ import (
 
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/dbx"
 )
 
 type Vote struct {
@@ -16,12 +17,21 @@ type Vote struct {
 		AvatarType    int64  `db:"avatar_type"`
 		AvatarBlobKey string `db:"avatar_bkey"`
 	} `db:"user"`
-	CreatedAt time.Time `db:"created_at"`
+	CreatedAt  time.Time `db:"created_at"`
+	Importance int       `db:"importance"`
+	Weight     int       `db:"weight"`
+	ProxiedBy  *struct {
+		ID   dbx.NullInt    `db:"id"`
+		Name dbx.NullString `db:"name"`
+	} `db:"proxied_by"`
+	ProxyNote dbx.NullString `db:"proxy_note"`
 }
 
 func (v *Vote) ToModel(ctx context.Context) *entity.Vote {
 	vote := &entity.Vote{
-		CreatedAt: v.CreatedAt,
+		CreatedAt:  v.CreatedAt,
+		Importance: enum.VoteImportance(v.Importance),
+		Weight:     v.Weight,
 		User: &entity.VoteUser{
 			ID:        v.User.ID,
 			Name:      v.User.Name,
@@ -29,5 +39,12 @@ func (v *Vote) ToModel(ctx context.Context) *entity.Vote {
 			AvatarURL: buildAvatarURL(ctx, enum.AvatarType(v.User.AvatarType), v.User.ID, v.User.Name, v.User.AvatarBlobKey),
 		},
 	}
+	if v.ProxiedBy != nil && v.ProxiedBy.ID.Valid {
+		vote.ProxiedBy = &entity.VoteUser{
+			ID:   int(v.ProxiedBy.ID.Int64),
+			Name: v.ProxiedBy.Name.String,
+		}
+		vote.ProxyNote = v.ProxyNote.String
+	}
 	return vote
 }
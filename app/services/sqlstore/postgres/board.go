@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/gosimple/slug"
+	"github.com/lib/pq"
+)
+
+const sqlSelectBoards = `
+	SELECT b.id, b.name, b.slug, b.welcome_message, b.default_tags, b.is_moderation_enabled, b.is_private, b.position,
+		ARRAY(SELECT bs.user_id FROM board_staff bs WHERE bs.board_id = b.id ORDER BY bs.user_id) AS staff_ids
+	FROM boards b
+	WHERE b.tenant_id = $1`
+
+func getBoardBySlug(ctx context.Context, q *query.GetBoardBySlug) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		board, err := queryBoard(trx, sqlSelectBoards+" AND b.slug = $2", tenant.ID, q.Slug)
+		if err != nil {
+			return errors.Wrap(err, "failed to get board with slug '%s'", q.Slug)
+		}
+
+		q.Result = board
+		return nil
+	})
+}
+
+func getBoardByID(ctx context.Context, q *query.GetBoardByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		board, err := queryBoard(trx, sqlSelectBoards+" AND b.id = $2", tenant.ID, q.BoardID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get board with id '%d'", q.BoardID)
+		}
+
+		q.Result = board
+		return nil
+	})
+}
+
+func getAllBoards(ctx context.Context, q *query.GetAllBoards) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		isStaff := user != nil && user.IsCollaborator()
+
+		// Private boards and board staff are only listed to staff
+		condition := " AND b.is_private = false"
+		if isStaff {
+			condition = ""
+		}
+
+		boards := []*dbEntities.Board{}
+		err := trx.Select(&boards, sqlSelectBoards+condition+" ORDER BY b.position, b.name", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get all boards")
+		}
+
+		q.Result = make([]*entity.Board, len(boards))
+		for i, board := range boards {
+			q.Result[i] = board.ToModel()
+			if !isStaff {
+				q.Result[i].StaffIDs = []int{}
+			}
+		}
+		return nil
+	})
+}
+
+func addNewBoard(ctx context.Context, c *cmd.AddNewBoard) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO boards (tenant_id, name, slug, welcome_message, default_tags, is_moderation_enabled, is_private, position, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id`,
+			tenant.ID, c.Name, slug.Make(c.Name), c.WelcomeMessage, pq.Array(c.DefaultTags), c.IsModerationEnabled, c.IsPrivate, c.Position, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to add new board")
+		}
+
+		if err := setBoardStaff(trx, tenant, id, c.StaffIDs); err != nil {
+			return err
+		}
+
+		c.Result, err = queryBoard(trx, sqlSelectBoards+" AND b.id = $2", tenant.ID, id)
+		return err
+	})
+}
+
+func updateBoard(ctx context.Context, c *cmd.UpdateBoard) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			UPDATE boards
+			SET name = $3, slug = $4, welcome_message = $5, default_tags = $6, is_moderation_enabled = $7, is_private = $8, position = $9
+			WHERE id = $1 AND tenant_id = $2`,
+			c.BoardID, tenant.ID, c.Name, slug.Make(c.Name), c.WelcomeMessage, pq.Array(c.DefaultTags), c.IsModerationEnabled, c.IsPrivate, c.Position,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to update board with id '%d'", c.BoardID)
+		}
+
+		if err := setBoardStaff(trx, tenant, c.BoardID, c.StaffIDs); err != nil {
+			return err
+		}
+
+		c.Result, err = queryBoard(trx, sqlSelectBoards+" AND b.id = $2", tenant.ID, c.BoardID)
+		return err
+	})
+}
+
+func deleteBoard(ctx context.Context, c *cmd.DeleteBoard) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`UPDATE posts SET board_id = NULL WHERE board_id = $1 AND tenant_id = $2`, c.Board.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove posts from board with id '%d'", c.Board.ID)
+		}
+
+		_, err = trx.Execute(`DELETE FROM board_staff WHERE board_id = $1 AND tenant_id = $2`, c.Board.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove staff from board with id '%d'", c.Board.ID)
+		}
+
+		_, err = trx.Execute(`DELETE FROM boards WHERE id = $1 AND tenant_id = $2`, c.Board.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete board with id '%d'", c.Board.ID)
+		}
+		return nil
+	})
+}
+
+func setPostBoard(ctx context.Context, c *cmd.SetPostBoard) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var boardID any
+		if c.Board != nil {
+			boardID = c.Board.ID
+		}
+
+		_, err := trx.Execute(`UPDATE posts SET board_id = $1 WHERE id = $2 AND tenant_id = $3`, boardID, c.Post.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to move post '%d' to another board", c.Post.ID)
+		}
+
+		c.Post.BoardID = 0
+		c.Post.BoardSlug = ""
+		if c.Board != nil {
+			c.Post.BoardID = c.Board.ID
+			c.Post.BoardSlug = c.Board.Slug
+		}
+		return nil
+	})
+}
+
+func setBoardStaff(trx *dbx.Trx, tenant *entity.Tenant, boardID int, staffIDs []int) error {
+	_, err := trx.Execute(`DELETE FROM board_staff WHERE board_id = $1 AND tenant_id = $2`, boardID, tenant.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to clear staff of board with id '%d'", boardID)
+	}
+
+	for _, userID := range staffIDs {
+		_, err := trx.Execute(`
+			INSERT INTO board_staff (tenant_id, board_id, user_id, created_at)
+			VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
+			tenant.ID, boardID, userID, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to assign user '%d' to board with id '%d'", userID, boardID)
+		}
+	}
+	return nil
+}
+
+func queryBoard(trx *dbx.Trx, query string, args ...any) (*entity.Board, error) {
+	board := dbEntities.Board{}
+	if err := trx.Get(&board, query, args...); err != nil {
+		return nil, err
+	}
+	return board.ToModel(), nil
+}
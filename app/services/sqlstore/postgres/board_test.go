@@ -0,0 +1,33 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestBoardStorage_GetAllBoards_PrivateBoardsOnlyForStaff(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addPublic := &cmd.AddNewBoard{Name: "Ideas", Position: 1, StaffIDs: []int{jonSnow.ID}}
+	addPrivate := &cmd.AddNewBoard{Name: "Support", Position: 2, IsPrivate: true, StaffIDs: []int{jonSnow.ID}}
+	err := bus.Dispatch(jonSnowCtx, addPublic, addPrivate)
+	Expect(err).IsNil()
+
+	staffBoards := &query.GetAllBoards{}
+	err = bus.Dispatch(jonSnowCtx, staffBoards)
+	Expect(err).IsNil()
+	Expect(staffBoards.Result).HasLen(2)
+	Expect(staffBoards.Result[0].StaffIDs).Equals([]int{jonSnow.ID})
+
+	visitorBoards := &query.GetAllBoards{}
+	err = bus.Dispatch(aryaStarkCtx, visitorBoards)
+	Expect(err).IsNil()
+	Expect(visitorBoards.Result).HasLen(1)
+	Expect(visitorBoards.Result[0].Slug).Equals("ideas")
+	Expect(visitorBoards.Result[0].StaffIDs).HasLen(0)
+}
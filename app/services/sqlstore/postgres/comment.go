@@ -18,12 +18,16 @@ import (
 func addNewComment(ctx context.Context, c *cmd.AddNewComment) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		isApproved := !tenant.IsModerationEnabled || !user.RequiresModeration()
+		var parentID any
+		if c.ParentID > 0 {
+			parentID = c.ParentID
+		}
 		var id int
 		if err := trx.Get(&id, `
-			INSERT INTO comments (tenant_id, post_id, content, user_id, created_at, is_approved) 
-			VALUES ($1, $2, $3, $4, $5, $6) 
+			INSERT INTO comments (tenant_id, post_id, content, user_id, created_at, is_approved, parent_id, is_internal) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
 			RETURNING id
-		`, tenant.ID, c.Post.ID, c.Content, user.ID, time.Now(), isApproved); err != nil {
+		`, tenant.ID, c.Post.ID, c.Content, user.ID, time.Now(), isApproved, parentID, c.IsInternal); err != nil {
 			return errors.Wrap(err, "failed add new comment")
 		}
 
@@ -131,6 +135,12 @@ func getCommentByID(ctx context.Context, q *query.GetCommentByID) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		q.Result = nil
 
+		// Internal notes are only visible to collaborators
+		internalFilter := ""
+		if user == nil || !user.IsCollaborator() {
+			internalFilter = " AND c.is_internal = false"
+		}
+
 		comment := dbEntities.Comment{}
 		err := trx.Get(&comment,
 			`SELECT c.id, 
@@ -138,6 +148,8 @@ func getCommentByID(ctx context.Context, q *query.GetCommentByID) error {
 							c.created_at, 
 							c.edited_at, 
 							c.is_approved,
+							c.parent_id,
+							c.is_internal,
 							u.id AS user_id, 
 							u.name AS user_name,
 							u.email AS user_email,
@@ -161,7 +173,7 @@ func getCommentByID(ctx context.Context, q *query.GetCommentByID) error {
 			AND e.tenant_id = c.tenant_id
 			WHERE c.id = $1
 			AND c.tenant_id = $2
-			AND c.deleted_at IS NULL`, q.CommentID, tenant.ID)
+			AND c.deleted_at IS NULL`+internalFilter, q.CommentID, tenant.ID)
 
 		if err != nil {
 			return err
@@ -318,7 +330,19 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 			// Anonymous users can only see approved comments
 			approvalFilter = " AND c.is_approved = true"
 		}
+
+		// Internal notes are only visible to collaborators
+		internalFilter := ""
+		if q.PublicOnly || user == nil || !user.IsCollaborator() {
+			internalFilter = " AND c.is_internal = false"
+		}
 		
+		// Deleted comments that have replies are kept as tombstones so that threads stay readable
+		deletedFilter := " AND c.deleted_at IS NULL"
+		if q.IncludeTombstones {
+			deletedFilter = " AND (c.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = c.id AND r.tenant_id = c.tenant_id))"
+		}
+
 		query := fmt.Sprintf(`
 			WITH agg_attachments AS ( 
 					SELECT 
@@ -360,6 +384,9 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 					c.edited_at, 
 					c.is_approved,
 					c.pinned_at,
+					c.parent_id,
+					c.deleted_at,
+					c.is_internal,
 					u.id AS user_id, 
 					u.name AS user_name,
 					u.email AS user_email,
@@ -401,9 +428,8 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 			LEFT JOIN agg_reactions ar
 			ON ar.comment_id = c.id
 			WHERE p.id = $1
-			AND p.tenant_id = $2
-			AND c.deleted_at IS NULL%s
-			ORDER BY c.pinned_at DESC NULLS LAST, c.created_at DESC`, approvalFilter)
+			AND p.tenant_id = $2%s%s%s
+			ORDER BY c.pinned_at DESC NULLS LAST, c.created_at DESC`, deletedFilter, approvalFilter, internalFilter)
 		
 		err := trx.Select(&comments, query, q.Post.ID, tenant.ID, userId)
 		if err != nil {
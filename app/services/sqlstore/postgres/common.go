@@ -61,6 +61,8 @@ func getViewData(query query.SearchPosts) (string, []enum.PostStatus, string) {
 		sort = "votes_count"
 	case "most-discussed":
 		sort = "comments_count"
+	case "most-viewed":
+		sort = "views_count"
 	case "my-votes":
 		// Deprecated: You can instead filter on my votes only for more flexibility than using this view.
 		condition = "AND has_voted = true"
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/lib/pq"
+)
+
+func getCustomFieldByKey(ctx context.Context, q *query.GetCustomFieldByKey) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		field := dbEntities.CustomField{}
+		err := trx.Get(&field, `
+			SELECT id, key, name, type, options, is_required, is_public, position
+			FROM custom_fields
+			WHERE tenant_id = $1 AND key = $2`, tenant.ID, q.Key)
+		if err != nil {
+			return errors.Wrap(err, "failed to get custom field with key '%s'", q.Key)
+		}
+
+		q.Result = field.ToModel()
+		return nil
+	})
+}
+
+func getAllCustomFields(ctx context.Context, q *query.GetAllCustomFields) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		condition := `AND is_public = true`
+		if user != nil && user.IsCollaborator() {
+			condition = ``
+		}
+
+		fields := []*dbEntities.CustomField{}
+		err := trx.Select(&fields, fmt.Sprintf(`
+			SELECT id, key, name, type, options, is_required, is_public, position
+			FROM custom_fields
+			WHERE tenant_id = $1 %s
+			ORDER BY position, id`, condition), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get all custom fields")
+		}
+
+		q.Result = make([]*entity.CustomField, len(fields))
+		for i, field := range fields {
+			q.Result[i] = field.ToModel()
+		}
+		return nil
+	})
+}
+
+func addCustomField(ctx context.Context, c *cmd.AddCustomField) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO custom_fields (tenant_id, key, name, type, options, is_required, is_public, position, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id`,
+			tenant.ID, c.Key, c.Name, c.Type, pq.Array(c.Options), c.IsRequired, c.IsPublic, c.Position, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to add new custom field")
+		}
+
+		c.Result = &entity.CustomField{
+			ID:         id,
+			Key:        c.Key,
+			Name:       c.Name,
+			Type:       c.Type,
+			Options:    c.Options,
+			IsRequired: c.IsRequired,
+			IsPublic:   c.IsPublic,
+			Position:   c.Position,
+		}
+		return nil
+	})
+}
+
+func updateCustomField(ctx context.Context, c *cmd.UpdateCustomField) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		field := dbEntities.CustomField{}
+		err := trx.Get(&field, `
+			UPDATE custom_fields SET name = $3, options = $4, is_required = $5, is_public = $6, position = $7
+			WHERE id = $1 AND tenant_id = $2
+			RETURNING id, key, name, type, options, is_required, is_public, position`,
+			c.FieldID, tenant.ID, c.Name, pq.Array(c.Options), c.IsRequired, c.IsPublic, c.Position,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to update custom field with id '%d'", c.FieldID)
+		}
+
+		c.Result = field.ToModel()
+		return nil
+	})
+}
+
+func deleteCustomField(ctx context.Context, c *cmd.DeleteCustomField) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`DELETE FROM post_custom_field_values WHERE field_id = $1 AND tenant_id = $2`, c.Field.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove custom field with id '%d' from all posts", c.Field.ID)
+		}
+
+		_, err = trx.Execute(`DELETE FROM custom_fields WHERE id = $1 AND tenant_id = $2`, c.Field.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete custom field with id '%d'", c.Field.ID)
+		}
+		return nil
+	})
+}
+
+func setPostCustomFields(ctx context.Context, c *cmd.SetPostCustomFields) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		now := time.Now()
+		for key, value := range c.Values {
+			var err error
+			if value == "" {
+				_, err = trx.Execute(`
+					DELETE FROM post_custom_field_values
+					WHERE post_id = $1 AND tenant_id = $2
+					AND field_id = (SELECT id FROM custom_fields WHERE tenant_id = $2 AND key = $3)`,
+					c.Post.ID, tenant.ID, key,
+				)
+			} else {
+				_, err = trx.Execute(`
+					INSERT INTO post_custom_field_values (tenant_id, post_id, field_id, value, updated_at)
+					SELECT $1, $2, f.id, $4, $5 FROM custom_fields f WHERE f.tenant_id = $1 AND f.key = $3
+					ON CONFLICT (post_id, field_id) DO UPDATE SET value = $4, updated_at = $5`,
+					tenant.ID, c.Post.ID, key, value, now,
+				)
+			}
+			if err != nil {
+				return errors.Wrap(err, "failed to set custom field '%s' of post", key)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/lib/pq"
+)
+
+const sqlSelectPostDuplicateCandidates = `
+	SELECT c.id, c.score, c.created_at,
+		p.number AS post_number, p.title AS post_title, p.slug AS post_slug, p.status AS post_status,
+		o.number AS original_number, o.title AS original_title, o.slug AS original_slug, o.status AS original_status
+	FROM post_duplicate_candidates c
+	INNER JOIN posts p
+	ON p.id = c.post_id
+	AND p.tenant_id = c.tenant_id
+	INNER JOIN posts o
+	ON o.id = c.original_id
+	AND o.tenant_id = c.tenant_id
+	WHERE c.tenant_id = $1
+	AND c.dismissed_at IS NULL`
+
+// duplicateCandidateStatuses are the statuses of posts that can still be merged into another one
+var duplicateCandidateStatuses = []enum.PostStatus{
+	enum.PostOpen,
+	enum.PostStarted,
+	enum.PostPlanned,
+}
+
+func getPostDuplicateCandidates(ctx context.Context, q *query.GetPostDuplicateCandidates) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		candidates := []*dbEntities.PostDuplicateCandidate{}
+		err := trx.Select(&candidates, sqlSelectPostDuplicateCandidates+`
+			AND p.status = ANY($2)
+			AND o.status <> $3
+			ORDER BY c.score DESC, c.id DESC
+			LIMIT 100`, tenant.ID, pq.Array(duplicateCandidateStatuses), enum.PostDeleted)
+		if err != nil {
+			return errors.Wrap(err, "failed to get post duplicate candidates")
+		}
+
+		q.Result = make([]*entity.PostDuplicateCandidate, len(candidates))
+		for i, candidate := range candidates {
+			q.Result[i] = candidate.ToModel()
+		}
+		return nil
+	})
+}
+
+func getPostDuplicateCandidateByID(ctx context.Context, q *query.GetPostDuplicateCandidateByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		candidate := dbEntities.PostDuplicateCandidate{}
+		err := trx.Get(&candidate, sqlSelectPostDuplicateCandidates+" AND c.id = $2", tenant.ID, q.CandidateID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get post duplicate candidate with id '%d'", q.CandidateID)
+		}
+
+		q.Result = candidate.ToModel()
+		return nil
+	})
+}
+
+func findPostDuplicateCandidates(ctx context.Context, c *cmd.FindPostDuplicateCandidates) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		// Newer posts are compared with every older post of the same tenant, the older one is kept as the original
+		count, err := trx.Execute(`
+			INSERT INTO post_duplicate_candidates (tenant_id, post_id, original_id, score, created_at)
+			SELECT p.tenant_id, p.id, o.id, similarity(lower(p.title), lower(o.title)), $1
+			FROM posts p
+			INNER JOIN tenants t
+			ON t.id = p.tenant_id
+			AND t.status = $2
+			INNER JOIN posts o
+			ON o.tenant_id = p.tenant_id
+			AND o.id < p.id
+			AND o.status <> $3
+			AND lower(o.title) % lower(p.title)
+			WHERE p.created_at >= $4
+			AND p.status = ANY($5)
+			AND similarity(lower(p.title), lower(o.title)) >= $6
+			ON CONFLICT (post_id, original_id) DO NOTHING`,
+			time.Now(), enum.TenantActive, enum.PostDeleted, c.Since, pq.Array(duplicateCandidateStatuses), c.MinScore,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to find post duplicate candidates")
+		}
+
+		c.NumOfCandidates = count
+		return nil
+	})
+}
+
+func dismissPostDuplicateCandidate(ctx context.Context, c *cmd.DismissPostDuplicateCandidate) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			UPDATE post_duplicate_candidates SET dismissed_at = $3
+			WHERE id = $1 AND tenant_id = $2`,
+			c.CandidateID, tenant.ID, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to dismiss post duplicate candidate with id '%d'", c.CandidateID)
+		}
+		return nil
+	})
+}
@@ -104,13 +104,14 @@ func getNotificationByID(ctx context.Context, q *query.GetNotificationByID) erro
 func getActiveNotifications(ctx context.Context, q *query.GetActiveNotifications) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		err := trx.Select(&q.Result, `
-			SELECT n.id, n.title, n.link, n.read, n.created_at, n.author_id, u.avatar_type, u.avatar_bkey, u.name
+			SELECT n.id, n.title, n.link, n.read, n.created_at, COALESCE(n.author_id, 0) AS author_id,
+			COALESCE(u.avatar_type, $3) AS avatar_type, COALESCE(u.avatar_bkey, '') AS avatar_bkey, COALESCE(u.name, '') AS name
 			FROM notifications n
 			LEFT JOIN users u ON u.id = n.author_id
 			WHERE n.tenant_id = $1 AND n.user_id = $2
 			AND (n.read = false OR n.updated_at > CURRENT_DATE - INTERVAL '30 days')
 			ORDER BY n.updated_at DESC 
-		`, tenant.ID, user.ID)
+		`, tenant.ID, user.ID, enum.AvatarTypeLetter)
 		if err != nil {
 			return errors.Wrap(err, "failed to get active notifications")
 		}
@@ -127,7 +128,7 @@ func getActiveNotifications(ctx context.Context, q *query.GetActiveNotifications
 func addNewNotification(ctx context.Context, c *cmd.AddNewNotification) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		c.Result = nil
-		if user.ID == c.User.ID {
+		if user != nil && user.ID == c.User.ID {
 			return nil
 		}
 
@@ -138,11 +139,18 @@ func addNewNotification(ctx context.Context, c *cmd.AddNewNotification) error {
 			CreatedAt: now,
 			Read:      false,
 		}
+		// The author of an anonymous post is never stored, so it can't be revealed by the notification.
+		// Notifications sent by the system have no author either
+		var authorID any
+		if user != nil && !c.IsAnonymous {
+			authorID = user.ID
+		}
+
 		err := trx.Get(&notification.ID, `
 			INSERT INTO notifications (tenant_id, user_id, title, link, read, post_id, author_id, created_at, updated_at) 
 			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
 			RETURNING id
-		`, tenant.ID, c.User.ID, c.Title, c.Link, false, c.PostID, user.ID, now)
+		`, tenant.ID, c.User.ID, c.Title, c.Link, false, c.PostID, authorID, now)
 		if err != nil {
 			return errors.Wrap(err, "failed to insert notification")
 		}
@@ -154,7 +162,7 @@ func addNewNotification(ctx context.Context, c *cmd.AddNewNotification) error {
 
 func addSubscriber(ctx context.Context, c *cmd.AddSubscriber) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		return internalAddSubscriber(trx, c.Post, tenant, c.User, true)
+		return internalAddSubscriber(trx, c.Post, tenant, c.User, !c.KeepUnsubscribed)
 	})
 }
 
@@ -173,6 +181,122 @@ func removeSubscriber(ctx context.Context, c *cmd.RemoveSubscriber) error {
 	})
 }
 
+func subscribeToTag(ctx context.Context, c *cmd.SubscribeToTag) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			INSERT INTO tag_subscribers (tenant_id, user_id, tag_id, channels, include_comments, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $6) ON CONFLICT (user_id, tag_id)
+			DO UPDATE SET channels = $4, include_comments = $5, updated_at = $6`,
+			tenant.ID, user.ID, c.Tag.ID, c.Channels, c.IncludeComments, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to subscribe to tag with id '%d'", c.Tag.ID)
+		}
+		return nil
+	})
+}
+
+func unsubscribeFromTag(ctx context.Context, c *cmd.UnsubscribeFromTag) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			`DELETE FROM tag_subscribers WHERE user_id = $1 AND tag_id = $2 AND tenant_id = $3`,
+			user.ID, c.Tag.ID, tenant.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to unsubscribe from tag with id '%d'", c.Tag.ID)
+		}
+		return nil
+	})
+}
+
+func getTagSubscriptions(ctx context.Context, q *query.GetTagSubscriptions) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make([]*entity.TagSubscription, 0)
+		if user == nil {
+			return nil
+		}
+
+		condition := `AND t.is_public = true`
+		if user.IsCollaborator() {
+			condition = ``
+		}
+
+		subscriptions := []*dbEntities.TagSubscription{}
+		err := trx.Select(&subscriptions, fmt.Sprintf(`
+			SELECT t.id AS tag_id, t.name AS tag_name, t.slug AS tag_slug, t.color AS tag_color, t.is_public AS tag_is_public,
+			t.group_id AS tag_group_id, t.parent_id AS tag_parent_id,
+			ts.channels, ts.include_comments
+			FROM tag_subscribers ts
+			INNER JOIN tags t ON t.id = ts.tag_id AND t.tenant_id = ts.tenant_id
+			WHERE ts.tenant_id = $1 AND ts.user_id = $2 %s
+			ORDER BY t.name`, condition),
+			tenant.ID, user.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to get tag subscriptions of user with id '%d'", user.ID)
+		}
+
+		q.Result = make([]*entity.TagSubscription, len(subscriptions))
+		for i, s := range subscriptions {
+			q.Result[i] = s.ToModel()
+		}
+		return nil
+	})
+}
+
+// boardAudienceCondition restricts the notified users when the post belongs to a board.
+// When the board has assigned staff, only those staff members are notified,
+// and visitors other than the author are never notified about posts of private boards
+func boardAudienceCondition(tenantParam, numberParam, visitorParam string) string {
+	return fmt.Sprintf(`
+				AND (
+					u.role = %[3]s
+					OR NOT EXISTS (SELECT 1 FROM board_staff bs INNER JOIN posts p ON p.board_id = bs.board_id WHERE p.tenant_id = %[1]s AND p.number = %[2]s)
+					OR EXISTS (SELECT 1 FROM board_staff bs INNER JOIN posts p ON p.board_id = bs.board_id WHERE p.tenant_id = %[1]s AND p.number = %[2]s AND bs.user_id = u.id)
+				)
+				AND (
+					u.role != %[3]s
+					OR NOT EXISTS (SELECT 1 FROM boards b INNER JOIN posts p ON p.board_id = b.id WHERE p.tenant_id = %[1]s AND p.number = %[2]s AND b.is_private = true)
+					OR EXISTS (SELECT 1 FROM posts p WHERE p.tenant_id = %[1]s AND p.number = %[2]s AND p.user_id = u.id)
+				)`, tenantParam, numberParam, visitorParam)
+}
+
+// tagSubscribersCondition matches users that follow a tag of the post, or a parent of one of them.
+// Followers of a tag are notified about new posts and status changes, and about new comments only
+// when they asked for it. Private tags and private boards are never followed by visitors, and those
+// who unsubscribed from the post itself are left out
+func tagSubscribersCondition(event enum.NotificationEvent, tenantParam, numberParam, channelParam, visitorParam string) string {
+	commentsCondition := ""
+	switch event.UserSettingsKeyName {
+	case enum.NotificationEventNewPost.UserSettingsKeyName, enum.NotificationEventChangeStatus.UserSettingsKeyName:
+	case enum.NotificationEventNewComment.UserSettingsKeyName:
+		commentsCondition = "AND ts.include_comments = true"
+	default:
+		return "FALSE"
+	}
+
+	return fmt.Sprintf(`EXISTS (
+					WITH RECURSIVE followed AS (
+						SELECT ts.tag_id AS id FROM tag_subscribers ts
+						WHERE ts.tenant_id = %[1]s AND ts.user_id = u.id
+						AND ts.channels & %[3]s > 0
+						%[5]s
+						UNION
+						SELECT c.id FROM tags c
+						INNER JOIN followed f ON c.parent_id = f.id
+						WHERE c.tenant_id = %[1]s
+					)
+					SELECT 1 FROM followed f
+					INNER JOIN tags t ON t.id = f.id AND t.tenant_id = %[1]s
+					INNER JOIN post_tags pt ON pt.tag_id = t.id AND pt.tenant_id = t.tenant_id
+					INNER JOIN posts p ON p.id = pt.post_id AND p.tenant_id = pt.tenant_id
+					LEFT JOIN boards b ON b.id = p.board_id
+					WHERE p.number = %[2]s
+					AND (u.role != %[4]s OR (t.is_public = true AND (b.is_private IS NULL OR b.is_private = false)))
+					AND NOT EXISTS (SELECT 1 FROM post_subscribers ps WHERE ps.user_id = u.id AND ps.post_id = p.id AND ps.status = %[6]d)
+				)`, tenantParam, numberParam, channelParam, visitorParam, commentsCondition, enum.SubscriberInactive)
+}
+
 func getActiveSubscribers(ctx context.Context, q *query.GetActiveSubscribers) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		q.Result = make([]*entity.User, 0)
@@ -201,15 +325,21 @@ func getActiveSubscribers(ctx context.Context, q *query.GetActiveSubscribers) er
 				AND u.status = $5
 				%s
 				AND (
-					(set.value IS NULL AND u.role = ANY($3))
-					OR CAST(set.value AS integer) & $4 > 0
+					(
+						(set.value IS NULL AND u.role = ANY($3))
+						OR CAST(set.value AS integer) & $4 > 0
+					)
+					OR %s
 				)
-				ORDER by u.id`, supressionCondition),
+				%s
+				ORDER by u.id`, supressionCondition, tagSubscribersCondition(q.Event, "$2", "$6", "$4", "$7"), boardAudienceCondition("$2", "$6", "$7")),
 				q.Event.UserSettingsKeyName,
 				tenant.ID,
 				pq.Array(q.Event.DefaultEnabledUserRoles),
 				q.Channel,
 				enum.UserActive,
+				q.Number,
+				enum.RoleVisitor,
 			)
 		} else {
 			// If the event requires a subscription, notify only those who subscribed
@@ -227,12 +357,18 @@ func getActiveSubscribers(ctx context.Context, q *query.GetActiveSubscribers) er
 				WHERE u.tenant_id = $4
 				AND u.status = $8
 				%s
-				AND ( sub.status = $2 OR (sub.status IS NULL AND NOT u.role = ANY($7)) )
 				AND (
-					(set.value IS NULL AND u.role = ANY($5))
-					OR CAST(set.value AS integer) & $6 > 0
+					(
+						( sub.status = $2 OR (sub.status IS NULL AND NOT u.role = ANY($7)) )
+						AND (
+							(set.value IS NULL AND u.role = ANY($5))
+							OR CAST(set.value AS integer) & $6 > 0
+						)
+					)
+					OR %s
 				)
-				ORDER by u.id`, supressionCondition),
+				%s
+				ORDER by u.id`, supressionCondition, tagSubscribersCondition(q.Event, "$4", "$1", "$6", "$9"), boardAudienceCondition("$4", "$1", "$9")),
 				q.Number,
 				enum.SubscriberActive,
 				q.Event.UserSettingsKeyName,
@@ -241,6 +377,7 @@ func getActiveSubscribers(ctx context.Context, q *query.GetActiveSubscribers) er
 				q.Channel,
 				pq.Array(q.Event.RequiresSubscriptionUserRoles),
 				enum.UserActive,
+				enum.RoleVisitor,
 			)
 		}
 
@@ -256,6 +393,60 @@ func getActiveSubscribers(ctx context.Context, q *query.GetActiveSubscribers) er
 	})
 }
 
+func getTagFollowers(ctx context.Context, q *query.GetTagFollowers) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make([]*entity.User, 0)
+
+		supressionCondition := ""
+		if q.Channel == enum.NotificationChannelEmail {
+			supressionCondition = "AND u.email_supressed_at IS NULL"
+		}
+
+		users := []*dbEntities.User{}
+		err := trx.Select(&users, fmt.Sprintf(`
+			WITH RECURSIVE ancestors AS (
+				SELECT id, parent_id FROM tags WHERE id = $2 AND tenant_id = $1
+				UNION
+				SELECT t.id, t.parent_id FROM tags t
+				INNER JOIN ancestors a ON t.id = a.parent_id
+				WHERE t.tenant_id = $1
+			)
+			SELECT DISTINCT u.id, u.name, u.email, u.tenant_id, u.role, u.status
+			FROM users u
+			INNER JOIN tag_subscribers ts
+			ON ts.user_id = u.id
+			AND ts.tenant_id = u.tenant_id
+			WHERE u.tenant_id = $1
+			AND u.status = $4
+			AND ts.tag_id IN (SELECT id FROM ancestors)
+			AND ts.channels & $3 > 0
+			%s
+			AND ($7 = true OR u.role != $6)
+			AND NOT EXISTS (SELECT 1 FROM post_subscribers ps WHERE ps.user_id = u.id AND ps.post_id = $8 AND ps.status = $9)
+			%s
+			ORDER BY u.id`, supressionCondition, boardAudienceCondition("$1", "$5", "$6")),
+			tenant.ID,
+			q.Tag.ID,
+			q.Channel,
+			enum.UserActive,
+			q.Post.Number,
+			enum.RoleVisitor,
+			q.Tag.IsPublic,
+			q.Post.ID,
+			enum.SubscriberInactive,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to get followers of tag with id '%d'", q.Tag.ID)
+		}
+
+		q.Result = make([]*entity.User, len(users))
+		for i, user := range users {
+			q.Result[i] = user.ToModel(ctx)
+		}
+		return nil
+	})
+}
+
 func internalAddSubscriber(trx *dbx.Trx, post *entity.Post, tenant *entity.Tenant, user *entity.User, force bool) error {
 	conflict := " DO NOTHING"
 	if force {
@@ -0,0 +1,207 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/lib/pq"
+)
+
+const sqlSelectPolls = `
+	SELECT pl.id, pl.post_id, p.number AS post_number, pl.comment_id, pl.question,
+		pl.is_multiple_choice, pl.is_voters_only, pl.closes_at, pl.created_at,
+		(SELECT COUNT(DISTINCT a.user_id) FROM poll_answers a WHERE a.poll_id = pl.id AND a.tenant_id = pl.tenant_id) AS voters_count,
+		u.id AS created_by_id,
+		u.name AS created_by_name,
+		u.email AS created_by_email,
+		u.role AS created_by_role,
+		u.status AS created_by_status,
+		u.avatar_type AS created_by_avatar_type,
+		u.avatar_bkey AS created_by_avatar_bkey
+	FROM polls pl
+	INNER JOIN posts p
+	ON p.id = pl.post_id
+	AND p.tenant_id = pl.tenant_id
+	INNER JOIN users u
+	ON u.id = pl.created_by_id
+	AND u.tenant_id = pl.tenant_id
+	WHERE pl.tenant_id = $1`
+
+func getPollsByPost(ctx context.Context, q *query.GetPollsByPost) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		polls, err := queryPolls(ctx, trx, tenant, user, " AND pl.post_id = $2 ORDER BY pl.id", q.PostID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get polls of post '%d'", q.PostID)
+		}
+
+		q.Result = polls
+		return nil
+	})
+}
+
+func getPollByID(ctx context.Context, q *query.GetPollByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		polls, err := queryPolls(ctx, trx, tenant, user, " AND pl.id = $2", q.PollID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get poll with id '%d'", q.PollID)
+		}
+		if len(polls) == 0 {
+			return app.ErrNotFound
+		}
+
+		q.Result = polls[0]
+		return nil
+	})
+}
+
+func getAllPolls(ctx context.Context, q *query.GetAllPolls) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		polls, err := queryPolls(ctx, trx, tenant, user, " ORDER BY p.number, pl.id")
+		if err != nil {
+			return errors.Wrap(err, "failed to get all polls")
+		}
+
+		q.Result = polls
+		return nil
+	})
+}
+
+// queryPolls loads polls with their options, results and the answers of current user
+func queryPolls(ctx context.Context, trx *dbx.Trx, tenant *entity.Tenant, user *entity.User, filter string, args ...any) ([]*entity.Poll, error) {
+	rows := []*dbEntities.Poll{}
+	err := trx.Select(&rows, sqlSelectPolls+filter, append([]any{tenant.ID}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	polls := make([]*entity.Poll, len(rows))
+	pollsByID := make(map[int]*entity.Poll, len(rows))
+	pollIDs := make([]int, len(rows))
+	for i, row := range rows {
+		polls[i] = row.ToModel(ctx)
+		pollsByID[row.ID] = polls[i]
+		pollIDs[i] = row.ID
+	}
+
+	if len(polls) == 0 {
+		return polls, nil
+	}
+
+	options := []*dbEntities.PollOption{}
+	err = trx.Select(&options, `
+		SELECT o.id, o.poll_id, o.text,
+			(SELECT COUNT(*) FROM poll_answers a WHERE a.option_id = o.id AND a.tenant_id = o.tenant_id) AS answers_count
+		FROM poll_options o
+		WHERE o.tenant_id = $1
+		AND o.poll_id = ANY($2)
+		ORDER BY o.poll_id, o.position`, tenant.ID, pq.Array(pollIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, option := range options {
+		poll := pollsByID[option.PollID]
+		poll.Options = append(poll.Options, option.ToModel())
+	}
+
+	if user != nil {
+		answers := []*dbEntities.PollAnswer{}
+		err = trx.Select(&answers, `
+			SELECT poll_id, option_id
+			FROM poll_answers
+			WHERE tenant_id = $1
+			AND poll_id = ANY($2)
+			AND user_id = $3`, tenant.ID, pq.Array(pollIDs), user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, answer := range answers {
+			poll := pollsByID[answer.PollID]
+			poll.MyAnswers = append(poll.MyAnswers, answer.OptionID)
+		}
+	}
+
+	return polls, nil
+}
+
+func addNewPoll(ctx context.Context, c *cmd.AddNewPoll) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var commentID any
+		if c.Comment != nil {
+			commentID = c.Comment.ID
+		}
+
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO polls (tenant_id, post_id, comment_id, question, is_multiple_choice, is_voters_only, closes_at, created_by_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id`,
+			tenant.ID, c.Post.ID, commentID, c.Question, c.IsMultipleChoice, c.IsVotersOnly, c.ClosesAt, user.ID, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to add poll to post '%d'", c.Post.ID)
+		}
+
+		for i, option := range c.Options {
+			_, err := trx.Execute(`
+				INSERT INTO poll_options (tenant_id, poll_id, text, position)
+				VALUES ($1, $2, $3, $4)`,
+				tenant.ID, id, option, i,
+			)
+			if err != nil {
+				return errors.Wrap(err, "failed to add option to poll '%d'", id)
+			}
+		}
+
+		polls, err := queryPolls(ctx, trx, tenant, user, " AND pl.id = $2", id)
+		if err != nil {
+			return errors.Wrap(err, "failed to get poll with id '%d'", id)
+		}
+
+		c.Result = polls[0]
+		return nil
+	})
+}
+
+func answerPoll(ctx context.Context, c *cmd.AnswerPoll) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"DELETE FROM poll_answers WHERE poll_id = $1 AND user_id = $2 AND tenant_id = $3",
+			c.Poll.ID, user.ID, tenant.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove previous answers to poll '%d'", c.Poll.ID)
+		}
+
+		now := time.Now()
+		for _, optionID := range c.OptionIDs {
+			_, err := trx.Execute(`
+				INSERT INTO poll_answers (tenant_id, poll_id, option_id, user_id, created_at)
+				VALUES ($1, $2, $3, $4, $5)`,
+				tenant.ID, c.Poll.ID, optionID, user.ID, now,
+			)
+			if err != nil {
+				return errors.Wrap(err, "failed to answer poll '%d'", c.Poll.ID)
+			}
+		}
+		return nil
+	})
+}
+
+func deletePoll(ctx context.Context, c *cmd.DeletePoll) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute("DELETE FROM polls WHERE id = $1 AND tenant_id = $2", c.Poll.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete poll '%d'", c.Poll.ID)
+		}
+		return nil
+	})
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -38,6 +39,18 @@ var (
 														%s
 														GROUP BY post_id
 													),
+													agg_fields AS (
+														SELECT
+																v.post_id,
+																json_object_agg(f.key, v.value) as custom_fields
+														FROM post_custom_field_values v
+														INNER JOIN custom_fields f
+														ON f.id = v.field_id
+														AND f.tenant_id = v.tenant_id
+														WHERE v.tenant_id = $1
+														%s
+														GROUP BY v.post_id
+													),
 													agg_comments AS (
 															SELECT
 																	post_id,
@@ -49,13 +62,14 @@ var (
 															AND posts.tenant_id = comments.tenant_id
 															WHERE posts.tenant_id = $1
 															AND comments.deleted_at IS NULL
+															AND comments.is_internal = false
 															GROUP BY post_id
 													),
 													agg_votes AS (
 															SELECT
 															post_id,
-																	COUNT(CASE WHEN post_votes.created_at > CURRENT_DATE - INTERVAL '30 days'  THEN 1 END) as recent,
-																	COUNT(*) as all
+																	SUM(CASE WHEN post_votes.created_at > CURRENT_DATE - INTERVAL '30 days' THEN post_votes.weight ELSE 0 END) as recent,
+																	SUM(post_votes.weight) as all
 															FROM post_votes
 															INNER JOIN posts
 															ON posts.id = post_votes.post_id
@@ -96,6 +110,9 @@ var (
 																d.slug AS original_slug,
 																d.status AS original_status,
 																COALESCE(agg_t.tags, ARRAY[]::text[]) AS tags,
+																agg_f.custom_fields,
+																p.board_id,
+																b.slug AS board_slug,
 																COALESCE(%s, false) AS has_voted,
 																p.is_approved,
 																p.pinned_at,
@@ -105,7 +122,41 @@ var (
 																pinner.role AS pinned_by_role,
 																pinner.status AS pinned_by_status,
 																pinner.avatar_type AS pinned_by_avatar_type,
-																pinner.avatar_bkey AS pinned_by_avatar_bkey
+																pinner.avatar_bkey AS pinned_by_avatar_bkey,
+																p.is_anonymous,
+																p.locked_at,
+																locker.id AS locked_by_id,
+																locker.name AS locked_by_name,
+																locker.email AS locked_by_email,
+																locker.role AS locked_by_role,
+																locker.status AS locked_by_status,
+																locker.avatar_type AS locked_by_avatar_type,
+																locker.avatar_bkey AS locked_by_avatar_bkey,
+																p.lock_reason,
+																p.views_count,
+																ARRAY(SELECT pc.user_id FROM post_coauthors pc WHERE pc.post_id = p.id ORDER BY pc.user_id) AS co_author_ids,
+																(SELECT json_agg(json_build_object(
+																	'id', l.id, 'type', l.type, 'externalId', l.external_id, 'url', l.url,
+																	'state', l.state, 'createdAt', l.created_at, 'updatedAt', l.updated_at
+																) ORDER BY l.id) FROM post_links l WHERE l.post_id = p.id) AS links,
+																ac.id AS answer_comment_id,
+																ac.content AS answer_content,
+																p.answer_accepted_at,
+																au.id AS answer_user_id,
+																au.name AS answer_user_name,
+																au.email AS answer_user_email,
+																au.role AS answer_user_role,
+																au.status AS answer_user_status,
+																au.avatar_type AS answer_user_avatar_type,
+																au.avatar_bkey AS answer_user_avatar_bkey,
+																(SELECT json_agg(json_build_object(
+																	'emoji', pr.emoji, 'count', pr.count, 'includesMe', pr.includes_me
+																) ORDER BY pr.count DESC, pr.emoji) FROM (
+																	SELECT emoji, COUNT(*) AS count, bool_or(user_id = %d) AS includes_me
+																	FROM post_reactions
+																	WHERE post_id = p.id AND tenant_id = $1
+																	GROUP BY emoji
+																) pr) AS reaction_counts
 													FROM posts p
 													INNER JOIN users u
 													ON u.id = p.user_id
@@ -116,15 +167,30 @@ var (
 													LEFT JOIN users pinner
 													ON pinner.id = p.pinned_by_id
 													AND pinner.tenant_id = $1
+													LEFT JOIN users locker
+													ON locker.id = p.locked_by_id
+													AND locker.tenant_id = $1
 													LEFT JOIN posts d
 													ON d.id = p.original_id
 													AND d.tenant_id = $1
+													LEFT JOIN comments ac
+													ON ac.id = p.accepted_comment_id
+													AND ac.tenant_id = $1
+													AND ac.deleted_at IS NULL
+													LEFT JOIN users au
+													ON au.id = ac.user_id
+													AND au.tenant_id = $1
 													LEFT JOIN agg_comments agg_c
 													ON agg_c.post_id = p.id
 													LEFT JOIN agg_votes agg_s
 													ON agg_s.post_id = p.id
 													LEFT JOIN agg_tags agg_t
 													ON agg_t.post_id = p.id
+													LEFT JOIN agg_fields agg_f
+													ON agg_f.post_id = p.id
+													LEFT JOIN boards b
+													ON b.id = p.board_id
+													AND b.tenant_id = $1
 													WHERE p.status != ` + strconv.Itoa(int(enum.PostDeleted)) + ` AND %s`
 )
 
@@ -155,15 +221,18 @@ func getTopPostsByVotes(ctx context.Context, q *query.GetTopPostsByVotes) error
 		if limit <= 0 {
 			limit = 10
 		}
-		err := trx.Select(&q.Result, `
-			SELECT p.number, p.title, p.slug, u.id AS user_id, u.name AS user_name,
+		err := trx.Select(&q.Result, fmt.Sprintf(`
+			SELECT p.number, p.title, p.slug,
+				CASE WHEN p.is_anonymous THEN 0 ELSE u.id END AS user_id,
+				CASE WHEN p.is_anonymous THEN $5 ELSE u.name END AS user_name,
 				COALESCE(vc.cnt, 0)::int AS votes_count
 			FROM posts p
 			INNER JOIN users u ON u.id = p.user_id AND u.tenant_id = p.tenant_id
-			LEFT JOIN (SELECT post_id, COUNT(*) AS cnt FROM post_votes GROUP BY post_id) vc ON vc.post_id = p.id
-			WHERE p.tenant_id = $1 AND p.status != $2
+			LEFT JOIN boards b ON b.id = p.board_id AND b.tenant_id = p.tenant_id
+			LEFT JOIN (SELECT post_id, SUM(weight) AS cnt FROM post_votes GROUP BY post_id) vc ON vc.post_id = p.id
+			WHERE p.tenant_id = $1 AND p.status != $2 AND ($4 = '' OR b.slug = $4) %s
 			ORDER BY votes_count DESC, p.number DESC
-			LIMIT $3`, tenant.ID, enum.PostDeleted, limit)
+			LIMIT $3`, boardPrivacyFilter(user)), tenant.ID, enum.PostDeleted, limit, q.Board, entity.AnonymousAuthor().Name)
 		if err != nil {
 			return errors.Wrap(err, "failed to get top posts by votes")
 		}
@@ -184,15 +253,17 @@ func getTopUsersByVotes(ctx context.Context, q *query.GetTopUsersByVotes) error
 			VotesCount int    `db:"votes_count"`
 		}
 		var rows []*row
-		err := trx.Select(&rows, `
+		err := trx.Select(&rows, fmt.Sprintf(`
 			SELECT p.user_id, u.name AS user_name, COALESCE(SUM(vc.cnt), 0)::int AS votes_count
 			FROM posts p
 			INNER JOIN users u ON u.id = p.user_id AND u.tenant_id = p.tenant_id
-			LEFT JOIN (SELECT post_id, COUNT(*) AS cnt FROM post_votes GROUP BY post_id) vc ON vc.post_id = p.id
-			WHERE p.tenant_id = $1 AND p.status != $2
+			LEFT JOIN boards b ON b.id = p.board_id AND b.tenant_id = p.tenant_id
+			LEFT JOIN (SELECT post_id, SUM(weight) AS cnt FROM post_votes GROUP BY post_id) vc ON vc.post_id = p.id
+			WHERE p.tenant_id = $1 AND p.status != $2 AND ($4 = '' OR b.slug = $4) %s
+			AND p.is_anonymous = false
 			GROUP BY p.user_id, u.name
 			ORDER BY votes_count DESC
-			LIMIT $3`, tenant.ID, enum.PostDeleted, limit)
+			LIMIT $3`, boardPrivacyFilter(user)), tenant.ID, enum.PostDeleted, limit, q.Board)
 		if err != nil {
 			return errors.Wrap(err, "failed to get top users by votes")
 		}
@@ -214,11 +285,17 @@ func setPostResponse(ctx context.Context, c *cmd.SetPostResponse) error {
 			respondedAt = c.Post.Response.RespondedAt
 		}
 
+		// Responses given by the system, such as those of post rules, have no user
+		var userID any
+		if user != nil {
+			userID = user.ID
+		}
+
 		_, err := trx.Execute(`
 		UPDATE posts
 		SET response = $3, original_id = NULL, response_date = $4, response_user_id = $5, status = $6
 		WHERE id = $1 and tenant_id = $2
-		`, c.Post.ID, tenant.ID, c.Text, respondedAt, user.ID, c.Status)
+		`, c.Post.ID, tenant.ID, c.Text, respondedAt, userID, c.Status)
 		if err != nil {
 			return errors.Wrap(err, "failed to update post's response")
 		}
@@ -301,15 +378,21 @@ func countPostPerStatus(ctx context.Context, q *query.CountPostPerStatus) error
 
 func addNewPost(ctx context.Context, c *cmd.AddNewPost) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		isApproved := !tenant.IsModerationEnabled || !user.RequiresModeration()
+		isModerationEnabled := tenant.IsModerationEnabled
+		var boardID any
+		if c.Board != nil {
+			boardID = c.Board.ID
+			isModerationEnabled = isModerationEnabled || c.Board.IsModerationEnabled
+		}
+		isApproved := !isModerationEnabled || !user.RequiresModeration()
 		var id int
 		// Detect language using lingua-go
 		lang := detectPostLanguage(c.Title, c.Description)
 
 		err := trx.Get(&id,
-			`INSERT INTO posts (title, slug, number, description, tenant_id, user_id, created_at, status, is_approved, language)
-			 VALUES ($1, $2, (SELECT COALESCE(MAX(number), 0) + 1 FROM posts p WHERE p.tenant_id = $4), $3, $4, $5, $6, 0, $7, $8)
-			 RETURNING id`, c.Title, slug.Make(c.Title), c.Description, tenant.ID, user.ID, time.Now(), isApproved, lang)
+			`INSERT INTO posts (title, slug, number, description, tenant_id, user_id, created_at, status, is_approved, language, board_id, is_anonymous)
+			 VALUES ($1, $2, (SELECT COALESCE(MAX(number), 0) + 1 FROM posts p WHERE p.tenant_id = $4), $3, $4, $5, $6, 0, $7, $8, $9, $10)
+			 RETURNING id`, c.Title, slug.Make(c.Title), c.Description, tenant.ID, user.ID, time.Now(), isApproved, lang, boardID, c.IsAnonymous)
 		if err != nil {
 			return errors.Wrap(err, "failed add new post")
 		}
@@ -426,55 +509,85 @@ func preprocessSearchQuery(query string) string {
 	return strings.Join(filteredWords, " ")
 }
 
+// minSimilarPostConfidence is the lowest confidence of a post to be considered similar
+const minSimilarPostConfidence = 0.2
+
 func findSimilarPosts(ctx context.Context, q *query.FindSimilarPosts) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		statuses := pq.Array([]enum.PostStatus{
+			enum.PostOpen,
+			enum.PostStarted,
+			enum.PostPlanned,
+			enum.PostCompleted,
+			enum.PostDeclined,
+		})
 		innerQuery := buildPostQuery(user, "p.tenant_id = $1 AND p.status = ANY($2)", "")
 
 		filteredQuery := preprocessSearchQuery(q.Query)
 
-		var (
-			posts []*dbEntities.Post
-			err   error
-		)
-
+		q.Result = make([]*entity.SimilarPost, 0)
 		if filteredQuery == "" {
-			q.Result = make([]*entity.Post, 0)
-		} else {
-			tsConfig := MapLocaleToTSConfig(tenant.Locale)
+			return nil
+		}
 
-			// Build tsquery with AND operator between words and prefix matching on each word
-			// The search column already contains both language-specific and simple tsvectors
-			tsQueryExpr := fmt.Sprintf("to_tsquery('%s', regexp_replace(regexp_replace($3, '\\\\s+', ':* & ', 'g'), '$', ':*'))", tsConfig)
-			tsQuerySimple := "to_tsquery('simple', regexp_replace(regexp_replace($3, '\\\\s+', ':* & ', 'g'), '$', ':*'))"
+		tsConfig := MapLocaleToTSConfig(tenant.Locale)
 
-			// Use ts_rank_cd (cover density ranking) for better relevance scoring
-			// Query against the generated search column which combines language-specific and simple tsvectors
-			score := fmt.Sprintf("ts_rank_cd(q.search, %s) + ts_rank_cd(q.search, %s)", tsQueryExpr, tsQuerySimple)
+		// Build tsquery with AND operator between words and prefix matching on each word
+		// The search column already contains both language-specific and simple tsvectors
+		tsQueryExpr := fmt.Sprintf("to_tsquery('%s', regexp_replace(regexp_replace($3, '\\\\s+', ':* & ', 'g'), '$', ':*'))", tsConfig)
+		tsQuerySimple := "to_tsquery('simple', regexp_replace(regexp_replace($3, '\\\\s+', ':* & ', 'g'), '$', ':*'))"
 
-			// Match against the pre-computed search column
-			whereParts := fmt.Sprintf(`q.search @@ %s OR q.search @@ %s`, tsQueryExpr, tsQuerySimple)
+		// Full-text rank normalized to 0..1, which catches posts using the same words in a different order
+		textScore := fmt.Sprintf("LEAST(1, 2 * GREATEST(ts_rank_cd(q.search, %s, 32), ts_rank_cd(q.search, %s, 32)))", tsQueryExpr, tsQuerySimple)
 
-			sql := fmt.Sprintf(`
-				SELECT * FROM (%s) AS q
+		// Trigram similarity of titles, which catches different word forms and typos
+		trigramScore := "GREATEST(similarity(lower(q.title), $4), word_similarity($4, lower(q.title)))"
+
+		confidence := fmt.Sprintf("(0.6 * %s + 0.4 * %s)", trigramScore, textScore)
+
+		// Match against the pre-computed search column or titles with enough trigrams in common
+		whereParts := fmt.Sprintf(`(q.search @@ %s OR q.search @@ %s OR lower(q.title) %% $4 OR $4 <%% lower(q.title))`, tsQueryExpr, tsQuerySimple)
+
+		scores := []*dbEntities.SimilarPost{}
+		sql := fmt.Sprintf(`
+			SELECT id, confidence FROM (
+				SELECT q.id, %s AS confidence
+				FROM (%s) AS q
 				WHERE %s
-				ORDER BY %s DESC
-				LIMIT 5
-			`, innerQuery, whereParts, score)
-			err = trx.Select(&posts, sql, tenant.ID, pq.Array([]enum.PostStatus{
-				enum.PostOpen,
-				enum.PostStarted,
-				enum.PostPlanned,
-				enum.PostCompleted,
-				enum.PostDeclined,
-			}), ToTSQuery(SanitizeString(q.Query)))
-		}
+			) AS s
+			WHERE confidence >= $5
+			ORDER BY confidence DESC, id DESC
+			LIMIT 5
+		`, confidence, innerQuery, whereParts)
+		err := trx.Select(&scores, sql, tenant.ID, statuses, ToTSQuery(SanitizeString(q.Query)), strings.ToLower(SanitizeString(filteredQuery)), minSimilarPostConfidence)
 		if err != nil {
 			return errors.Wrap(err, "failed to find similar posts")
 		}
 
-		q.Result = make([]*entity.Post, len(posts))
-		for i, post := range posts {
-			q.Result[i] = post.ToModel(ctx)
+		if len(scores) == 0 {
+			return nil
+		}
+
+		ids := make([]int, len(scores))
+		for i, score := range scores {
+			ids[i] = score.ID
+		}
+
+		posts := []*dbEntities.Post{}
+		err = trx.Select(&posts, buildPostQuery(user, "p.tenant_id = $1 AND p.status = ANY($2) AND p.id = ANY($3)", ""), tenant.ID, statuses, pq.Array(ids))
+		if err != nil {
+			return errors.Wrap(err, "failed to get similar posts")
+		}
+
+		postsByID := make(map[int]*dbEntities.Post, len(posts))
+		for _, post := range posts {
+			postsByID[post.ID] = post
+		}
+
+		for _, score := range scores {
+			if post, ok := postsByID[score.ID]; ok {
+				q.Result = append(q.Result, &entity.SimilarPost{Post: post.ToModel(ctx), Confidence: score.Confidence})
+			}
 		}
 		return nil
 	})
@@ -516,26 +629,37 @@ func searchPosts(ctx context.Context, q *query.SearchPosts) error {
 
 			score := fmt.Sprintf("ts_rank_cd(q.search, %s) + ts_rank_cd(q.search, %s)", tsQueryExpr, tsQuerySimple)
 
-			whereParts := fmt.Sprintf(`q.search @@ %s OR q.search @@ %s`, tsQueryExpr, tsQuerySimple)
+			whereParts := fmt.Sprintf(`(q.search @@ %s OR q.search @@ %s)`, tsQueryExpr, tsQuerySimple)
 
-			sql := fmt.Sprintf(`
-				SELECT * FROM (%s) AS q
-				WHERE %s
-				ORDER BY q.pinned_at DESC NULLS LAST, %s DESC
-				LIMIT %s
-			`, innerQuery, whereParts, score, q.Limit)
-			err = trx.Select(&posts, sql, tenant.ID, pq.Array([]enum.PostStatus{
+			params := []any{tenant.ID, pq.Array([]enum.PostStatus{
 				enum.PostOpen,
 				enum.PostStarted,
 				enum.PostPlanned,
 				enum.PostCompleted,
 				enum.PostDeclined,
-			}), tsQuery)
+			}), tsQuery}
+			var fieldsCondition, linksFilter string
+			fieldsCondition, params = customFieldsCondition(user, q.CustomFields, params)
+			linksFilter, params = linksCondition(q.LinkType, q.LinkState, params)
+			fieldsCondition += linksFilter + answeredCondition(q.Answered)
+			if q.Board != "" {
+				params = append(params, q.Board)
+				fieldsCondition += fmt.Sprintf(" AND q.board_slug = $%d", len(params))
+			}
+
+			sql := fmt.Sprintf(`
+				SELECT * FROM (%s) AS q
+				WHERE %s %s
+				ORDER BY q.pinned_at DESC NULLS LAST, %s DESC
+				LIMIT %s
+			`, innerQuery, whereParts, fieldsCondition, score, q.Limit)
+			err = trx.Select(&posts, sql, params...)
 		} else {
 			condition, statuses, sort := getViewData(*q)
 
 			if q.MyPostsOnly {
-				condition += " AND user_id = " + strconv.Itoa(user.ID)
+				userID := strconv.Itoa(user.ID)
+				condition += " AND (user_id = " + userID + " OR EXISTS (SELECT 1 FROM post_coauthors pc WHERE pc.post_id = q.id AND pc.user_id = " + userID + "))"
 			}
 
 			// Second sort key: simple column (e.g. "id") -> "q.id"; expression (e.g. trending formula) -> qualify columns with "q."
@@ -548,16 +672,30 @@ func searchPosts(ctx context.Context, q *query.SearchPosts) error {
 				sortKey = "q." + sort
 			}
 
+			params := []any{tenant.ID, pq.Array(statuses)}
+			if len(q.Tags) > 0 {
+				// Filtering by a parent tag includes posts tagged with its children
+				tags, err := expandTagSlugs(trx, tenant, q.Tags, user == nil || !user.IsCollaborator())
+				if err != nil {
+					return err
+				}
+				params = append(params, pq.Array(tags))
+			}
+			var fieldsCondition, linksFilter string
+			fieldsCondition, params = customFieldsCondition(user, q.CustomFields, params)
+			linksFilter, params = linksCondition(q.LinkType, q.LinkState, params)
+			condition += fieldsCondition + linksFilter + answeredCondition(q.Answered)
+			if q.Board != "" {
+				params = append(params, q.Board)
+				condition += fmt.Sprintf(" AND q.board_slug = $%d", len(params))
+			}
+
 			sql := fmt.Sprintf(`
 				SELECT * FROM (%s) AS q
 				WHERE 1 = 1 %s
 				ORDER BY q.pinned_at DESC NULLS LAST, %s DESC
 				LIMIT %s
 			`, innerQuery, condition, sortKey, q.Limit)
-			params := []interface{}{tenant.ID, pq.Array(statuses)}
-			if len(q.Tags) > 0 {
-				params = append(params, pq.Array(q.Tags))
-			}
 			err = trx.Select(&posts, sql, params...)
 		}
 
@@ -573,6 +711,71 @@ func searchPosts(ctx context.Context, q *query.SearchPosts) error {
 	})
 }
 
+// customFieldsCondition builds a filter that matches posts having all the given custom field values.
+// A multi select value matches when any of its options equals the filter value.
+func customFieldsCondition(user *entity.User, fields map[string]string, params []any) (string, []any) {
+	visibility := " AND f.is_public = true"
+	if user != nil && user.IsCollaborator() {
+		visibility = ""
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	condition := ""
+	for _, key := range keys {
+		params = append(params, key, fields[key])
+		keyParam, valueParam := len(params)-1, len(params)
+		condition += fmt.Sprintf(`
+			AND EXISTS (
+				SELECT 1 FROM post_custom_field_values v
+				INNER JOIN custom_fields f
+				ON f.id = v.field_id
+				AND f.tenant_id = v.tenant_id
+				WHERE v.post_id = q.id
+				AND f.key = $%d%s
+				AND (v.value = $%d OR $%d = ANY(string_to_array(v.value, ',')))
+			)`, keyParam, visibility, valueParam, valueParam)
+	}
+	return condition, params
+}
+
+// answeredCondition builds a filter on whether posts have an accepted answer
+func answeredCondition(answered string) string {
+	switch answered {
+	case "answered":
+		return " AND q.answer_comment_id IS NOT NULL"
+	case "unanswered":
+		return " AND q.answer_comment_id IS NULL"
+	}
+	return ""
+}
+
+// linksCondition builds a filter on the external links of posts.
+// Link type 'none' matches posts without links and 'any' matches posts with links of any type.
+func linksCondition(linkType, linkState string, params []any) (string, []any) {
+	if linkType == "" && linkState == "" {
+		return "", params
+	}
+	if linkType == "none" {
+		return " AND NOT EXISTS (SELECT 1 FROM post_links l WHERE l.post_id = q.id)", params
+	}
+
+	condition := ""
+	if linkType != "" && linkType != "any" {
+		params = append(params, linkType)
+		condition += fmt.Sprintf(" AND l.type = $%d", len(params))
+	}
+	if linkState != "" {
+		params = append(params, linkState)
+		condition += fmt.Sprintf(" AND l.state = $%d", len(params))
+	}
+	return fmt.Sprintf(" AND EXISTS (SELECT 1 FROM post_links l WHERE l.post_id = q.id%s)", condition), params
+}
+
 func getAllPosts(ctx context.Context, q *query.GetAllPosts) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		searchQuery := &query.SearchPosts{View: "all", Limit: "all"}
@@ -596,8 +799,10 @@ func querySinglePost(ctx context.Context, trx *dbx.Trx, query string, args ...an
 
 func buildPostQuery(user *entity.User, filter string, moderationFilter string) string {
 	tagCondition := `AND tags.is_public = true`
+	fieldCondition := `AND f.is_public = true`
 	if user != nil && user.IsCollaborator() {
 		tagCondition = ``
+		fieldCondition = ``
 	}
 	hasVotedSubQuery := "null"
 	if user != nil {
@@ -626,16 +831,18 @@ func buildPostQuery(user *entity.User, filter string, moderationFilter string) s
 		approvalFilter = " AND p.is_approved = true"
 	}
 
-	combinedFilter := filter + approvalFilter
-	return fmt.Sprintf(sqlSelectPostsWhere, tagCondition, hasVotedSubQuery, combinedFilter)
+	combinedFilter := filter + approvalFilter + boardPrivacyFilter(user)
+	return fmt.Sprintf(sqlSelectPostsWhere, tagCondition, fieldCondition, hasVotedSubQuery, reactionUserID(user), combinedFilter)
 }
 
 // buildSinglePostQuery is used for fetching individual posts (by ID, slug, or number)
 // Collaborators can view any post for moderation purposes
 func buildSinglePostQuery(user *entity.User, filter string) string {
 	tagCondition := `AND tags.is_public = true`
+	fieldCondition := `AND f.is_public = true`
 	if user != nil && user.IsCollaborator() {
 		tagCondition = ``
+		fieldCondition = ``
 	}
 	hasVotedSubQuery := "null"
 	if user != nil {
@@ -655,8 +862,26 @@ func buildSinglePostQuery(user *entity.User, filter string) string {
 		approvalFilter = " AND p.is_approved = true"
 	}
 
-	combinedFilter := filter + approvalFilter
-	return fmt.Sprintf(sqlSelectPostsWhere, tagCondition, hasVotedSubQuery, combinedFilter)
+	combinedFilter := filter + approvalFilter + boardPrivacyFilter(user)
+	return fmt.Sprintf(sqlSelectPostsWhere, tagCondition, fieldCondition, hasVotedSubQuery, reactionUserID(user), combinedFilter)
+}
+
+// reactionUserID is who reaction counts are computed for, zero when anonymous
+func reactionUserID(user *entity.User) int {
+	if user != nil {
+		return user.ID
+	}
+	return 0
+}
+
+// boardPrivacyFilter hides posts of private boards from everyone but staff and the post author
+func boardPrivacyFilter(user *entity.User) string {
+	if user != nil && user.IsCollaborator() {
+		return ""
+	} else if user != nil {
+		return fmt.Sprintf(" AND (b.is_private IS NOT TRUE OR p.user_id = %d)", user.ID)
+	}
+	return " AND b.is_private IS NOT TRUE"
 }
 
 func setPostPinned(ctx context.Context, c *cmd.SetPostPinned) error {
@@ -680,13 +905,74 @@ func setPostPinned(ctx context.Context, c *cmd.SetPostPinned) error {
 	})
 }
 
+func setAcceptedAnswer(ctx context.Context, c *cmd.SetAcceptedAnswer) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if c.Accepted {
+			_, err := trx.Execute(`
+				UPDATE posts SET accepted_comment_id = $3, answer_accepted_at = $4 WHERE id = $1 AND tenant_id = $2`,
+				c.Post.ID, tenant.ID, c.CommentID, time.Now())
+			if err != nil {
+				return errors.Wrap(err, "failed to accept answer")
+			}
+		} else {
+			_, err := trx.Execute(`
+				UPDATE posts SET accepted_comment_id = NULL, answer_accepted_at = NULL WHERE id = $1 AND tenant_id = $2 AND accepted_comment_id = $3`,
+				c.Post.ID, tenant.ID, c.CommentID)
+			if err != nil {
+				return errors.Wrap(err, "failed to clear accepted answer")
+			}
+		}
+		return nil
+	})
+}
+
+func setPostLocked(ctx context.Context, c *cmd.SetPostLocked) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if c.Locked {
+			// Posts locked by the system, such as by the stale posts job, have no user
+			var userID any
+			if user != nil {
+				userID = user.ID
+			}
+
+			now := time.Now()
+			_, err := trx.Execute(`
+				UPDATE posts SET locked_at = $3, locked_by_id = $4, lock_reason = $5 WHERE id = $1 AND tenant_id = $2`,
+				c.Post.ID, tenant.ID, now, userID, c.Reason)
+			if err != nil {
+				return errors.Wrap(err, "failed to lock post")
+			}
+			c.Post.LockedAt = &now
+			c.Post.LockedBy = user
+			c.Post.LockReason = c.Reason
+		} else {
+			_, err := trx.Execute(`
+				UPDATE posts SET locked_at = NULL, locked_by_id = NULL, lock_reason = '' WHERE id = $1 AND tenant_id = $2`,
+				c.Post.ID, tenant.ID)
+			if err != nil {
+				return errors.Wrap(err, "failed to unlock post")
+			}
+			c.Post.LockedAt = nil
+			c.Post.LockedBy = nil
+			c.Post.LockReason = ""
+		}
+		return nil
+	})
+}
+
 func flagPost(ctx context.Context, c *cmd.FlagPost) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		// Flags raised by the system have no user and are kept once per post
+		var userID any
+		if user != nil {
+			userID = user.ID
+		}
+
 		_, err := trx.Execute(`
 			INSERT INTO post_flags (tenant_id, post_id, user_id, created_at, reason)
 			VALUES ($1, $2, $3, $4, $5)
-			ON CONFLICT (tenant_id, post_id, user_id) DO NOTHING`,
-			tenant.ID, c.PostID, user.ID, time.Now(), c.Reason,
+			ON CONFLICT DO NOTHING`,
+			tenant.ID, c.PostID, userID, time.Now(), c.Reason,
 		)
 		if err != nil {
 			return errors.Wrap(err, "failed to flag post")
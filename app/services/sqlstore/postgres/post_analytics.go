@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+)
+
+// postViewWindow is the period during which repeated views of a post from the same session are counted once
+const postViewWindow = 30 * time.Minute
+
+func recordPostView(ctx context.Context, c *cmd.RecordPostView) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		now := time.Now()
+		rows, err := trx.Execute(`
+			INSERT INTO post_views (tenant_id, post_id, session_id, created_at)
+			SELECT $1, $2, $3, $4
+			WHERE NOT EXISTS (
+				SELECT 1 FROM post_views
+				WHERE post_id = $2 AND session_id = $3 AND created_at > $5
+			)`, tenant.ID, c.PostID, c.SessionID, now, now.Add(-postViewWindow))
+		if err != nil {
+			return errors.Wrap(err, "failed to record view of post '%d'", c.PostID)
+		}
+
+		c.Result = rows > 0
+		if !c.Result {
+			return nil
+		}
+
+		_, err = trx.Execute(`
+			UPDATE posts SET views_count = views_count + 1 WHERE id = $1 AND tenant_id = $2`,
+			c.PostID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to increment views of post '%d'", c.PostID)
+		}
+		return nil
+	})
+}
+
+func getPostAnalytics(ctx context.Context, q *query.GetPostAnalytics) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		totals := dbEntities.PostTotals{}
+		err := trx.Get(&totals, `
+			SELECT
+				p.views_count AS views,
+				(SELECT COUNT(*) FROM post_votes v WHERE v.post_id = p.id AND v.tenant_id = p.tenant_id) AS voters,
+				(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.id AND c.tenant_id = p.tenant_id AND c.deleted_at IS NULL AND c.is_approved = true AND c.is_internal = false) AS comments
+			FROM posts p
+			WHERE p.id = $1 AND p.tenant_id = $2`, q.PostID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get totals of post '%d'", q.PostID)
+		}
+
+		series := []*dbEntities.PostActivity{}
+		err = trx.Select(&series, `
+			SELECT
+				d.day AS date,
+				(SELECT COUNT(*) FROM post_views v
+					WHERE v.post_id = $1 AND v.tenant_id = $2
+					AND v.created_at >= d.day AND v.created_at < d.day + INTERVAL '1 day') AS views,
+				(SELECT COUNT(*) FROM post_votes v
+					WHERE v.post_id = $1 AND v.tenant_id = $2
+					AND v.created_at >= d.day AND v.created_at < d.day + INTERVAL '1 day') AS votes,
+				(SELECT COUNT(*) FROM comments c
+					WHERE c.post_id = $1 AND c.tenant_id = $2 AND c.deleted_at IS NULL AND c.is_approved = true AND c.is_internal = false
+					AND c.created_at >= d.day AND c.created_at < d.day + INTERVAL '1 day') AS comments
+			FROM generate_series(date_trunc('day', $3::timestamptz), date_trunc('day', NOW()), INTERVAL '1 day') AS d(day)
+			ORDER BY d.day`, q.PostID, tenant.ID, q.Since)
+		if err != nil {
+			return errors.Wrap(err, "failed to get activity of post '%d'", q.PostID)
+		}
+
+		q.Result = &entity.PostAnalytics{
+			ViewsCount:     totals.Views,
+			VotersCount:    totals.Voters,
+			CommentsCount:  totals.Comments,
+			ConversionRate: entity.VoteConversionRate(totals.Voters, totals.Views),
+			Series:         make([]*entity.PostActivity, len(series)),
+		}
+		for i, a := range series {
+			q.Result.Series[i] = a.ToModel()
+		}
+		return nil
+	})
+}
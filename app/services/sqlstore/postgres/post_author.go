@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+)
+
+func getPostCoAuthors(ctx context.Context, q *query.GetPostCoAuthors) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		users := []*dbEntities.User{}
+		err := trx.Select(&users, `
+			SELECT u.id, u.name, u.email, u.role, u.status, u.avatar_type, u.avatar_bkey
+			FROM post_coauthors pc
+			INNER JOIN users u
+			ON u.id = pc.user_id
+			AND u.tenant_id = pc.tenant_id
+			WHERE pc.post_id = $1
+			AND pc.tenant_id = $2
+			AND u.status != $3
+			ORDER BY pc.created_at, u.id`, q.PostID, tenant.ID, enum.UserDeleted)
+		if err != nil {
+			return errors.Wrap(err, "failed to get co-authors of post '%d'", q.PostID)
+		}
+
+		q.Result = make([]*entity.User, len(users))
+		for i, u := range users {
+			q.Result[i] = u.ToModel(ctx)
+		}
+		return nil
+	})
+}
+
+func changePostOwner(ctx context.Context, c *cmd.ChangePostOwner) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			UPDATE posts SET user_id = $3 WHERE id = $1 AND tenant_id = $2`,
+			c.Post.ID, tenant.ID, c.User.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to change owner of post '%d'", c.Post.ID)
+		}
+
+		// The new owner can't also be a co-author
+		_, err = trx.Execute(`
+			DELETE FROM post_coauthors WHERE post_id = $1 AND tenant_id = $2 AND user_id = $3`,
+			c.Post.ID, tenant.ID, c.User.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove new owner from co-authors of post '%d'", c.Post.ID)
+		}
+
+		if err := internalAddSubscriber(trx, c.Post, tenant, c.User, true); err != nil {
+			return err
+		}
+
+		c.Post.User = c.User
+		return nil
+	})
+}
+
+func addPostCoAuthor(ctx context.Context, c *cmd.AddPostCoAuthor) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			INSERT INTO post_coauthors (tenant_id, post_id, user_id, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (post_id, user_id) DO NOTHING`,
+			tenant.ID, c.Post.ID, c.User.ID, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to add co-author to post '%d'", c.Post.ID)
+		}
+
+		return internalAddSubscriber(trx, c.Post, tenant, c.User, true)
+	})
+}
+
+func removePostCoAuthor(ctx context.Context, c *cmd.RemovePostCoAuthor) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			DELETE FROM post_coauthors WHERE post_id = $1 AND tenant_id = $2 AND user_id = $3`,
+			c.Post.ID, tenant.ID, c.User.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove co-author from post '%d'", c.Post.ID)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+)
+
+const sqlSelectPostLinks = `
+	SELECT id, post_id, type, external_id, url, state, created_by_id, created_at, updated_at
+	FROM post_links`
+
+func getPostLinkByID(ctx context.Context, q *query.GetPostLinkByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		link := dbEntities.PostLink{}
+		err := trx.Get(&link, sqlSelectPostLinks+`
+			WHERE id = $1 AND post_id = $2 AND tenant_id = $3`, q.LinkID, q.PostID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get link '%d' of post '%d'", q.LinkID, q.PostID)
+		}
+
+		q.Result = link.ToModel()
+		return nil
+	})
+}
+
+func getPostLinksByExternalID(ctx context.Context, q *query.GetPostLinksByExternalID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		links := []*dbEntities.PostLink{}
+		err := trx.Select(&links, sqlSelectPostLinks+`
+			WHERE tenant_id = $1 AND type = $2 AND external_id = $3
+			ORDER BY id`, tenant.ID, q.Type, q.ExternalID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get links to '%s'", q.ExternalID)
+		}
+
+		q.Result = make([]*entity.PostLink, len(links))
+		for i, link := range links {
+			q.Result[i] = link.ToModel()
+		}
+		return nil
+	})
+}
+
+func addPostLink(ctx context.Context, c *cmd.AddPostLink) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		now := time.Now()
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO post_links (tenant_id, post_id, type, external_id, url, state, created_by_id, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+			RETURNING id`,
+			tenant.ID, c.Post.ID, c.Type, c.ExternalID, c.URL, c.State, user.ID, now)
+		if err != nil {
+			return errors.Wrap(err, "failed to add link to post '%d'", c.Post.ID)
+		}
+
+		c.Result = &entity.PostLink{
+			ID:          id,
+			PostID:      c.Post.ID,
+			Type:        c.Type,
+			ExternalID:  c.ExternalID,
+			URL:         c.URL,
+			State:       c.State,
+			CreatedByID: user.ID,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		return nil
+	})
+}
+
+func setPostLinkState(ctx context.Context, c *cmd.SetPostLinkState) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		now := time.Now()
+		_, err := trx.Execute(`
+			UPDATE post_links SET state = $3, updated_at = $4 WHERE id = $1 AND tenant_id = $2`,
+			c.Link.ID, tenant.ID, c.State, now)
+		if err != nil {
+			return errors.Wrap(err, "failed to update state of link '%d'", c.Link.ID)
+		}
+
+		c.Link.State = c.State
+		c.Link.UpdatedAt = now
+		return nil
+	})
+}
+
+func deletePostLink(ctx context.Context, c *cmd.DeletePostLink) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`DELETE FROM post_links WHERE id = $1 AND tenant_id = $2`, c.Link.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete link '%d'", c.Link.ID)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+)
+
+func addPostLog(ctx context.Context, c *cmd.AddPostLog) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var userID any
+		if user != nil {
+			userID = user.ID
+		}
+
+		_, err := trx.Execute(`
+			INSERT INTO post_logs (tenant_id, post_id, user_id, action, details, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			tenant.ID, c.Post.ID, userID, c.Action, c.Details, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to add log to post '%d'", c.Post.ID)
+		}
+		return nil
+	})
+}
+
+func getPostLogs(ctx context.Context, q *query.GetPostLogs) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		logs := []*dbEntities.PostLog{}
+		err := trx.Select(&logs, `
+			SELECT l.id, l.action, l.details, l.user_id, u.name AS user_name, l.created_at
+			FROM post_logs l
+			LEFT JOIN users u
+			ON u.id = l.user_id
+			AND u.tenant_id = l.tenant_id
+			WHERE l.tenant_id = $1
+			AND l.post_id = $2
+			ORDER BY l.created_at, l.id`, tenant.ID, q.PostID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get logs of post '%d'", q.PostID)
+		}
+
+		q.Result = make([]*entity.PostLog, len(logs))
+		for i, l := range logs {
+			q.Result[i] = l.ToModel()
+		}
+		return nil
+	})
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+)
+
+func togglePostReaction(ctx context.Context, c *cmd.TogglePostReaction) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var added bool
+		err := trx.Scalar(&added, `
+			WITH toggle_reaction AS (
+				INSERT INTO post_reactions (tenant_id, post_id, user_id, emoji, created_at)
+				VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT (post_id, user_id, emoji) DO NOTHING
+				RETURNING true AS added
+			),
+			delete_existing AS (
+				DELETE FROM post_reactions
+				WHERE tenant_id = $1 AND post_id = $2 AND user_id = $3 AND emoji = $4
+				AND NOT EXISTS (SELECT 1 FROM toggle_reaction)
+				RETURNING false AS added
+			)
+			SELECT COALESCE(
+				(SELECT added FROM toggle_reaction),
+				(SELECT added FROM delete_existing),
+				false
+			)
+		`, tenant.ID, c.Post.ID, user.ID, c.Emoji, time.Now())
+
+		if err != nil {
+			return errors.Wrap(err, "failed to toggle post reaction")
+		}
+
+		c.Result = added
+		return nil
+	})
+}
+
+func listReactions(ctx context.Context, q *query.ListReactions) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if q.Limit <= 0 {
+			q.Limit = 20
+		}
+		if q.Page <= 0 {
+			q.Page = 1
+		}
+
+		// The reaction of the author would give away who wrote an anonymous post
+		source := `
+			SELECT r.emoji, r.created_at, r.user_id
+			FROM post_reactions r
+			INNER JOIN posts p
+			ON p.id = r.post_id
+			AND p.tenant_id = r.tenant_id
+			WHERE r.tenant_id = $1 AND r.post_id = $2
+			AND (NOT p.is_anonymous OR r.user_id <> p.user_id OR r.user_id = $3)
+		`
+		viewerID := 0
+		if user != nil {
+			viewerID = user.ID
+		}
+		third := viewerID
+
+		if q.CommentID > 0 {
+			// Same visibility as the comment list: internal notes and unapproved comments are hidden from visitors
+			visibilityFilter := ""
+			if user == nil || !user.IsCollaborator() {
+				visibilityFilter = fmt.Sprintf(" AND c.is_internal = false AND (c.is_approved = true OR c.user_id = %d)", viewerID)
+			}
+
+			source = `
+				SELECT r.emoji, r.created_on AS created_at, r.user_id
+				FROM reactions r
+				INNER JOIN comments c
+				ON c.id = r.comment_id
+				WHERE c.tenant_id = $1 AND c.post_id = $2 AND r.comment_id = $3
+				AND c.deleted_at IS NULL` + visibilityFilter + `
+			`
+			third = q.CommentID
+		}
+
+		filter := "($4::text = '' OR r.emoji = $4::text)"
+		err := trx.Scalar(&q.TotalCount, fmt.Sprintf(`
+			WITH reactors AS (%s)
+			SELECT COUNT(*)
+			FROM reactors r
+			INNER JOIN users u
+			ON u.id = r.user_id
+			AND u.tenant_id = $1
+			WHERE %s
+		`, source, filter), tenant.ID, q.PostID, third, q.Emoji)
+		if err != nil {
+			return errors.Wrap(err, "failed to count reactions")
+		}
+
+		reactors := []*dbEntities.Reactor{}
+		err = trx.Select(&reactors, fmt.Sprintf(`
+			WITH reactors AS (%s)
+			SELECT
+				r.emoji,
+				r.created_at,
+				u.id AS user_id,
+				u.name AS user_name,
+				u.role AS user_role,
+				u.status AS user_status,
+				u.avatar_type AS user_avatar_type,
+				u.avatar_bkey AS user_avatar_bkey
+			FROM reactors r
+			INNER JOIN users u
+			ON u.id = r.user_id
+			AND u.tenant_id = $1
+			WHERE %s
+			ORDER BY r.created_at, u.id
+			LIMIT $5 OFFSET $6
+		`, source, filter), tenant.ID, q.PostID, third, q.Emoji, q.Limit, (q.Page-1)*q.Limit)
+		if err != nil {
+			return errors.Wrap(err, "failed to list reactions")
+		}
+
+		q.Result = make([]*entity.Reactor, len(reactors))
+		for i, reactor := range reactors {
+			q.Result[i] = reactor.ToModel(ctx)
+		}
+		return nil
+	})
+}
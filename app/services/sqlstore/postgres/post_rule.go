@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/lib/pq"
+)
+
+const sqlSelectPostRules = `
+	SELECT id, name, is_enabled, keywords, pattern, match_in, email_domains, roles, board_slugs,
+	tags, status, subscriber_ids, flag_for_moderation, position
+	FROM post_rules
+	WHERE tenant_id = $1`
+
+func getPostRuleByID(ctx context.Context, q *query.GetPostRuleByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rule, err := queryPostRule(trx, sqlSelectPostRules+" AND id = $2", tenant.ID, q.RuleID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get post rule with id '%d'", q.RuleID)
+		}
+
+		q.Result = rule
+		return nil
+	})
+}
+
+func getAllPostRules(ctx context.Context, q *query.GetAllPostRules) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rules := []*dbEntities.PostRule{}
+		err := trx.Select(&rules, sqlSelectPostRules+" ORDER BY position, id", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get all post rules")
+		}
+
+		q.Result = make([]*entity.PostRule, len(rules))
+		for i, rule := range rules {
+			q.Result[i] = rule.ToModel()
+		}
+		return nil
+	})
+}
+
+func addNewPostRule(ctx context.Context, c *cmd.AddNewPostRule) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		r := c.Rule
+
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO post_rules (tenant_id, name, is_enabled, keywords, pattern, match_in, email_domains, roles, board_slugs,
+			tags, status, subscriber_ids, flag_for_moderation, position, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			RETURNING id`,
+			tenant.ID, r.Name, r.IsEnabled, pq.Array(r.Keywords), r.Pattern, r.MatchIn, pq.Array(r.EmailDomains), pq.Array(postRuleRoles(r)),
+			pq.Array(r.BoardSlugs), pq.Array(r.Tags), postRuleStatus(r), pq.Array(r.SubscriberIDs), r.FlagForModeration, r.Position, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to add new post rule")
+		}
+
+		c.Result, err = queryPostRule(trx, sqlSelectPostRules+" AND id = $2", tenant.ID, id)
+		return err
+	})
+}
+
+func updatePostRule(ctx context.Context, c *cmd.UpdatePostRule) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		r := c.Rule
+
+		_, err := trx.Execute(`
+			UPDATE post_rules
+			SET name = $3, is_enabled = $4, keywords = $5, pattern = $6, match_in = $7, email_domains = $8, roles = $9,
+			board_slugs = $10, tags = $11, status = $12, subscriber_ids = $13, flag_for_moderation = $14, position = $15
+			WHERE id = $1 AND tenant_id = $2`,
+			r.ID, tenant.ID, r.Name, r.IsEnabled, pq.Array(r.Keywords), r.Pattern, r.MatchIn, pq.Array(r.EmailDomains), pq.Array(postRuleRoles(r)),
+			pq.Array(r.BoardSlugs), pq.Array(r.Tags), postRuleStatus(r), pq.Array(r.SubscriberIDs), r.FlagForModeration, r.Position,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to update post rule with id '%d'", r.ID)
+		}
+
+		c.Result, err = queryPostRule(trx, sqlSelectPostRules+" AND id = $2", tenant.ID, r.ID)
+		return err
+	})
+}
+
+func deletePostRule(ctx context.Context, c *cmd.DeletePostRule) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`DELETE FROM post_rules WHERE id = $1 AND tenant_id = $2`, c.Rule.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete post rule with id '%d'", c.Rule.ID)
+		}
+		return nil
+	})
+}
+
+func listPostRuleSubjects(ctx context.Context, q *query.ListPostRuleSubjects) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		subjects := []*dbEntities.PostRuleSubject{}
+		err := trx.Select(&subjects, `
+			SELECT p.id, p.number, p.slug, p.title, p.description, u.email AS author_email, u.role AS author_role, b.slug AS board_slug
+			FROM posts p
+			INNER JOIN users u ON u.id = p.user_id AND u.tenant_id = p.tenant_id
+			LEFT JOIN boards b ON b.id = p.board_id AND b.tenant_id = p.tenant_id
+			WHERE p.tenant_id = $1 AND p.status <> $2 AND ($3 = 0 OR p.id = $3)
+			ORDER BY p.id DESC
+			LIMIT $4`,
+			tenant.ID, enum.PostDeleted, q.PostID, q.Limit,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to list posts for post rules")
+		}
+
+		q.Result = make([]*entity.PostRuleSubject, len(subjects))
+		for i, subject := range subjects {
+			q.Result[i] = subject.ToModel()
+		}
+		return nil
+	})
+}
+
+func queryPostRule(trx *dbx.Trx, query string, args ...any) (*entity.PostRule, error) {
+	rule := dbEntities.PostRule{}
+	if err := trx.Get(&rule, query, args...); err != nil {
+		return nil, err
+	}
+	return rule.ToModel(), nil
+}
+
+func postRuleRoles(r *entity.PostRule) []int {
+	roles := make([]int, len(r.Roles))
+	for i, role := range r.Roles {
+		roles[i] = int(role)
+	}
+	return roles
+}
+
+// postRuleStatus returns the status set by a rule as a nullable value
+func postRuleStatus(r *entity.PostRule) any {
+	if r.Status != nil {
+		return int(*r.Status)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
+)
+
+func TestPostRuleStorage_AddUpdateAndDelete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	status := enum.PostPlanned
+	addRule := &cmd.AddNewPostRule{Rule: &entity.PostRule{
+		Name:          "Mobile crashes",
+		IsEnabled:     true,
+		Keywords:      []string{"crash", "crashes"},
+		Roles:         []enum.Role{enum.RoleVisitor},
+		Tags:          []string{"bug"},
+		Status:        &status,
+		SubscriberIDs: []int{jonSnow.ID},
+	}}
+	err := bus.Dispatch(jonSnowCtx, addRule)
+	Expect(err).IsNil()
+	Expect(addRule.Result.ID).NotEquals(0)
+	Expect(addRule.Result.Keywords).Equals([]string{"crash", "crashes"})
+	Expect(addRule.Result.Roles).Equals([]enum.Role{enum.RoleVisitor})
+	Expect(*addRule.Result.Status).Equals(enum.PostPlanned)
+	Expect(addRule.Result.SubscriberIDs).Equals([]int{jonSnow.ID})
+	Expect(addRule.Result.EmailDomains).Equals([]string{})
+
+	rule := addRule.Result
+	rule.Status = nil
+	rule.IsEnabled = false
+	rule.FlagForModeration = true
+	updateRule := &cmd.UpdatePostRule{Rule: rule}
+	err = bus.Dispatch(jonSnowCtx, updateRule)
+	Expect(err).IsNil()
+	Expect(updateRule.Result.Status).IsNil()
+	Expect(updateRule.Result.IsEnabled).IsFalse()
+	Expect(updateRule.Result.FlagForModeration).IsTrue()
+
+	getAll := &query.GetAllPostRules{}
+	err = bus.Dispatch(jonSnowCtx, getAll)
+	Expect(err).IsNil()
+	Expect(getAll.Result).HasLen(1)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.DeletePostRule{Rule: rule})
+	Expect(err).IsNil()
+
+	getRule := &query.GetPostRuleByID{RuleID: rule.ID}
+	err = bus.Dispatch(jonSnowCtx, getRule)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestPostRuleStorage_ListPostRuleSubjects(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	post1 := &cmd.AddNewPost{Title: "App crashes on startup", Description: "every time"}
+	post2 := &cmd.AddNewPost{Title: "Add dark mode", Description: "please"}
+	err := bus.Dispatch(aryaStarkCtx, post1, post2)
+	Expect(err).IsNil()
+
+	listRecent := &query.ListPostRuleSubjects{Limit: 10}
+	listOne := &query.ListPostRuleSubjects{PostID: post1.Result.ID, Limit: 1}
+	err = bus.Dispatch(jonSnowCtx, listRecent, listOne)
+	Expect(err).IsNil()
+	Expect(listRecent.Result).HasLen(2)
+	Expect(listRecent.Result[0].PostID).Equals(post2.Result.ID)
+
+	Expect(listOne.Result).HasLen(1)
+	Expect(listOne.Result[0].Number).Equals(post1.Result.Number)
+	Expect(listOne.Result[0].Title).Equals("App crashes on startup")
+	Expect(listOne.Result[0].AuthorEmail).Equals(aryaStark.Email)
+	Expect(listOne.Result[0].AuthorRole).Equals(aryaStark.Role)
+	Expect(listOne.Result[0].BoardSlug).Equals("")
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/lib/pq"
+)
+
+const sqlSelectPostTemplates = `
+	SELECT t.id, t.name, t.content, t.required_sections, t.tag_slug, t.board_id, b.slug AS board_slug, t.position
+	FROM post_templates t
+	LEFT JOIN boards b
+	ON b.id = t.board_id
+	AND b.tenant_id = t.tenant_id
+	WHERE t.tenant_id = $1`
+
+func getPostTemplateByID(ctx context.Context, q *query.GetPostTemplateByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		template, err := queryPostTemplate(trx, sqlSelectPostTemplates+" AND t.id = $2", tenant.ID, q.TemplateID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get post template with id '%d'", q.TemplateID)
+		}
+
+		q.Result = template
+		return nil
+	})
+}
+
+func getAllPostTemplates(ctx context.Context, q *query.GetAllPostTemplates) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		// Templates of private boards or tags are only listed to staff
+		condition := `
+			AND (b.id IS NULL OR b.is_private = false)
+			AND NOT EXISTS (
+				SELECT 1 FROM tags tg
+				WHERE tg.tenant_id = t.tenant_id AND tg.slug = t.tag_slug AND tg.is_public = false
+			)`
+		if user != nil && user.IsCollaborator() {
+			condition = ""
+		}
+
+		templates := []*dbEntities.PostTemplate{}
+		err := trx.Select(&templates, sqlSelectPostTemplates+condition+" ORDER BY t.position, t.name", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get all post templates")
+		}
+
+		q.Result = make([]*entity.PostTemplate, len(templates))
+		for i, template := range templates {
+			q.Result[i] = template.ToModel()
+		}
+		return nil
+	})
+}
+
+func addNewPostTemplate(ctx context.Context, c *cmd.AddNewPostTemplate) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		tagSlug, boardID := postTemplateReferences(c.TagSlug, c.Board)
+
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO post_templates (tenant_id, name, content, required_sections, tag_slug, board_id, position, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id`,
+			tenant.ID, c.Name, c.Content, pq.Array(c.RequiredSections), tagSlug, boardID, c.Position, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to add new post template")
+		}
+
+		c.Result, err = queryPostTemplate(trx, sqlSelectPostTemplates+" AND t.id = $2", tenant.ID, id)
+		return err
+	})
+}
+
+func updatePostTemplate(ctx context.Context, c *cmd.UpdatePostTemplate) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		tagSlug, boardID := postTemplateReferences(c.TagSlug, c.Board)
+
+		_, err := trx.Execute(`
+			UPDATE post_templates
+			SET name = $3, content = $4, required_sections = $5, tag_slug = $6, board_id = $7, position = $8
+			WHERE id = $1 AND tenant_id = $2`,
+			c.TemplateID, tenant.ID, c.Name, c.Content, pq.Array(c.RequiredSections), tagSlug, boardID, c.Position,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to update post template with id '%d'", c.TemplateID)
+		}
+
+		c.Result, err = queryPostTemplate(trx, sqlSelectPostTemplates+" AND t.id = $2", tenant.ID, c.TemplateID)
+		return err
+	})
+}
+
+func deletePostTemplate(ctx context.Context, c *cmd.DeletePostTemplate) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`DELETE FROM post_templates WHERE id = $1 AND tenant_id = $2`, c.Template.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete post template with id '%d'", c.Template.ID)
+		}
+		return nil
+	})
+}
+
+func queryPostTemplate(trx *dbx.Trx, query string, args ...any) (*entity.PostTemplate, error) {
+	template := dbEntities.PostTemplate{}
+	if err := trx.Get(&template, query, args...); err != nil {
+		return nil, err
+	}
+	return template.ToModel(), nil
+}
+
+// postTemplateReferences returns the tag slug and board id of a template as nullable values
+func postTemplateReferences(tagSlug string, board *entity.Board) (any, any) {
+	var tag, boardID any
+	if tagSlug != "" {
+		tag = tagSlug
+	}
+	if board != nil {
+		boardID = board.ID
+	}
+	return tag, boardID
+}
@@ -0,0 +1,44 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestPostTemplateStorage_GetAllPostTemplates_PrivateOnlyForStaff(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addPrivateBoard := &cmd.AddNewBoard{Name: "Support", IsPrivate: true}
+	addPrivateTag := &cmd.AddNewTag{Name: "Security", Color: "FF0000", IsPublic: false}
+	addPublicTag := &cmd.AddNewTag{Name: "Feature", Color: "00FF00", IsPublic: true}
+	err := bus.Dispatch(jonSnowCtx, addPrivateBoard, addPrivateTag, addPublicTag)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.AddNewPostTemplate{Name: "Idea", Content: "## Idea", TagSlug: "feature", Position: 1},
+		&cmd.AddNewPostTemplate{Name: "Ticket", Content: "## Problem", Board: addPrivateBoard.Result, Position: 2},
+		&cmd.AddNewPostTemplate{Name: "Vulnerability", Content: "## Impact", TagSlug: "security", Position: 3},
+	)
+	Expect(err).IsNil()
+
+	staffTemplates := &query.GetAllPostTemplates{}
+	err = bus.Dispatch(jonSnowCtx, staffTemplates)
+	Expect(err).IsNil()
+	Expect(staffTemplates.Result).HasLen(3)
+
+	visitorTemplates := &query.GetAllPostTemplates{}
+	err = bus.Dispatch(aryaStarkCtx, visitorTemplates)
+	Expect(err).IsNil()
+	Expect(visitorTemplates.Result).HasLen(1)
+	Expect(visitorTemplates.Result[0].Name).Equals("Idea")
+
+	anonymousTemplates := &query.GetAllPostTemplates{}
+	err = bus.Dispatch(demoTenantCtx, anonymousTemplates)
+	Expect(err).IsNil()
+	Expect(anonymousTemplates.Result).HasLen(1)
+}
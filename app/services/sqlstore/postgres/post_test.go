@@ -1033,3 +1033,65 @@ func TestViewReactions_AnonymousUser(t *testing.T) {
 	Expect(commentByID.Result[0].ReactionCounts[0].Count).Equals(1)
 	Expect(commentByID.Result[0].ReactionCounts[0].IncludesMe).IsFalse()
 }
+
+func TestListReactions_InternalCommentHiddenFromVisitors(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	newComment := &cmd.AddNewComment{Post: newPost.Result, Content: "Staff only", IsInternal: true}
+	err = bus.Dispatch(jonSnowCtx, newComment)
+	Expect(err).IsNil()
+
+	reaction := &cmd.ToggleCommentReaction{Comment: newComment.Result, Emoji: "👍", User: jonSnow}
+	err = bus.Dispatch(jonSnowCtx, reaction)
+	Expect(err).IsNil()
+
+	listReactions := &query.ListReactions{PostID: newPost.Result.ID, CommentID: newComment.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, listReactions)
+	Expect(err).IsNil()
+	Expect(listReactions.TotalCount).Equals(1)
+
+	listReactions = &query.ListReactions{PostID: newPost.Result.ID, CommentID: newComment.Result.ID}
+	err = bus.Dispatch(aryaStarkCtx, listReactions)
+	Expect(err).IsNil()
+	Expect(listReactions.TotalCount).Equals(0)
+	Expect(listReactions.Result).HasLen(0)
+
+	listReactions = &query.ListReactions{PostID: newPost.Result.ID, CommentID: newComment.Result.ID}
+	err = bus.Dispatch(demoTenantCtx, listReactions)
+	Expect(err).IsNil()
+	Expect(listReactions.TotalCount).Equals(0)
+}
+
+func TestPostStorage_Leaderboard_HidesAnonymousAuthors(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	anonymousPost := &cmd.AddNewPost{Title: "Salary transparency", Description: "Please", IsAnonymous: true}
+	publicPost := &cmd.AddNewPost{Title: "Dark mode", Description: "Please"}
+	err := bus.Dispatch(aryaStarkCtx, anonymousPost, publicPost)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AddVote{Post: anonymousPost.Result, User: jonSnow})
+	Expect(err).IsNil()
+
+	topPosts := &query.GetTopPostsByVotes{}
+	topUsers := &query.GetTopUsersByVotes{}
+	err = bus.Dispatch(demoTenantCtx, topPosts, topUsers)
+	Expect(err).IsNil()
+
+	Expect(topPosts.Result).HasLen(2)
+	Expect(topPosts.Result[0].Number).Equals(anonymousPost.Result.Number)
+	Expect(topPosts.Result[0].UserID).Equals(0)
+	Expect(topPosts.Result[0].UserName).Equals("Anonymous")
+	Expect(topPosts.Result[1].UserName).Equals(aryaStark.Name)
+
+	// Votes on the anonymous post don't count toward its author
+	Expect(topUsers.Result).HasLen(1)
+	Expect(topUsers.Result[0].UserID).Equals(aryaStark.ID)
+	Expect(topUsers.Result[0].VotesCount).Equals(0)
+}
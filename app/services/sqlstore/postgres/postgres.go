@@ -44,6 +44,10 @@ func (s Service) Init() {
 	bus.AddHandler(removeSubscriber)
 	bus.AddHandler(supressEmail)
 	bus.AddHandler(getActiveSubscribers)
+	bus.AddHandler(getTagFollowers)
+	bus.AddHandler(subscribeToTag)
+	bus.AddHandler(unsubscribeFromTag)
+	bus.AddHandler(getTagSubscriptions)
 
 	bus.AddHandler(getTagBySlug)
 	bus.AddHandler(getAssignedTags)
@@ -53,10 +57,47 @@ func (s Service) Init() {
 	bus.AddHandler(deleteTag)
 	bus.AddHandler(assignTag)
 	bus.AddHandler(unassignTag)
+	bus.AddHandler(mergeTags)
+	bus.AddHandler(getTagSlugRedirects)
+	bus.AddHandler(getTagStats)
+	bus.AddHandler(getTagGroupBySlug)
+	bus.AddHandler(getAllTagGroups)
+	bus.AddHandler(countPostsWithManyGroupTags)
+	bus.AddHandler(addNewTagGroup)
+	bus.AddHandler(updateTagGroup)
+	bus.AddHandler(deleteTagGroup)
+
+	bus.AddHandler(getCustomFieldByKey)
+	bus.AddHandler(getAllCustomFields)
+	bus.AddHandler(addCustomField)
+	bus.AddHandler(updateCustomField)
+	bus.AddHandler(deleteCustomField)
+	bus.AddHandler(setPostCustomFields)
+
+	bus.AddHandler(getBoardBySlug)
+	bus.AddHandler(getBoardByID)
+	bus.AddHandler(getAllBoards)
+	bus.AddHandler(addNewBoard)
+	bus.AddHandler(updateBoard)
+	bus.AddHandler(deleteBoard)
+	bus.AddHandler(setPostBoard)
+
+	bus.AddHandler(getPostTemplateByID)
+	bus.AddHandler(getAllPostTemplates)
+	bus.AddHandler(addNewPostTemplate)
+	bus.AddHandler(updatePostTemplate)
+	bus.AddHandler(deletePostTemplate)
+	bus.AddHandler(getPostRuleByID)
+	bus.AddHandler(getAllPostRules)
+	bus.AddHandler(addNewPostRule)
+	bus.AddHandler(updatePostRule)
+	bus.AddHandler(deletePostRule)
+	bus.AddHandler(listPostRuleSubjects)
 
 	bus.AddHandler(addVote)
 	bus.AddHandler(removeVote)
 	bus.AddHandler(listPostVotes)
+	bus.AddHandler(countActiveVotes)
 
 	bus.AddHandler(addNewPost)
 	bus.AddHandler(updatePost)
@@ -65,11 +106,17 @@ func (s Service) Init() {
 	bus.AddHandler(getPostByNumber)
 	bus.AddHandler(searchPosts)
 	bus.AddHandler(findSimilarPosts)
+	bus.AddHandler(getPostDuplicateCandidates)
+	bus.AddHandler(getPostDuplicateCandidateByID)
+	bus.AddHandler(findPostDuplicateCandidates)
+	bus.AddHandler(dismissPostDuplicateCandidate)
 	bus.AddHandler(getAllPosts)
 	bus.AddHandler(getTopPostsByVotes)
 	bus.AddHandler(getTopUsersByVotes)
 	bus.AddHandler(countPostPerStatus)
 	bus.AddHandler(setPostPinned)
+	bus.AddHandler(setPostLocked)
+	bus.AddHandler(setAcceptedAnswer)
 	bus.AddHandler(flagPost)
 	bus.AddHandler(getPostFlagsCount)
 	bus.AddHandler(getFlaggedPosts)
@@ -78,6 +125,44 @@ func (s Service) Init() {
 	bus.AddHandler(setPostResponse)
 	bus.AddHandler(postIsReferenced)
 
+	bus.AddHandler(addPostLog)
+	bus.AddHandler(getPostLogs)
+
+	bus.AddHandler(getPostLinkByID)
+	bus.AddHandler(getPostLinksByExternalID)
+	bus.AddHandler(addPostLink)
+	bus.AddHandler(setPostLinkState)
+	bus.AddHandler(deletePostLink)
+
+	bus.AddHandler(recordPostView)
+	bus.AddHandler(getPostAnalytics)
+
+	bus.AddHandler(getPostCoAuthors)
+	bus.AddHandler(changePostOwner)
+	bus.AddHandler(addPostCoAuthor)
+	bus.AddHandler(removePostCoAuthor)
+
+	bus.AddHandler(getPollsByPost)
+	bus.AddHandler(getPollByID)
+	bus.AddHandler(getAllPolls)
+	bus.AddHandler(addNewPoll)
+	bus.AddHandler(answerPoll)
+	bus.AddHandler(deletePoll)
+
+	bus.AddHandler(getScheduledResponses)
+	bus.AddHandler(getScheduledResponseByID)
+	bus.AddHandler(listDueScheduledResponses)
+	bus.AddHandler(addScheduledResponse)
+	bus.AddHandler(deleteScheduledResponse)
+	bus.AddHandler(markScheduledResponseAsExecuted)
+
+	bus.AddHandler(getStalePostRules)
+	bus.AddHandler(listActiveStalePostRules)
+	bus.AddHandler(saveStalePostRules)
+	bus.AddHandler(getPostsToLock)
+	bus.AddHandler(getStalePosts)
+	bus.AddHandler(markPostAsStaleWarned)
+
 	bus.AddHandler(setAttachments)
 	bus.AddHandler(getAttachments)
 	bus.AddHandler(uploadImage)
@@ -86,6 +171,8 @@ func (s Service) Init() {
 	bus.AddHandler(addNewComment)
 	bus.AddHandler(updateComment)
 	bus.AddHandler(toggleCommentReaction)
+	bus.AddHandler(togglePostReaction)
+	bus.AddHandler(listReactions)
 	bus.AddHandler(deleteComment)
 	bus.AddHandler(flagComment)
 	bus.AddHandler(setCommentPinned)
@@ -114,6 +201,7 @@ func (s Service) Init() {
 	bus.AddHandler(getUserByAPIKey)
 	bus.AddHandler(getUserByEmail)
 	bus.AddHandler(getUserByID)
+	bus.AddHandler(getPostAuthor)
 	bus.AddHandler(getUserByProvider)
 	bus.AddHandler(getAllUsers)
 	bus.AddHandler(getAllUsersNames)
@@ -121,12 +209,15 @@ func (s Service) Init() {
 
 	bus.AddHandler(createTenant)
 	bus.AddHandler(getFirstTenant)
+	bus.AddHandler(getTenantByID)
 	bus.AddHandler(getTenantByDomain)
 	bus.AddHandler(activateTenant)
 	bus.AddHandler(isSubdomainAvailable)
 	bus.AddHandler(isCNAMEAvailable)
 	bus.AddHandler(updateTenantSettings)
 	bus.AddHandler(updateTenantPrivacySettings)
+	bus.AddHandler(updateTenantVotingSettings)
+	bus.AddHandler(updateTenantReactionSettings)
 	bus.AddHandler(updateTenantEmailAuthAllowedSettings)
 	bus.AddHandler(updateTenantAdvancedSettings)
 
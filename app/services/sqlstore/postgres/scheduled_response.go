@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+)
+
+const sqlSelectScheduledResponses = `
+	SELECT r.id, r.tenant_id, r.post_id, r.status, r.response, r.scheduled_at, r.base_url, r.created_at,
+		u.id AS created_by_id,
+		u.name AS created_by_name,
+		u.email AS created_by_email,
+		u.role AS created_by_role,
+		u.status AS created_by_status,
+		u.avatar_type AS created_by_avatar_type,
+		u.avatar_bkey AS created_by_avatar_bkey
+	FROM post_scheduled_responses r
+	INNER JOIN users u
+	ON u.id = r.created_by_id
+	AND u.tenant_id = r.tenant_id
+	WHERE r.executed_at IS NULL`
+
+func getScheduledResponses(ctx context.Context, q *query.GetScheduledResponses) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		responses := []*dbEntities.ScheduledResponse{}
+		err := trx.Select(&responses, sqlSelectScheduledResponses+`
+			AND r.tenant_id = $1
+			AND r.post_id = $2
+			ORDER BY r.scheduled_at NULLS FIRST, r.id`, tenant.ID, q.PostID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get scheduled responses of post '%d'", q.PostID)
+		}
+
+		q.Result = make([]*entity.ScheduledResponse, len(responses))
+		for i, r := range responses {
+			q.Result[i] = r.ToModel(ctx)
+		}
+		return nil
+	})
+}
+
+func getScheduledResponseByID(ctx context.Context, q *query.GetScheduledResponseByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		response := dbEntities.ScheduledResponse{}
+		err := trx.Get(&response, sqlSelectScheduledResponses+`
+			AND r.tenant_id = $1
+			AND r.id = $2`, tenant.ID, q.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get scheduled response with id '%d'", q.ID)
+		}
+
+		q.Result = response.ToModel(ctx)
+		return nil
+	})
+}
+
+func listDueScheduledResponses(ctx context.Context, q *query.ListDueScheduledResponses) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		responses := []*dbEntities.ScheduledResponse{}
+		err := trx.Select(&responses, `
+			SELECT r.id, r.tenant_id, r.post_id, r.status, r.response, r.scheduled_at, r.base_url, r.created_at,
+				r.created_by_id
+			FROM post_scheduled_responses r
+			WHERE r.executed_at IS NULL
+			AND r.scheduled_at <= $1
+			ORDER BY r.tenant_id, r.scheduled_at, r.id`, q.Now)
+		if err != nil {
+			return errors.Wrap(err, "failed to list due scheduled responses")
+		}
+
+		q.Result = make([]*entity.ScheduledResponse, len(responses))
+		for i, r := range responses {
+			q.Result[i] = r.ToModel(ctx)
+		}
+		return nil
+	})
+}
+
+func addScheduledResponse(ctx context.Context, c *cmd.AddScheduledResponse) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var id int
+		err := trx.Get(&id, `
+			INSERT INTO post_scheduled_responses (tenant_id, post_id, status, response, scheduled_at, base_url, created_by_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id`,
+			tenant.ID, c.Post.ID, c.Status, c.Text, c.ScheduledAt, c.BaseURL, user.ID, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to add scheduled response to post '%d'", c.Post.ID)
+		}
+
+		response := dbEntities.ScheduledResponse{}
+		err = trx.Get(&response, sqlSelectScheduledResponses+" AND r.tenant_id = $1 AND r.id = $2", tenant.ID, id)
+		if err != nil {
+			return errors.Wrap(err, "failed to get scheduled response with id '%d'", id)
+		}
+
+		c.Result = response.ToModel(ctx)
+		return nil
+	})
+}
+
+func deleteScheduledResponse(ctx context.Context, c *cmd.DeleteScheduledResponse) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"DELETE FROM post_scheduled_responses WHERE id = $1 AND tenant_id = $2 AND executed_at IS NULL",
+			c.ID, tenant.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete scheduled response with id '%d'", c.ID)
+		}
+		return nil
+	})
+}
+
+func markScheduledResponseAsExecuted(ctx context.Context, c *cmd.MarkScheduledResponseAsExecuted) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"UPDATE post_scheduled_responses SET executed_at = $3 WHERE id = $1 AND tenant_id = $2",
+			c.ID, tenant.ID, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to mark scheduled response '%d' as executed", c.ID)
+		}
+		return nil
+	})
+}
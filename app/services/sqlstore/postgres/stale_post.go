@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/dbx"
+	"github.com/getfider/fider/app/pkg/errors"
+	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/lib/pq"
+)
+
+func getStalePostRules(ctx context.Context, q *query.GetStalePostRules) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rules := dbEntities.StalePostRules{}
+		err := trx.Get(&rules, `
+			SELECT tenant_id, inactive_days, grace_days, close_status, response, exempt_tags, lock_after_days
+			FROM stale_post_rules
+			WHERE tenant_id = $1`, tenant.ID)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return errors.Wrap(err, "failed to get stale post rules")
+		}
+
+		if err != nil {
+			q.Result = &entity.StalePostRules{
+				TenantID:    tenant.ID,
+				GraceDays:   14,
+				CloseStatus: enum.PostDeclined,
+				ExemptTags:  []string{},
+			}
+			return nil
+		}
+
+		q.Result = rules.ToModel()
+		return nil
+	})
+}
+
+func listActiveStalePostRules(ctx context.Context, q *query.ListActiveStalePostRules) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		rules := []*dbEntities.StalePostRules{}
+		err := trx.Select(&rules, `
+			SELECT r.tenant_id, r.inactive_days, r.grace_days, r.close_status, r.response, r.exempt_tags, r.lock_after_days
+			FROM stale_post_rules r
+			INNER JOIN tenants t
+			ON t.id = r.tenant_id
+			WHERE (r.inactive_days > 0 OR r.lock_after_days > 0)
+			AND t.status = $1
+			ORDER BY r.tenant_id`, enum.TenantActive)
+		if err != nil {
+			return errors.Wrap(err, "failed to list active stale post rules")
+		}
+
+		q.Result = make([]*entity.StalePostRules, len(rules))
+		for i, r := range rules {
+			q.Result[i] = r.ToModel()
+		}
+		return nil
+	})
+}
+
+func saveStalePostRules(ctx context.Context, c *cmd.SaveStalePostRules) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			INSERT INTO stale_post_rules (tenant_id, inactive_days, grace_days, close_status, response, exempt_tags, lock_after_days, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (tenant_id) DO UPDATE
+			SET inactive_days = $2, grace_days = $3, close_status = $4, response = $5, exempt_tags = $6, lock_after_days = $7, updated_at = $8`,
+			tenant.ID, c.InactiveDays, c.GraceDays, c.CloseStatus, c.Response, pq.Array(c.ExemptTags), c.LockAfterDays, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to save stale post rules")
+		}
+		return nil
+	})
+}
+
+func getStalePosts(ctx context.Context, q *query.GetStalePosts) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.ToWarn = make([]int, 0)
+		q.ToClose = make([]int, 0)
+
+		exemptTags := q.Rules.ExemptTags
+		if exemptTags == nil {
+			exemptTags = []string{}
+		}
+
+		// A post is active again when anything happens after it was warned
+		posts := []*dbEntities.StalePost{}
+		err := trx.Select(&posts, `
+			WITH activity AS (
+				SELECT p.number, p.stale_warned_at,
+					GREATEST(
+						p.created_at,
+						p.response_date,
+						(SELECT MAX(pv.created_at) FROM post_votes pv WHERE pv.post_id = p.id AND pv.tenant_id = p.tenant_id),
+						(SELECT MAX(COALESCE(c.edited_at, c.created_at)) FROM comments c WHERE c.post_id = p.id AND c.tenant_id = p.tenant_id AND c.deleted_at IS NULL)
+					) AS last_activity_at
+				FROM posts p
+				WHERE p.tenant_id = $1
+				AND p.status = $2
+				AND p.pinned_at IS NULL
+				AND NOT EXISTS (
+					SELECT 1 FROM post_tags pt
+					INNER JOIN tags t
+					ON t.id = pt.tag_id
+					AND t.tenant_id = pt.tenant_id
+					WHERE pt.post_id = p.id
+					AND pt.tenant_id = p.tenant_id
+					AND t.slug = ANY($3)
+				)
+			)
+			SELECT number, (stale_warned_at IS NOT NULL AND stale_warned_at >= last_activity_at) AS is_warned
+			FROM activity
+			WHERE (
+				(stale_warned_at IS NULL OR stale_warned_at < last_activity_at)
+				AND last_activity_at <= $4
+			) OR (
+				stale_warned_at >= last_activity_at
+				AND stale_warned_at <= $5
+			)
+			ORDER BY number`,
+			tenant.ID, enum.PostOpen, pq.Array(exemptTags),
+			q.Now.AddDate(0, 0, -q.Rules.InactiveDays), q.Now.AddDate(0, 0, -q.Rules.GraceDays),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to get stale posts")
+		}
+
+		for _, post := range posts {
+			if post.IsWarned {
+				q.ToClose = append(q.ToClose, post.Number)
+			} else {
+				q.ToWarn = append(q.ToWarn, post.Number)
+			}
+		}
+		return nil
+	})
+}
+
+func markPostAsStaleWarned(ctx context.Context, c *cmd.MarkPostAsStaleWarned) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"UPDATE posts SET stale_warned_at = $3 WHERE id = $1 AND tenant_id = $2",
+			c.Post.ID, tenant.ID, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to mark post '%d' as stale warned", c.Post.ID)
+		}
+		return nil
+	})
+}
+
+func getPostsToLock(ctx context.Context, q *query.GetPostsToLock) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		// Posts unlocked by staff since they were closed are left unlocked
+		posts := []*dbEntities.StalePost{}
+		err := trx.Select(&posts, `
+			SELECT number
+			FROM posts p
+			WHERE p.tenant_id = $1
+			AND p.status = ANY($2)
+			AND p.locked_at IS NULL
+			AND p.response_date <= $3
+			AND NOT EXISTS (
+				SELECT 1 FROM post_logs l
+				WHERE l.tenant_id = p.tenant_id AND l.post_id = p.id
+				AND l.action = $4 AND l.created_at >= p.response_date
+			)
+			ORDER BY number`,
+			tenant.ID, pq.Array([]enum.PostStatus{enum.PostCompleted, enum.PostDeclined, enum.PostDuplicate}),
+			q.Now.AddDate(0, 0, -q.Rules.LockAfterDays), entity.PostLogUnlocked,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to get posts to lock")
+		}
+
+		q.Result = make([]int, len(posts))
+		for i, post := range posts {
+			q.Result[i] = post.Number
+		}
+		return nil
+	})
+}
@@ -352,3 +352,162 @@ func TestSubscription_EmailSupressed(t *testing.T) {
 	Expect(q.Result).HasLen(1)
 	Expect(q.Result[0].ID).Equals(jonSnow.ID)
 }
+
+func TestSubscription_TagFollower(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addTag := &cmd.AddNewTag{Name: "Accessibility", Color: "0000FF", IsPublic: true}
+	err := bus.Dispatch(jonSnowCtx, addTag)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(sansaStarkCtx, &cmd.SubscribeToTag{Tag: addTag.Result, Channels: enum.NotificationChannelWeb})
+	Expect(err).IsNil()
+
+	newPost := &cmd.AddNewPost{Title: "Screen reader support", Description: "Please"}
+	err = bus.Dispatch(aryaStarkCtx, newPost)
+	Expect(err).IsNil()
+
+	// Not notified while the post is untagged
+	q := &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewPost}
+	err = bus.Dispatch(aryaStarkCtx, q)
+	Expect(err).IsNil()
+	Expect(q.Result).HasLen(1)
+	Expect(q.Result[0].ID).Equals(jonSnow.ID)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: addTag.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+
+	newPostSubscribers := &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewPost}
+	newPostEmailSubscribers := &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelEmail, Event: enum.NotificationEventNewPost}
+	newCommentSubscribers := &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewComment}
+	changeStatusSubscribers := &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventChangeStatus}
+	err = bus.Dispatch(aryaStarkCtx, newPostSubscribers, newPostEmailSubscribers, newCommentSubscribers, changeStatusSubscribers)
+	Expect(err).IsNil()
+
+	Expect(newPostSubscribers.Result).HasLen(2)
+	Expect(newPostSubscribers.Result[0].ID).Equals(jonSnow.ID)
+	Expect(newPostSubscribers.Result[1].ID).Equals(sansaStark.ID)
+
+	Expect(newPostEmailSubscribers.Result).HasLen(1)
+	Expect(newPostEmailSubscribers.Result[0].ID).Equals(jonSnow.ID)
+
+	Expect(newCommentSubscribers.Result).HasLen(2)
+	Expect(newCommentSubscribers.Result[0].ID).Equals(jonSnow.ID)
+	Expect(newCommentSubscribers.Result[1].ID).Equals(aryaStark.ID)
+
+	Expect(changeStatusSubscribers.Result).HasLen(3)
+	Expect(changeStatusSubscribers.Result[2].ID).Equals(sansaStark.ID)
+
+	// Comments are only included when asked for
+	err = bus.Dispatch(sansaStarkCtx, &cmd.SubscribeToTag{Tag: addTag.Result, Channels: enum.NotificationChannelWeb, IncludeComments: true})
+	Expect(err).IsNil()
+
+	newCommentSubscribers = &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewComment}
+	err = bus.Dispatch(aryaStarkCtx, newCommentSubscribers)
+	Expect(err).IsNil()
+	Expect(newCommentSubscribers.Result).HasLen(3)
+	Expect(newCommentSubscribers.Result[2].ID).Equals(sansaStark.ID)
+
+	subscriptions := &query.GetTagSubscriptions{}
+	err = bus.Dispatch(sansaStarkCtx, subscriptions)
+	Expect(err).IsNil()
+	Expect(subscriptions.Result).HasLen(1)
+	Expect(subscriptions.Result[0].Tag.Slug).Equals("accessibility")
+	Expect(subscriptions.Result[0].Channels).Equals(enum.NotificationChannelWeb)
+	Expect(subscriptions.Result[0].IncludeComments).IsTrue()
+
+	// Unsubscribing from the post itself wins over following its tag
+	err = bus.Dispatch(sansaStarkCtx, &cmd.RemoveSubscriber{Post: newPost.Result, User: sansaStark})
+	Expect(err).IsNil()
+
+	changeStatusSubscribers = &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventChangeStatus}
+	err = bus.Dispatch(aryaStarkCtx, changeStatusSubscribers)
+	Expect(err).IsNil()
+	Expect(changeStatusSubscribers.Result).HasLen(2)
+
+	err = bus.Dispatch(sansaStarkCtx, &cmd.UnsubscribeFromTag{Tag: addTag.Result})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(sansaStarkCtx, subscriptions)
+	Expect(err).IsNil()
+	Expect(subscriptions.Result).HasLen(0)
+}
+
+func TestSubscription_TagFollower_PrivateTag(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addTag := &cmd.AddNewTag{Name: "Security", Color: "FF0000", IsPublic: false}
+	err := bus.Dispatch(jonSnowCtx, addTag)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(sansaStarkCtx, &cmd.SubscribeToTag{Tag: addTag.Result, Channels: enum.NotificationChannelWeb})
+	Expect(err).IsNil()
+
+	newPost := &cmd.AddNewPost{Title: "Leaked tokens", Description: "In the logs"}
+	err = bus.Dispatch(aryaStarkCtx, newPost)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: addTag.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+
+	q := &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewPost}
+	err = bus.Dispatch(aryaStarkCtx, q)
+	Expect(err).IsNil()
+	Expect(q.Result).HasLen(1)
+	Expect(q.Result[0].ID).Equals(jonSnow.ID)
+}
+
+func TestSubscription_TagFollower_ParentTag(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addMobile := &cmd.AddNewTag{Name: "Mobile", Color: "0000FF", IsPublic: true}
+	err := bus.Dispatch(jonSnowCtx, addMobile)
+	Expect(err).IsNil()
+
+	addIOS := &cmd.AddNewTag{Name: "iOS", Color: "0000FF", IsPublic: true, ParentID: addMobile.Result.ID}
+	addBeta := &cmd.AddNewTag{Name: "Beta", Color: "0000FF", IsPublic: false, ParentID: addMobile.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, addIOS, addBeta)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(sansaStarkCtx, &cmd.SubscribeToTag{Tag: addMobile.Result, Channels: enum.NotificationChannelWeb})
+	Expect(err).IsNil()
+
+	iosPost := &cmd.AddNewPost{Title: "Dark mode on iOS", Description: "Please"}
+	betaPost := &cmd.AddNewPost{Title: "Crash on the beta", Description: "Please"}
+	err = bus.Dispatch(aryaStarkCtx, iosPost, betaPost)
+	Expect(err).IsNil()
+
+	assignIOS := &cmd.AssignTag{Tag: addIOS.Result, Post: iosPost.Result}
+	err = bus.Dispatch(jonSnowCtx, assignIOS, &cmd.AssignTag{Tag: addBeta.Result, Post: betaPost.Result})
+	Expect(err).IsNil()
+	Expect(assignIOS.Result).IsTrue()
+
+	// Following a parent tag includes its public children
+	iosSubscribers := &query.GetActiveSubscribers{Number: iosPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewPost}
+	betaSubscribers := &query.GetActiveSubscribers{Number: betaPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewPost}
+	err = bus.Dispatch(aryaStarkCtx, iosSubscribers, betaSubscribers)
+	Expect(err).IsNil()
+	Expect(iosSubscribers.Result).HasLen(2)
+	Expect(iosSubscribers.Result[1].ID).Equals(sansaStark.ID)
+	Expect(betaSubscribers.Result).HasLen(1)
+	Expect(betaSubscribers.Result[0].ID).Equals(jonSnow.ID)
+
+	iosFollowers := &query.GetTagFollowers{Post: iosPost.Result, Tag: addIOS.Result, Channel: enum.NotificationChannelWeb}
+	betaFollowers := &query.GetTagFollowers{Post: betaPost.Result, Tag: addBeta.Result, Channel: enum.NotificationChannelWeb}
+	emailFollowers := &query.GetTagFollowers{Post: iosPost.Result, Tag: addIOS.Result, Channel: enum.NotificationChannelEmail}
+	err = bus.Dispatch(jonSnowCtx, iosFollowers, betaFollowers, emailFollowers)
+	Expect(err).IsNil()
+	Expect(iosFollowers.Result).HasLen(1)
+	Expect(iosFollowers.Result[0].ID).Equals(sansaStark.ID)
+	Expect(betaFollowers.Result).HasLen(0)
+	Expect(emailFollowers.Result).HasLen(0)
+
+	// Assigning the same tag again is not reported as new
+	assignIOS = &cmd.AssignTag{Tag: addIOS.Result, Post: iosPost.Result}
+	err = bus.Dispatch(jonSnowCtx, assignIOS)
+	Expect(err).IsNil()
+	Expect(assignIOS.Result).IsFalse()
+}
@@ -3,15 +3,18 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/dbx"
 	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
 	"github.com/gosimple/slug"
+	"github.com/lib/pq"
 )
 
 func getTagBySlug(ctx context.Context, q *query.GetTagBySlug) error {
@@ -27,7 +30,7 @@ func getAssignedTags(ctx context.Context, q *query.GetAssignedTags) error {
 		q.Result = make([]*entity.Tag, 0)
 
 		tags, err := queryTags(trx, `
-			SELECT t.id, t.name, t.slug, t.color, t.is_public 
+			SELECT t.id, t.name, t.slug, t.color, t.is_public, t.group_id, t.parent_id
 			FROM tags t
 			INNER JOIN post_tags pt
 			ON pt.tag_id = t.id
@@ -55,7 +58,7 @@ func getAllTags(ctx context.Context, q *query.GetAllTags) error {
 		}
 
 		query := fmt.Sprintf(`
-			SELECT t.id, t.name, t.slug, t.color, t.is_public 
+			SELECT t.id, t.name, t.slug, t.color, t.is_public, t.group_id, t.parent_id
 			FROM tags t
 			WHERE t.tenant_id = $1 %s
 			ORDER BY t.name
@@ -76,13 +79,19 @@ func addNewTag(ctx context.Context, c *cmd.AddNewTag) error {
 		newSlug := slug.Make(c.Name)
 
 		_, err := trx.Execute(`
-			INSERT INTO tags (name, slug, color, is_public, created_at, tenant_id) 
-			VALUES ($1, $2, $3, $4, $5, $6) RETURNING id
-		`, c.Name, newSlug, c.Color, c.IsPublic, time.Now(), tenant.ID)
+			INSERT INTO tags (name, slug, color, is_public, created_at, tenant_id, group_id, parent_id) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id
+		`, c.Name, newSlug, c.Color, c.IsPublic, time.Now(), tenant.ID, nullableID(c.GroupID), nullableID(c.ParentID))
 		if err != nil {
 			return errors.Wrap(err, "failed to add new tag")
 		}
 
+		// A tag that takes an old slug takes over its redirect too
+		_, err = trx.Execute(`DELETE FROM tag_slug_redirects WHERE old_slug = $1 AND tenant_id = $2`, newSlug, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove tag slug redirect")
+		}
+
 		tag, err := queryTagBySlug(trx, tenant, newSlug)
 		c.Result = tag
 		return err
@@ -94,12 +103,33 @@ func updateTag(ctx context.Context, c *cmd.UpdateTag) error {
 		c.Result = nil
 		newSlug := slug.Make(c.Name)
 
-		_, err := trx.Execute(`UPDATE tags SET name = $1, slug = $2, color = $3, is_public = $4
-													 WHERE id = $5 AND tenant_id = $6`, c.Name, newSlug, c.Color, c.IsPublic, c.TagID, tenant.ID)
+		var oldSlug string
+		err := trx.Scalar(&oldSlug, "SELECT slug FROM tags WHERE id = $1 AND tenant_id = $2", c.TagID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get current slug of tag")
+		}
+
+		_, err = trx.Execute(`UPDATE tags SET name = $1, slug = $2, color = $3, is_public = $4, group_id = $7, parent_id = $8
+													 WHERE id = $5 AND tenant_id = $6`, c.Name, newSlug, c.Color, c.IsPublic, c.TagID, tenant.ID, nullableID(c.GroupID), nullableID(c.ParentID))
 		if err != nil {
 			return errors.Wrap(err, "failed to update tag")
 		}
 
+		if oldSlug != newSlug {
+			_, err = trx.Execute(`DELETE FROM tag_slug_redirects WHERE old_slug = $1 AND tenant_id = $2`, newSlug, tenant.ID)
+			if err != nil {
+				return errors.Wrap(err, "failed to remove tag slug redirect")
+			}
+
+			if err := addTagSlugRedirect(trx, tenant, oldSlug, c.TagID); err != nil {
+				return err
+			}
+
+			if err := replaceBoardDefaultTag(trx, tenant, oldSlug, newSlug); err != nil {
+				return err
+			}
+		}
+
 		tag, err := queryTagBySlug(trx, tenant, newSlug)
 		c.Result = tag
 		return err
@@ -121,8 +151,131 @@ func deleteTag(ctx context.Context, c *cmd.DeleteTag) error {
 	})
 }
 
+func mergeTags(ctx context.Context, c *cmd.MergeTags) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			INSERT INTO post_tags (tag_id, post_id, created_at, created_by_id, tenant_id)
+			SELECT $2, pt.post_id, pt.created_at, pt.created_by_id, pt.tenant_id
+			FROM post_tags pt
+			WHERE pt.tag_id = $1 AND pt.tenant_id = $3
+			AND NOT EXISTS (SELECT 1 FROM post_tags x WHERE x.post_id = pt.post_id AND x.tag_id = $2 AND x.tenant_id = $3)
+		`, c.From.ID, c.Into.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to reassign posts of tag with id '%d'", c.From.ID)
+		}
+
+		_, err = trx.Execute(`DELETE FROM post_tags WHERE tag_id = $1 AND tenant_id = $2`, c.From.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove tag with id '%d' from all posts", c.From.ID)
+		}
+
+		_, err = trx.Execute(`UPDATE tags SET parent_id = $2 WHERE parent_id = $1 AND tenant_id = $3`, c.From.ID, c.Into.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to move children of tag with id '%d'", c.From.ID)
+		}
+
+		// Followers of the merged tag follow the target, unless they already do
+		_, err = trx.Execute(`
+			UPDATE tag_subscribers ts SET tag_id = $2
+			WHERE ts.tag_id = $1 AND ts.tenant_id = $3
+			AND NOT EXISTS (SELECT 1 FROM tag_subscribers x WHERE x.user_id = ts.user_id AND x.tag_id = $2)
+		`, c.From.ID, c.Into.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to move subscribers of tag with id '%d'", c.From.ID)
+		}
+
+		_, err = trx.Execute(`UPDATE tag_slug_redirects SET tag_id = $2 WHERE tag_id = $1 AND tenant_id = $3`, c.From.ID, c.Into.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to move slug redirects of tag with id '%d'", c.From.ID)
+		}
+
+		if err := addTagSlugRedirect(trx, tenant, c.From.Slug, c.Into.ID); err != nil {
+			return err
+		}
+
+		if err := replaceBoardDefaultTag(trx, tenant, c.From.Slug, c.Into.Slug); err != nil {
+			return err
+		}
+
+		_, err = trx.Execute(`DELETE FROM tags WHERE id = $1 AND tenant_id = $2`, c.From.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete tag with id '%d'", c.From.ID)
+		}
+		return nil
+	})
+}
+
+func getTagSlugRedirects(ctx context.Context, q *query.GetTagSlugRedirects) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make(map[string]string)
+		if len(q.Slugs) == 0 {
+			return nil
+		}
+
+		type redirect struct {
+			OldSlug string `db:"old_slug"`
+			Slug    string `db:"slug"`
+		}
+
+		// Private tags are only redirected to for staff
+		redirects := []*redirect{}
+		err := trx.Select(&redirects, `
+			SELECT r.old_slug, t.slug
+			FROM tag_slug_redirects r
+			INNER JOIN tags t ON t.id = r.tag_id AND t.tenant_id = r.tenant_id
+			WHERE r.tenant_id = $1 AND r.old_slug = ANY($2)
+			AND (t.is_public = true OR $3 = true)
+		`, tenant.ID, pq.Array(q.Slugs), user != nil && user.IsCollaborator())
+		if err != nil {
+			return errors.Wrap(err, "failed to get tag slug redirects")
+		}
+
+		for _, r := range redirects {
+			q.Result[r.OldSlug] = r.Slug
+		}
+		return nil
+	})
+}
+
+func getTagStats(ctx context.Context, q *query.GetTagStats) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		stats := []*dbEntities.TagStats{}
+		err := trx.Select(&stats, `
+			WITH tagged AS (
+				SELECT pt.tag_id, pt.created_at, p.status,
+				(SELECT COALESCE(SUM(pv.weight), 0) FROM post_votes pv WHERE pv.post_id = p.id AND pv.tenant_id = p.tenant_id) AS votes
+				FROM post_tags pt
+				INNER JOIN posts p ON p.id = pt.post_id AND p.tenant_id = pt.tenant_id
+				WHERE pt.tenant_id = $1 AND p.status <> $2
+			)
+			SELECT t.id AS tag_id, t.name AS tag_name, t.slug AS tag_slug, t.color AS tag_color, t.is_public AS tag_is_public,
+			t.group_id AS tag_group_id, t.parent_id AS tag_parent_id,
+			COUNT(tagged.tag_id) AS post_count,
+			COALESCE(SUM(tagged.votes), 0) AS votes_count,
+			COUNT(tagged.tag_id) FILTER (WHERE tagged.status IN ($3, $4, $5)) AS open_count,
+			COUNT(tagged.tag_id) FILTER (WHERE tagged.status = $6) AS completed_count,
+			MAX(tagged.created_at) AS last_used_at
+			FROM tags t
+			LEFT JOIN tagged ON tagged.tag_id = t.id
+			WHERE t.tenant_id = $1
+			GROUP BY t.id
+			ORDER BY post_count DESC, t.name
+		`, tenant.ID, enum.PostDeleted, enum.PostOpen, enum.PostPlanned, enum.PostStarted, enum.PostCompleted)
+		if err != nil {
+			return errors.Wrap(err, "failed to get tag stats")
+		}
+
+		q.Result = make([]*entity.TagStats, len(stats))
+		for i, s := range stats {
+			q.Result[i] = s.ToModel()
+		}
+		return nil
+	})
+}
+
 func assignTag(ctx context.Context, c *cmd.AssignTag) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		c.Result = false
 		alreadyAssigned, err := trx.Exists("SELECT 1 FROM post_tags WHERE post_id = $1 AND tag_id = $2 AND tenant_id = $3", c.Post.ID, c.Tag.ID, tenant.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to check if tag is already assigned")
@@ -132,14 +285,34 @@ func assignTag(ctx context.Context, c *cmd.AssignTag) error {
 			return nil
 		}
 
+		// Tags of a single-select group replace each other
+		_, err = trx.Execute(`
+			DELETE FROM post_tags pt
+			USING tags t, tag_groups g
+			WHERE pt.tag_id = t.id AND pt.tenant_id = t.tenant_id
+			AND g.id = t.group_id AND g.tenant_id = t.tenant_id AND g.is_single_select = true
+			AND t.group_id = (SELECT group_id FROM tags WHERE id = $2 AND tenant_id = $3)
+			AND pt.post_id = $1 AND pt.tenant_id = $3 AND pt.tag_id <> $2
+		`, c.Post.ID, c.Tag.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to unassign other tags of single-select group")
+		}
+
+		var userID any
+		if user != nil {
+			userID = user.ID
+		}
+
 		_, err = trx.Execute(
 			`INSERT INTO post_tags (tag_id, post_id, created_at, created_by_id, tenant_id) VALUES ($1, $2, $3, $4, $5)`,
-			c.Tag.ID, c.Post.ID, time.Now(), user.ID, tenant.ID,
+			c.Tag.ID, c.Post.ID, time.Now(), userID, tenant.ID,
 		)
 
 		if err != nil {
 			return errors.Wrap(err, "failed to assign tag to post")
 		}
+
+		c.Result = true
 		return nil
 	})
 }
@@ -158,10 +331,189 @@ func unassignTag(ctx context.Context, c *cmd.UnassignTag) error {
 	})
 }
 
+func getTagGroupBySlug(ctx context.Context, q *query.GetTagGroupBySlug) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		group, err := queryTagGroupBySlug(trx, tenant, q.Slug)
+		q.Result = group
+		return err
+	})
+}
+
+func getAllTagGroups(ctx context.Context, q *query.GetAllTagGroups) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		groups := []*dbEntities.TagGroup{}
+		err := trx.Select(&groups, `
+			SELECT id, name, slug, is_single_select
+			FROM tag_groups
+			WHERE tenant_id = $1
+			ORDER BY name
+		`, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get all tag groups")
+		}
+
+		q.Result = make([]*entity.TagGroup, len(groups))
+		for i, group := range groups {
+			q.Result[i] = group.ToModel()
+		}
+		return nil
+	})
+}
+
+func countPostsWithManyGroupTags(ctx context.Context, q *query.CountPostsWithManyGroupTags) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		err := trx.Scalar(&q.Result, `
+			SELECT COUNT(*) FROM (
+				SELECT pt.post_id
+				FROM post_tags pt
+				INNER JOIN tags t
+				ON t.id = pt.tag_id
+				AND t.tenant_id = pt.tenant_id
+				WHERE pt.tenant_id = $1 AND (t.group_id = $2 OR t.id = $3)
+				GROUP BY pt.post_id
+				HAVING COUNT(DISTINCT CASE WHEN t.id = $3 AND $4 > 0 THEN $4 ELSE t.id END) > 1
+			) AS q
+		`, tenant.ID, q.GroupID, q.TagID, q.MergedIntoID)
+		if err != nil {
+			return errors.Wrap(err, "failed to count posts with many tags of group with id '%d'", q.GroupID)
+		}
+		return nil
+	})
+}
+
+func addNewTagGroup(ctx context.Context, c *cmd.AddNewTagGroup) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		newSlug := slug.Make(c.Name)
+
+		_, err := trx.Execute(`
+			INSERT INTO tag_groups (name, slug, is_single_select, created_at, tenant_id)
+			VALUES ($1, $2, $3, $4, $5)
+		`, c.Name, newSlug, c.IsSingleSelect, time.Now(), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to add new tag group")
+		}
+
+		group, err := queryTagGroupBySlug(trx, tenant, newSlug)
+		c.Result = group
+		return err
+	})
+}
+
+func updateTagGroup(ctx context.Context, c *cmd.UpdateTagGroup) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		newSlug := slug.Make(c.Name)
+
+		_, err := trx.Execute(`
+			UPDATE tag_groups SET name = $1, slug = $2, is_single_select = $3
+			WHERE id = $4 AND tenant_id = $5
+		`, c.Name, newSlug, c.IsSingleSelect, c.GroupID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to update tag group")
+		}
+
+		group, err := queryTagGroupBySlug(trx, tenant, newSlug)
+		c.Result = group
+		return err
+	})
+}
+
+func deleteTagGroup(ctx context.Context, c *cmd.DeleteTagGroup) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`UPDATE tags SET group_id = NULL WHERE group_id = $1 AND tenant_id = $2`, c.Group.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove tags from group with id '%d'", c.Group.ID)
+		}
+
+		_, err = trx.Execute(`DELETE FROM tag_groups WHERE id = $1 AND tenant_id = $2`, c.Group.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete tag group with id '%d'", c.Group.ID)
+		}
+		return nil
+	})
+}
+
+// expandTagSlugs returns given tag slugs along with the slugs of all their descendants.
+// Private tags are neither expanded nor added when onlyPublic is set
+func expandTagSlugs(trx *dbx.Trx, tenant *entity.Tenant, slugs []string, onlyPublic bool) ([]string, error) {
+	var descendants []string
+	err := trx.Scalar(pq.Array(&descendants), `
+		WITH RECURSIVE tree AS (
+			SELECT id, slug FROM tags WHERE tenant_id = $1 AND ($3 = false OR is_public = true) AND (
+				slug = ANY($2) OR id IN (SELECT tag_id FROM tag_slug_redirects WHERE tenant_id = $1 AND old_slug = ANY($2))
+			)
+			UNION
+			SELECT t.id, t.slug FROM tags t
+			INNER JOIN tree ON t.parent_id = tree.id
+			WHERE t.tenant_id = $1 AND ($3 = false OR t.is_public = true)
+		)
+		SELECT COALESCE(ARRAY_AGG(slug), '{}') FROM tree
+	`, tenant.ID, pq.Array(slugs), onlyPublic)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get child tags")
+	}
+
+	result := append([]string{}, slugs...)
+	for _, s := range descendants {
+		if !slices.Contains(result, s) {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func queryTagGroupBySlug(trx *dbx.Trx, tenant *entity.Tenant, slug string) (*entity.TagGroup, error) {
+	group := dbEntities.TagGroup{}
+
+	err := trx.Get(&group, "SELECT id, name, slug, is_single_select FROM tag_groups WHERE tenant_id = $1 AND slug = $2", tenant.ID, slug)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tag group with slug '%s'", slug)
+	}
+
+	return group.ToModel(), nil
+}
+
+func addTagSlugRedirect(trx *dbx.Trx, tenant *entity.Tenant, oldSlug string, tagID int) error {
+	_, err := trx.Execute(`
+		INSERT INTO tag_slug_redirects (tenant_id, old_slug, tag_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, old_slug) DO UPDATE SET tag_id = $3
+	`, tenant.ID, oldSlug, tagID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to add redirect for tag slug '%s'", oldSlug)
+	}
+	return nil
+}
+
+// replaceBoardDefaultTag keeps board default tags pointing to the current slug
+func replaceBoardDefaultTag(trx *dbx.Trx, tenant *entity.Tenant, oldSlug, newSlug string) error {
+	_, err := trx.Execute(`
+		UPDATE boards SET default_tags = ARRAY(SELECT DISTINCT unnest(array_replace(default_tags, $1, $2)))
+		WHERE tenant_id = $3 AND $1 = ANY(default_tags)
+	`, oldSlug, newSlug, tenant.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to replace default tag '%s' of boards", oldSlug)
+	}
+	return nil
+}
+
+// nullableID stores zero as NULL on optional foreign keys
+func nullableID(id int) any {
+	if id > 0 {
+		return id
+	}
+	return nil
+}
+
 func queryTagBySlug(trx *dbx.Trx, tenant *entity.Tenant, slug string) (*entity.Tag, error) {
 	tag := dbEntities.Tag{}
 
-	err := trx.Get(&tag, "SELECT id, name, slug, color, is_public FROM tags WHERE tenant_id = $1 AND slug = $2", tenant.ID, slug)
+	// Old slugs of renamed or merged tags aren't matched here, so that changes never act on another tag.
+	// Pages and filters resolve them with GetTagSlugRedirects instead
+	err := trx.Get(&tag, `
+		SELECT id, name, slug, color, is_public, group_id, parent_id
+		FROM tags
+		WHERE tenant_id = $1 AND slug = $2
+	`, tenant.ID, slug)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get tag with slug '%s'", slug)
 	}
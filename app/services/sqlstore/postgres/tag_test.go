@@ -6,6 +6,7 @@ import (
 	"github.com/getfider/fider/app/models/query"
 
 	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/enum"
 
 	"github.com/getfider/fider/app"
 	. "github.com/getfider/fider/app/pkg/assert"
@@ -160,3 +161,343 @@ func TestTagStorage_GetAll(t *testing.T) {
 	Expect(getAllTags.Result).HasLen(1)
 	Expect(getAllTags.Result[0].Name).Equals("Feature Request")
 }
+
+func TestTagStorage_TagGroups(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addGroup := &cmd.AddNewTagGroup{Name: "Platform", IsSingleSelect: true}
+	err := bus.Dispatch(demoTenantCtx, addGroup)
+	Expect(err).IsNil()
+	Expect(addGroup.Result.Slug).Equals("platform")
+	Expect(addGroup.Result.IsSingleSelect).IsTrue()
+
+	addTag := &cmd.AddNewTag{Name: "iOS", Color: "FF0000", IsPublic: true, GroupID: addGroup.Result.ID}
+	err = bus.Dispatch(demoTenantCtx, addTag)
+	Expect(err).IsNil()
+	Expect(addTag.Result.GroupID).Equals(addGroup.Result.ID)
+
+	updateGroup := &cmd.UpdateTagGroup{GroupID: addGroup.Result.ID, Name: "Platforms", IsSingleSelect: false}
+	err = bus.Dispatch(demoTenantCtx, updateGroup)
+	Expect(err).IsNil()
+	Expect(updateGroup.Result.Slug).Equals("platforms")
+	Expect(updateGroup.Result.IsSingleSelect).IsFalse()
+
+	getAllGroups := &query.GetAllTagGroups{}
+	err = bus.Dispatch(demoTenantCtx, getAllGroups)
+	Expect(err).IsNil()
+	Expect(getAllGroups.Result).HasLen(1)
+	Expect(getAllGroups.Result[0].Name).Equals("Platforms")
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.DeleteTagGroup{Group: updateGroup.Result})
+	Expect(err).IsNil()
+
+	getTag := &query.GetTagBySlug{Slug: "ios"}
+	err = bus.Dispatch(demoTenantCtx, getTag)
+	Expect(err).IsNil()
+	Expect(getTag.Result.GroupID).Equals(0)
+
+	getGroup := &query.GetTagGroupBySlug{Slug: "platforms"}
+	err = bus.Dispatch(demoTenantCtx, getGroup)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestTagStorage_Assign_SingleSelectGroup(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(aryaStarkCtx, newPost)
+	Expect(err).IsNil()
+
+	addGroup := &cmd.AddNewTagGroup{Name: "Platform", IsSingleSelect: true}
+	err = bus.Dispatch(jonSnowCtx, addGroup)
+	Expect(err).IsNil()
+
+	addIOS := &cmd.AddNewTag{Name: "iOS", Color: "FF0000", IsPublic: true, GroupID: addGroup.Result.ID}
+	addAndroid := &cmd.AddNewTag{Name: "Android", Color: "00FF00", IsPublic: true, GroupID: addGroup.Result.ID}
+	addBug := &cmd.AddNewTag{Name: "Bug", Color: "0000FF", IsPublic: true}
+	err = bus.Dispatch(jonSnowCtx, addIOS, addAndroid, addBug)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: addIOS.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: addBug.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: addAndroid.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+
+	assignedTags := &query.GetAssignedTags{Post: newPost.Result}
+	err = bus.Dispatch(jonSnowCtx, assignedTags)
+	Expect(err).IsNil()
+	Expect(assignedTags.Result).HasLen(2)
+	Expect(assignedTags.Result[0].Slug).Equals("android")
+	Expect(assignedTags.Result[1].Slug).Equals("bug")
+}
+
+func TestTagStorage_SearchByParentTag(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addMobile := &cmd.AddNewTag{Name: "Mobile", Color: "FF0000", IsPublic: true}
+	err := bus.Dispatch(jonSnowCtx, addMobile)
+	Expect(err).IsNil()
+
+	addIOS := &cmd.AddNewTag{Name: "iOS", Color: "FF0000", IsPublic: true, ParentID: addMobile.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, addIOS)
+	Expect(err).IsNil()
+	Expect(addIOS.Result.ParentID).Equals(addMobile.Result.ID)
+
+	addIPad := &cmd.AddNewTag{Name: "iPad", Color: "FF0000", IsPublic: true, ParentID: addIOS.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, addIPad)
+	Expect(err).IsNil()
+
+	iosPost := &cmd.AddNewPost{Title: "Support dark mode on iOS", Description: "please"}
+	ipadPost := &cmd.AddNewPost{Title: "Support split view on iPad", Description: "please"}
+	otherPost := &cmd.AddNewPost{Title: "Support dark mode on desktop", Description: "please"}
+	err = bus.Dispatch(aryaStarkCtx, iosPost, ipadPost, otherPost)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.AssignTag{Tag: addIOS.Result, Post: iosPost.Result},
+		&cmd.AssignTag{Tag: addIPad.Result, Post: ipadPost.Result},
+	)
+	Expect(err).IsNil()
+
+	searchMobile := &query.SearchPosts{View: "all", Tags: []string{"mobile"}}
+	searchIOS := &query.SearchPosts{View: "all", Tags: []string{"ios"}}
+	searchIPad := &query.SearchPosts{View: "all", Tags: []string{"ipad"}}
+	err = bus.Dispatch(jonSnowCtx, searchMobile, searchIOS, searchIPad)
+	Expect(err).IsNil()
+	Expect(searchMobile.Result).HasLen(2)
+	Expect(searchIOS.Result).HasLen(2)
+	Expect(searchIPad.Result).HasLen(1)
+	Expect(searchIPad.Result[0].ID).Equals(ipadPost.Result.ID)
+}
+
+func TestTagStorage_SearchByParentTag_PrivateChildren(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addMobile := &cmd.AddNewTag{Name: "Mobile", Color: "FF0000", IsPublic: true}
+	err := bus.Dispatch(jonSnowCtx, addMobile)
+	Expect(err).IsNil()
+
+	addIOS := &cmd.AddNewTag{Name: "iOS", Color: "FF0000", IsPublic: true, ParentID: addMobile.Result.ID}
+	addBeta := &cmd.AddNewTag{Name: "Beta", Color: "FF0000", IsPublic: false, ParentID: addMobile.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, addIOS, addBeta)
+	Expect(err).IsNil()
+
+	iosPost := &cmd.AddNewPost{Title: "Support dark mode on iOS", Description: "please"}
+	betaPost := &cmd.AddNewPost{Title: "Crash on the beta build", Description: "please"}
+	err = bus.Dispatch(aryaStarkCtx, iosPost, betaPost)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.AssignTag{Tag: addIOS.Result, Post: iosPost.Result},
+		&cmd.AssignTag{Tag: addBeta.Result, Post: betaPost.Result},
+	)
+	Expect(err).IsNil()
+
+	staffSearch := &query.SearchPosts{View: "all", Tags: []string{"mobile"}}
+	err = bus.Dispatch(jonSnowCtx, staffSearch)
+	Expect(err).IsNil()
+	Expect(staffSearch.Result).HasLen(2)
+
+	visitorSearch := &query.SearchPosts{View: "all", Tags: []string{"mobile"}}
+	err = bus.Dispatch(aryaStarkCtx, visitorSearch)
+	Expect(err).IsNil()
+	Expect(visitorSearch.Result).HasLen(1)
+	Expect(visitorSearch.Result[0].ID).Equals(iosPost.Result.ID)
+}
+
+func TestTagStorage_CountPostsWithManyGroupTags(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addGroup := &cmd.AddNewTagGroup{Name: "Platform"}
+	err := bus.Dispatch(jonSnowCtx, addGroup)
+	Expect(err).IsNil()
+
+	addIOS := &cmd.AddNewTag{Name: "iOS", Color: "FF0000", IsPublic: true, GroupID: addGroup.Result.ID}
+	addAndroid := &cmd.AddNewTag{Name: "Android", Color: "FF0000", IsPublic: true, GroupID: addGroup.Result.ID}
+	addWeb := &cmd.AddNewTag{Name: "Web", Color: "FF0000", IsPublic: true}
+	err = bus.Dispatch(jonSnowCtx, addIOS, addAndroid, addWeb)
+	Expect(err).IsNil()
+
+	post1 := &cmd.AddNewPost{Title: "Support dark mode everywhere", Description: "please"}
+	post2 := &cmd.AddNewPost{Title: "Support offline mode", Description: "please"}
+	err = bus.Dispatch(aryaStarkCtx, post1, post2)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.AssignTag{Tag: addIOS.Result, Post: post1.Result},
+		&cmd.AssignTag{Tag: addAndroid.Result, Post: post1.Result},
+		&cmd.AssignTag{Tag: addIOS.Result, Post: post2.Result},
+		&cmd.AssignTag{Tag: addWeb.Result, Post: post2.Result},
+	)
+	Expect(err).IsNil()
+
+	countGroup := &query.CountPostsWithManyGroupTags{GroupID: addGroup.Result.ID}
+	countWithWeb := &query.CountPostsWithManyGroupTags{GroupID: addGroup.Result.ID, TagID: addWeb.Result.ID}
+	countWebIntoIOS := &query.CountPostsWithManyGroupTags{GroupID: addGroup.Result.ID, TagID: addWeb.Result.ID, MergedIntoID: addIOS.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, countGroup, countWithWeb, countWebIntoIOS)
+	Expect(err).IsNil()
+	Expect(countGroup.Result).Equals(1)
+	Expect(countWithWeb.Result).Equals(2)
+	Expect(countWebIntoIOS.Result).Equals(1)
+}
+
+func TestTagStorage_Rename_RedirectsOldSlug(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addNewTag := &cmd.AddNewTag{Name: "Mobile", Color: "FF0000", IsPublic: true}
+	err := bus.Dispatch(jonSnowCtx, addNewTag)
+	Expect(err).IsNil()
+
+	newPost := &cmd.AddNewPost{Title: "Support dark mode on phones", Description: "please"}
+	err = bus.Dispatch(aryaStarkCtx, newPost)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: addNewTag.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+
+	updateTag := &cmd.UpdateTag{TagID: addNewTag.Result.ID, Name: "Mobile App", Color: "FF0000", IsPublic: true}
+	err = bus.Dispatch(jonSnowCtx, updateTag)
+	Expect(err).IsNil()
+	Expect(updateTag.Result.Slug).Equals("mobile-app")
+
+	getRedirects := &query.GetTagSlugRedirects{Slugs: []string{"mobile", "unknown"}}
+	search := &query.SearchPosts{View: "all", Tags: []string{"mobile"}}
+	err = bus.Dispatch(jonSnowCtx, getRedirects, search)
+	Expect(err).IsNil()
+	Expect(getRedirects.Result).Equals(map[string]string{"mobile": "mobile-app"})
+	Expect(search.Result).HasLen(1)
+
+	// Changes given the old slug don't act on the renamed tag
+	err = bus.Dispatch(jonSnowCtx, &query.GetTagBySlug{Slug: "mobile"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	// A new tag takes over the old slug
+	addMobile := &cmd.AddNewTag{Name: "Mobile", Color: "00FF00", IsPublic: true}
+	err = bus.Dispatch(jonSnowCtx, addMobile)
+	Expect(err).IsNil()
+
+	getTag := &query.GetTagBySlug{Slug: "mobile"}
+	getRedirects = &query.GetTagSlugRedirects{Slugs: []string{"mobile"}}
+	err = bus.Dispatch(jonSnowCtx, getTag, getRedirects)
+	Expect(err).IsNil()
+	Expect(getTag.Result.ID).Equals(addMobile.Result.ID)
+	Expect(getRedirects.Result).HasLen(0)
+}
+
+func TestTagStorage_MergeTags(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addMobile := &cmd.AddNewTag{Name: "Mobile", Color: "FF0000", IsPublic: true}
+	addMobileApp := &cmd.AddNewTag{Name: "Mobile App", Color: "00FF00", IsPublic: true}
+	err := bus.Dispatch(jonSnowCtx, addMobile, addMobileApp)
+	Expect(err).IsNil()
+
+	addAndroid := &cmd.AddNewTag{Name: "Android", Color: "0000FF", IsPublic: true, ParentID: addMobile.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, addAndroid)
+	Expect(err).IsNil()
+
+	post1 := &cmd.AddNewPost{Title: "Support dark mode on phones", Description: "please"}
+	post2 := &cmd.AddNewPost{Title: "Support offline mode on phones", Description: "please"}
+	err = bus.Dispatch(aryaStarkCtx, post1, post2)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.AssignTag{Tag: addMobile.Result, Post: post1.Result},
+		&cmd.AssignTag{Tag: addMobile.Result, Post: post2.Result},
+		&cmd.AssignTag{Tag: addMobileApp.Result, Post: post2.Result},
+	)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.MergeTags{From: addMobile.Result, Into: addMobileApp.Result})
+	Expect(err).IsNil()
+
+	assigned1 := &query.GetAssignedTags{Post: post1.Result}
+	assigned2 := &query.GetAssignedTags{Post: post2.Result}
+	getRedirects := &query.GetTagSlugRedirects{Slugs: []string{"mobile"}}
+	getAndroid := &query.GetTagBySlug{Slug: "android"}
+	getAllTags := &query.GetAllTags{}
+	err = bus.Dispatch(jonSnowCtx, assigned1, assigned2, getRedirects, getAndroid, getAllTags)
+	Expect(err).IsNil()
+	Expect(assigned1.Result).HasLen(1)
+	Expect(assigned1.Result[0].ID).Equals(addMobileApp.Result.ID)
+	Expect(assigned2.Result).HasLen(1)
+	Expect(assigned2.Result[0].ID).Equals(addMobileApp.Result.ID)
+	Expect(getRedirects.Result).Equals(map[string]string{"mobile": "mobile-app"})
+	Expect(getAndroid.Result.ParentID).Equals(addMobileApp.Result.ID)
+	Expect(getAllTags.Result).HasLen(2)
+}
+
+func TestTagStorage_GetTagStats(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addBug := &cmd.AddNewTag{Name: "Bug", Color: "FF0000", IsPublic: true}
+	addUnused := &cmd.AddNewTag{Name: "Unused", Color: "00FF00", IsPublic: false}
+	err := bus.Dispatch(jonSnowCtx, addBug, addUnused)
+	Expect(err).IsNil()
+
+	post1 := &cmd.AddNewPost{Title: "App crashes on login", Description: "please fix"}
+	post2 := &cmd.AddNewPost{Title: "App crashes on logout", Description: "please fix"}
+	err = bus.Dispatch(aryaStarkCtx, post1, post2)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx,
+		&cmd.AssignTag{Tag: addBug.Result, Post: post1.Result},
+		&cmd.AssignTag{Tag: addBug.Result, Post: post2.Result},
+		&cmd.AddVote{Post: post1.Result, User: aryaStark},
+		&cmd.AddVote{Post: post1.Result, User: jonSnow},
+		&cmd.SetPostResponse{Post: post2.Result, Text: "Fixed", Status: enum.PostCompleted},
+	)
+	Expect(err).IsNil()
+
+	getStats := &query.GetTagStats{}
+	err = bus.Dispatch(jonSnowCtx, getStats)
+	Expect(err).IsNil()
+	Expect(getStats.Result).HasLen(2)
+
+	bug := getStats.Result[0]
+	Expect(bug.Tag.ID).Equals(addBug.Result.ID)
+	Expect(bug.PostCount).Equals(2)
+	Expect(bug.VotesCount).Equals(2)
+	Expect(bug.OpenCount).Equals(1)
+	Expect(bug.CompletedCount).Equals(1)
+	Expect(bug.CompletedRatio).Equals(0.5)
+	Expect(bug.LastUsedAt).IsNotNil()
+
+	unused := getStats.Result[1]
+	Expect(unused.Tag.ID).Equals(addUnused.Result.ID)
+	Expect(unused.PostCount).Equals(0)
+	Expect(unused.CompletedRatio).Equals(0.0)
+	Expect(unused.LastUsedAt).IsNil()
+}
+
+func TestTagStorage_GetTagSlugRedirects_PrivateTag(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addNewTag := &cmd.AddNewTag{Name: "Escalated", Color: "FF0000", IsPublic: false}
+	err := bus.Dispatch(jonSnowCtx, addNewTag)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.UpdateTag{TagID: addNewTag.Result.ID, Name: "Escalated to Legal", Color: "FF0000", IsPublic: false})
+	Expect(err).IsNil()
+
+	staffRedirects := &query.GetTagSlugRedirects{Slugs: []string{"escalated"}}
+	err = bus.Dispatch(jonSnowCtx, staffRedirects)
+	Expect(err).IsNil()
+	Expect(staffRedirects.Result).Equals(map[string]string{"escalated": "escalated-to-legal"})
+
+	visitorRedirects := &query.GetTagSlugRedirects{Slugs: []string{"escalated"}}
+	err = bus.Dispatch(aryaStarkCtx, visitorRedirects)
+	Expect(err).IsNil()
+	Expect(visitorRedirects.Result).HasLen(0)
+}
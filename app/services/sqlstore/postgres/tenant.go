@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/getfider/fider/app/pkg/bus"
@@ -60,6 +61,51 @@ func updateTenantPrivacySettings(ctx context.Context, c *cmd.UpdateTenantPrivacy
 		if err != nil {
 			return errors.Wrap(err, "failed update tenant moderation setting")
 		}
+		_, err = trx.Execute("UPDATE tenants SET is_anonymous_posting_enabled = $1 WHERE id = $2", c.IsAnonymousPostingEnabled, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed update tenant anonymous posting setting")
+		}
+		return nil
+	})
+}
+
+func updateTenantVotingSettings(ctx context.Context, c *cmd.UpdateTenantVotingSettings) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		weights := c.VoteRoleWeights
+		if weights == nil {
+			weights = map[string]int{}
+		}
+		weightsJSON, err := json.Marshal(weights)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal vote role weights")
+		}
+
+		_, err = trx.Execute(
+			"UPDATE tenants SET vote_budget = $1, is_vote_importance_enabled = $2, vote_role_weights = $3 WHERE id = $4",
+			c.VoteBudget, c.IsVoteImportanceEnabled, string(weightsJSON), tenant.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed update tenant voting settings")
+		}
+		return nil
+	})
+}
+
+func updateTenantReactionSettings(ctx context.Context, c *cmd.UpdateTenantReactionSettings) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		reactions := c.Reactions
+		if len(reactions) == 0 {
+			reactions = entity.DefaultReactions
+		}
+		reactionsJSON, err := json.Marshal(reactions)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal reactions")
+		}
+
+		_, err = trx.Execute("UPDATE tenants SET reactions = $1 WHERE id = $2", string(reactionsJSON), tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed update tenant reaction settings")
+		}
 		return nil
 	})
 }
@@ -103,14 +149,15 @@ func updateTenantAdvancedSettings(ctx context.Context, c *cmd.UpdateTenantAdvanc
 			AllowedSchemes = ""
 		}
 
-		query := "UPDATE tenants SET custom_css = $1, allowed_schemes = $2 WHERE id = $3"
-		_, err := trx.Execute(query, c.CustomCSS, AllowedSchemes, tenant.ID)
+		query := "UPDATE tenants SET custom_css = $1, allowed_schemes = $2, issue_webhook_secret = $3 WHERE id = $4"
+		_, err := trx.Execute(query, c.CustomCSS, AllowedSchemes, c.IssueWebhookSecret, tenant.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed update tenant advanced settings")
 		}
 
 		tenant.CustomCSS = c.CustomCSS
 		tenant.AllowedSchemes = AllowedSchemes
+		tenant.IssueWebhookSecret = c.IssueWebhookSecret
 		return nil
 	})
 }
@@ -208,7 +255,7 @@ func getFirstTenant(ctx context.Context, q *query.GetFirstTenant) error {
 		tenant := dbEntities.Tenant{}
 
 	err := trx.Get(&tenant, `
-		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.prevent_indexing, t.is_pro,
+		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.prevent_indexing, t.is_pro, t.vote_budget, t.is_vote_importance_enabled, t.vote_role_weights, t.is_anonymous_posting_enabled, t.issue_webhook_secret, t.reactions,
 			(b.paddle_subscription_id IS NOT NULL AND b.stripe_subscription_id IS NULL) AS has_paddle_subscription
 		FROM tenants t
 		LEFT JOIN tenants_billing b ON b.tenant_id = t.id
@@ -223,12 +270,32 @@ func getFirstTenant(ctx context.Context, q *query.GetFirstTenant) error {
 	})
 }
 
+func getTenantByID(ctx context.Context, q *query.GetTenantByID) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		tenant := dbEntities.Tenant{}
+
+		err := trx.Get(&tenant, `
+		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.prevent_indexing, t.is_pro, t.vote_budget, t.is_vote_importance_enabled, t.vote_role_weights, t.is_anonymous_posting_enabled, t.issue_webhook_secret, t.reactions,
+			(b.paddle_subscription_id IS NOT NULL AND b.stripe_subscription_id IS NULL) AS has_paddle_subscription
+		FROM tenants t
+		LEFT JOIN tenants_billing b ON b.tenant_id = t.id
+		WHERE t.id = $1
+	`, q.TenantID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get tenant with id '%d'", q.TenantID)
+		}
+
+		q.Result = tenant.ToModel()
+		return nil
+	})
+}
+
 func getTenantByDomain(ctx context.Context, q *query.GetTenantByDomain) error {
 	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
 		tenant := dbEntities.Tenant{}
 
 	err := trx.Get(&tenant, `
-		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.prevent_indexing, t.is_pro,
+		SELECT t.id, t.name, t.subdomain, t.cname, t.invitation, t.locale, t.welcome_message, t.welcome_header, t.status, t.is_private, t.logo_bkey, t.custom_css, t.allowed_schemes, t.is_email_auth_allowed, t.is_feed_enabled, t.is_moderation_enabled, t.prevent_indexing, t.is_pro, t.vote_budget, t.is_vote_importance_enabled, t.vote_role_weights, t.is_anonymous_posting_enabled, t.issue_webhook_secret, t.reactions,
 			(b.paddle_subscription_id IS NOT NULL AND b.stripe_subscription_id IS NULL) AS has_paddle_subscription
 		FROM tenants t
 		LEFT JOIN tenants_billing b ON b.tenant_id = t.id
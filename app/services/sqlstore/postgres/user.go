@@ -84,7 +84,10 @@ func deleteCurrentUser(ctx context.Context, c *cmd.DeleteCurrentUser) error {
 			{"notifications", "user_id"},
 			{"notifications", "author_id"},
 			{"post_votes", "user_id"},
+			{"post_reactions", "user_id"},
 			{"post_subscribers", "user_id"},
+			{"tag_subscribers", "user_id"},
+			{"post_coauthors", "user_id"},
 			{"email_verifications", "user_id"},
 		}
 
@@ -290,6 +293,17 @@ func getUserByID(ctx context.Context, q *query.GetUserByID) error {
 	})
 }
 
+func getPostAuthor(ctx context.Context, q *query.GetPostAuthor) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		u, err := queryUser(ctx, trx, "id = (SELECT user_id FROM posts WHERE id = $1 AND tenant_id = $2)", q.PostID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get author of post '%d'", q.PostID)
+		}
+		q.Result = u
+		return nil
+	})
+}
+
 func getUserByEmail(ctx context.Context, q *query.GetUserByEmail) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		email := strings.ToLower(q.Email)
@@ -347,13 +361,19 @@ func getAllUsers(ctx context.Context, q *query.GetAllUsers) error {
 
 func getAllUsersNames(ctx context.Context, q *query.GetAllUsersNames) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		condition := ""
+		if q.OnlyStaff {
+			condition = fmt.Sprintf("AND role IN (%d, %d)", enum.RoleCollaborator, enum.RoleAdministrator)
+		}
+
 		var users []*dbEntities.User
-		err := trx.Select(&users, `
+		err := trx.Select(&users, fmt.Sprintf(`
 			SELECT name
 			FROM users
 			WHERE tenant_id = $1
 			AND status = $2
-			ORDER BY id`, tenant.ID, enum.UserActive)
+			%s
+			ORDER BY id`, condition), tenant.ID, enum.UserActive)
 		if err != nil {
 			return errors.Wrap(err, "failed to get all users")
 		}
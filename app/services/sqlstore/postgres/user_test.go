@@ -2,6 +2,7 @@ package postgres_test
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
 
@@ -420,3 +421,28 @@ func TestUserStorage_BlockUser(t *testing.T) {
 	Expect(err).IsNil()
 	Expect(getUser.Result.Status).Equals(enum.UserActive)
 }
+
+func TestUserStorage_GetAllUsersNames_OnlyStaff(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	names := func(users []*dto.UserNames) []string {
+		result := make([]string, len(users))
+		for i, user := range users {
+			result[i] = user.Name
+		}
+		return result
+	}
+
+	allUsers := &query.GetAllUsersNames{}
+	err := bus.Dispatch(demoTenantCtx, allUsers)
+	Expect(err).IsNil()
+	Expect(slices.Contains(names(allUsers.Result), jonSnow.Name)).IsTrue()
+	Expect(slices.Contains(names(allUsers.Result), aryaStark.Name)).IsTrue()
+
+	staffUsers := &query.GetAllUsersNames{OnlyStaff: true}
+	err = bus.Dispatch(demoTenantCtx, staffUsers)
+	Expect(err).IsNil()
+	Expect(slices.Contains(names(staffUsers.Result), jonSnow.Name)).IsTrue()
+	Expect(slices.Contains(names(staffUsers.Result), aryaStark.Name)).IsFalse()
+}
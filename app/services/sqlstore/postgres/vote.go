@@ -7,10 +7,12 @@ import (
 
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/dbx"
 	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/services/sqlstore/dbEntities"
+	"github.com/lib/pq"
 )
 
 func addVote(ctx context.Context, c *cmd.AddVote) error {
@@ -19,15 +21,34 @@ func addVote(ctx context.Context, c *cmd.AddVote) error {
 			return nil
 		}
 
+		importance := c.Importance
+		if importance.Name() == "unknown" || !tenant.IsVoteImportanceEnabled {
+			importance = enum.VoteNiceToHave
+		}
+
+		var proxiedByID, proxyNote any
+		if c.ProxiedBy != nil {
+			proxiedByID = c.ProxiedBy.ID
+			if c.ProxyNote != "" {
+				proxyNote = c.ProxyNote
+			}
+		}
+
 		_, err := trx.Execute(
-			`INSERT INTO post_votes (tenant_id, user_id, post_id, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
-			tenant.ID, c.User.ID, c.Post.ID, time.Now(),
+			`INSERT INTO post_votes (tenant_id, user_id, post_id, created_at, importance, weight, proxied_by_id, proxy_note) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			 ON CONFLICT (user_id, post_id) DO UPDATE SET importance = $5, weight = $6`,
+			tenant.ID, c.User.ID, c.Post.ID, time.Now(), importance, tenant.VoteWeight(c.User, importance), proxiedByID, proxyNote,
 		)
 
 		if err != nil {
 			return errors.Wrap(err, "failed add vote to post")
 		}
 
+		// Customers don't visit the site to subscribe, so a vote recorded for them does it instead
+		if c.ProxiedBy != nil {
+			return internalAddSubscriber(trx, c.Post, tenant, c.User, false)
+		}
+
 		return nil
 	})
 }
@@ -47,6 +68,28 @@ func removeVote(ctx context.Context, c *cmd.RemoveVote) error {
 	})
 }
 
+func countActiveVotes(ctx context.Context, q *query.CountActiveVotes) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		// Votes on closed posts are given back to the user
+		err := trx.Scalar(&q.Result, `
+			SELECT COUNT(*)
+			FROM post_votes pv
+			INNER JOIN posts p
+			ON p.id = pv.post_id
+			AND p.tenant_id = pv.tenant_id
+			WHERE pv.user_id = $1
+			AND pv.tenant_id = $2
+			AND p.status = ANY($3)
+			AND p.id <> $4`,
+			q.UserID, tenant.ID, pq.Array([]enum.PostStatus{enum.PostOpen, enum.PostPlanned, enum.PostStarted}), q.ExcludePostID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to count active votes of user '%d'", q.UserID)
+		}
+		return nil
+	})
+}
+
 func listPostVotes(ctx context.Context, q *query.ListPostVotes) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		q.Result = make([]*entity.Vote, 0)
@@ -56,27 +99,47 @@ func listPostVotes(ctx context.Context, q *query.ListPostVotes) error {
 		}
 
 		emailColumn := "''"
+		proxyNoteColumn := "NULL"
 		if q.IncludeEmail {
 			emailColumn = "u.email"
+			proxyNoteColumn = "pv.proxy_note"
+		}
+
+		// The vote of the author would give away who wrote an anonymous post
+		viewerID := 0
+		if user != nil {
+			viewerID = user.ID
 		}
 
 		votes := []*dbEntities.Vote{}
 		err := trx.Select(&votes, `
 		SELECT 
 			pv.created_at, 
+			pv.importance,
+			pv.weight,
 			u.id AS user_id,
 			u.name AS user_name,
 			`+emailColumn+` AS user_email,
 			u.avatar_type AS user_avatar_type,
-			u.avatar_bkey AS user_avatar_bkey
+			u.avatar_bkey AS user_avatar_bkey,
+			pb.id AS proxied_by_id,
+			pb.name AS proxied_by_name,
+			`+proxyNoteColumn+` AS proxy_note
 		FROM post_votes pv
 		INNER JOIN users u
 		ON u.id = pv.user_id
 		AND u.tenant_id = pv.tenant_id 
+		LEFT JOIN users pb
+		ON pb.id = pv.proxied_by_id
+		AND pb.tenant_id = pv.tenant_id
+		INNER JOIN posts p
+		ON p.id = pv.post_id
+		AND p.tenant_id = pv.tenant_id
 		WHERE pv.post_id = $1  
 		AND pv.tenant_id = $2
+		AND (NOT p.is_anonymous OR pv.user_id <> p.user_id OR pv.user_id = $3)
 		ORDER BY pv.created_at
-		LIMIT `+sqlLimit, q.PostID, tenant.ID)
+		LIMIT `+sqlLimit, q.PostID, tenant.ID, viewerID)
 		if err != nil {
 			return errors.Wrap(err, "failed to get votes of post")
 		}
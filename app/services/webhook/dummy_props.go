@@ -39,6 +39,10 @@ You can use ` + "`" + `{{ markdown .post_description }}` + "`" + ` to parse __Ma
 		User:        nil,
 	},
 	Tags: []string{"tag1", "tag2"},
+	ReactionCounts: []entity.ReactionCounts{
+		{Emoji: "👍", Count: 5},
+		{Emoji: "🎉", Count: 2},
+	},
 }
 
 func dummyTriggerProps(c context.Context, webhookType enum.WebhookType) webhook.Props {
@@ -0,0 +1,97 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/markdown"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+// NotifyAboutAcceptedAnswer sends a notification (web and email) to subscribers with the answer of the post
+func NotifyAboutAcceptedAnswer(post *entity.Post, answer *entity.Comment) worker.Task {
+	return describe("Notify about accepted answer", func(c *worker.Context) error {
+		author := c.User()
+		// Staff accepting on an anonymous post are still named, only its author is hidden
+		isAnonymous := post.IsAnonymous && (post.User == nil || post.User.ID == author.ID)
+		displayedAuthor := author
+		if isAnonymous {
+			displayedAuthor = entity.AnonymousAuthor()
+		}
+		answerAuthor := ""
+		if answer.User != nil {
+			answerAuthor = answer.User.Name
+		}
+
+		// Web notification
+		users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventChangeStatus)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		title := fmt.Sprintf("**%s** accepted an answer on **%s**", displayedAuthor.Name, post.Title)
+		link := fmt.Sprintf("/posts/%d/%s#comment-%d", post.Number, post.Slug, answer.ID)
+		for _, user := range users {
+			if user.ID != author.ID {
+				err = bus.Dispatch(c, &cmd.AddNewNotification{
+					User:        user,
+					Title:       title,
+					Link:        link,
+					PostID:      post.ID,
+					IsAnonymous: isAnonymous,
+				})
+				if err != nil {
+					return c.Failure(err)
+				}
+			}
+		}
+
+		// Email notification
+		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventChangeStatus)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		to := make([]dto.Recipient, 0)
+		for _, user := range users {
+			if user.ID != author.ID {
+				to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+			}
+		}
+
+		if len(to) == 0 {
+			return nil
+		}
+
+		tenant := c.Tenant()
+		baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+
+		props := dto.Props{
+			"title":        post.Title,
+			"postLink":     linkWithText(fmt.Sprintf("#%d", post.Number), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"siteName":     tenant.Name,
+			"userName":     displayedAuthor.Name,
+			"answerAuthor": answerAuthor,
+			"content":      markdown.Full(entity.CommentString(answer.Content).SanitizeMentions(), false),
+			"view":         linkWithText(i18n.T(c, "email.subscription.view"), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"unsubscribe":  linkWithText(i18n.T(c, "email.subscription.unsubscribe"), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"change":       linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/settings"),
+			"logo":         logoURL,
+		}
+
+		bus.Publish(c, &cmd.SendMail{
+			From:         dto.Recipient{Name: displayedAuthor.Name},
+			To:           to,
+			TemplateName: "accepted_answer",
+			Props:        props,
+		})
+
+		return nil
+	})
+}
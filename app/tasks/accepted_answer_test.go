@@ -0,0 +1,101 @@
+package tasks_test
+
+import (
+	"context"
+	"html/template"
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/services/email/emailmock"
+	"github.com/getfider/fider/app/tasks"
+)
+
+func TestNotifyAboutAcceptedAnswerTask(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	notifications := make([]*cmd.AddNewNotification, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		notifications = append(notifications, c)
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.JonSnow, mock.AryaStark}
+		return nil
+	})
+
+	post := &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "How do I export my data?",
+		Slug:   "how-do-i-export-my-data",
+		User:   mock.AryaStark,
+	}
+	answer := &entity.Comment{ID: 5, Content: "Use the export button", User: mock.JonSnow}
+	task := tasks.NotifyAboutAcceptedAnswer(post, answer)
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(notifications).HasLen(1)
+	Expect(notifications[0].User).Equals(mock.JonSnow)
+	Expect(notifications[0].Title).Equals("**Arya Stark** accepted an answer on **How do I export my data?**")
+	Expect(notifications[0].Link).Equals("/posts/1/how-do-i-export-my-data#comment-5")
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("accepted_answer")
+	Expect(emailmock.MessageHistory[0].To).HasLen(1)
+	Expect(emailmock.MessageHistory[0].To[0].Address).Equals(mock.JonSnow.Email)
+	Expect(emailmock.MessageHistory[0].Props["answerAuthor"]).Equals("Jon Snow")
+	Expect(emailmock.MessageHistory[0].Props["content"]).Equals(template.HTML("<p>Use the export button</p>"))
+}
+
+func TestNotifyAboutAcceptedAnswerTask_AnonymousPost(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	var addNewNotification *cmd.AddNewNotification
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		addNewNotification = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.JonSnow, mock.AryaStark}
+		return nil
+	})
+
+	post := &entity.Post{
+		ID:          1,
+		Number:      1,
+		Title:       "How do I export my data?",
+		Slug:        "how-do-i-export-my-data",
+		User:        mock.AryaStark,
+		IsAnonymous: true,
+	}
+	answer := &entity.Comment{ID: 5, Content: "Use the export button", User: mock.JonSnow}
+	task := tasks.NotifyAboutAcceptedAnswer(post, answer)
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(addNewNotification.User).Equals(mock.JonSnow)
+	Expect(addNewNotification.Title).Equals("**Anonymous** accepted an answer on **How do I export my data?**")
+	Expect(addNewNotification.IsAnonymous).IsTrue()
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].From.Name).Equals("Anonymous")
+	Expect(emailmock.MessageHistory[0].Props["userName"]).Equals("Anonymous")
+}
@@ -0,0 +1,94 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+// NotifyAboutAssignedTag sends a notification (web and email) to followers of a tag that was just assigned to a post
+func NotifyAboutAssignedTag(post *entity.Post, tag *entity.Tag) worker.Task {
+	return describe("Notify about assigned tag", func(c *worker.Context) error {
+		if err := notifyTagFollowers(c, post, tag); err != nil {
+			return c.Failure(err)
+		}
+		return nil
+	})
+}
+
+// notifyTagFollowers notifies those following given tag, or one of its parents, that it was assigned to given post
+func notifyTagFollowers(c *worker.Context, post *entity.Post, tag *entity.Tag) error {
+	author := c.User()
+	// Staff tagging an anonymous post are still named, only its author is hidden
+	displayedAuthor := author
+	if post.IsAnonymous && (post.User == nil || post.User.ID == author.ID) {
+		displayedAuthor = entity.AnonymousAuthor()
+	}
+
+	// Web notification
+	getWebFollowers := &query.GetTagFollowers{Post: post, Tag: tag, Channel: enum.NotificationChannelWeb}
+	if err := bus.Dispatch(c, getWebFollowers); err != nil {
+		return err
+	}
+
+	title := fmt.Sprintf("**%s** was tagged **%s**", post.Title, tag.Name)
+	link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+	for _, user := range getWebFollowers.Result {
+		if user.ID != author.ID {
+			err := bus.Dispatch(c, &cmd.AddNewNotification{
+				User:   user,
+				Title:  title,
+				Link:   link,
+				PostID: post.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Email notification
+	getEmailFollowers := &query.GetTagFollowers{Post: post, Tag: tag, Channel: enum.NotificationChannelEmail}
+	if err := bus.Dispatch(c, getEmailFollowers); err != nil {
+		return err
+	}
+
+	to := make([]dto.Recipient, 0)
+	for _, user := range getEmailFollowers.Result {
+		if user.ID != author.ID {
+			to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+		}
+	}
+
+	if len(to) == 0 {
+		return nil
+	}
+
+	tenant := c.Tenant()
+	baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+
+	bus.Publish(c, &cmd.SendMail{
+		From:         dto.Recipient{Name: displayedAuthor.Name},
+		To:           to,
+		TemplateName: "assigned_tag",
+		Props: dto.Props{
+			"title":    post.Title,
+			"tag":      tag.Name,
+			"siteName": tenant.Name,
+			"postLink": linkWithText(fmt.Sprintf("#%d", post.Number), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"view":     linkWithText(i18n.T(c, "email.subscription.view"), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+			"change":   linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/settings"),
+			"logo":     logoURL,
+		},
+	})
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package tasks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/services/email/emailmock"
+	"github.com/getfider/fider/app/tasks"
+)
+
+func TestNotifyAboutAssignedTagTask(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	notifications := make([]*cmd.AddNewNotification, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		notifications = append(notifications, c)
+		return nil
+	})
+
+	tag := &entity.Tag{ID: 3, Name: "Accessibility", Slug: "accessibility", IsPublic: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagFollowers) error {
+		Expect(q.Tag).Equals(tag)
+		if q.Channel == enum.NotificationChannelWeb {
+			q.Result = []*entity.User{mock.JonSnow, mock.AryaStark}
+		} else {
+			q.Result = []*entity.User{mock.AryaStark}
+		}
+		return nil
+	})
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Screen reader support", Slug: "screen-reader-support"}
+	task := tasks.NotifyAboutAssignedTag(post, tag)
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(notifications).HasLen(1)
+	Expect(notifications[0].User).Equals(mock.AryaStark)
+	Expect(notifications[0].Title).Equals("**Screen reader support** was tagged **Accessibility**")
+	Expect(notifications[0].Link).Equals("/posts/1/screen-reader-support")
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("assigned_tag")
+	Expect(emailmock.MessageHistory[0].From.Name).Equals("Jon Snow")
+	Expect(emailmock.MessageHistory[0].To).HasLen(1)
+	Expect(emailmock.MessageHistory[0].To[0].Address).Equals(mock.AryaStark.Email)
+	Expect(emailmock.MessageHistory[0].Props["tag"]).Equals("Accessibility")
+}
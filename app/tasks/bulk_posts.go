@@ -0,0 +1,181 @@
+package tasks
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/i18n"
+	"github.com/getfider/fider/app/pkg/web"
+	"github.com/getfider/fider/app/pkg/webhook"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+// ApplyBulkPostChange applies given change to a batch of posts within a single transaction.
+// Subscribers get one web notification per post, but a single email for the whole batch
+func ApplyBulkPostChange(posts []*entity.Post, change *dto.BulkPostChange) worker.Task {
+	return describe("Apply bulk change to posts", func(c *worker.Context) error {
+		changed := make([]*entity.Post, 0, len(posts))
+		prevStatuses := make(map[int]enum.PostStatus, len(posts))
+
+		for _, post := range posts {
+			var command bus.Msg
+			switch change.Operation {
+			case dto.BulkPostRespond, dto.BulkPostDelete:
+				prevStatuses[post.ID] = post.Status
+				command = &cmd.SetPostResponse{Post: post, Text: change.Text, Status: change.Status}
+			case dto.BulkPostAddTag:
+				command = &cmd.AssignTag{Tag: change.Tag, Post: post}
+			case dto.BulkPostRemoveTag:
+				command = &cmd.UnassignTag{Tag: change.Tag, Post: post}
+			case dto.BulkPostMove:
+				command = &cmd.SetPostBoard{Post: post, Board: change.Board}
+			default:
+				return c.Failure(fmt.Errorf("unknown bulk operation '%s'", change.Operation))
+			}
+
+			if err := bus.Dispatch(c, command); err != nil {
+				return c.Failure(err)
+			}
+
+			if assignTag, ok := command.(*cmd.AssignTag); ok && assignTag.Result {
+				if err := notifyTagFollowers(c, post, change.Tag); err != nil {
+					return c.Failure(err)
+				}
+			}
+
+			if prevStatus, ok := prevStatuses[post.ID]; ok && prevStatus != post.Status {
+				changed = append(changed, post)
+			}
+		}
+
+		if len(changed) == 0 {
+			return nil
+		}
+
+		return notifyAboutBulkStatusChange(c, changed, prevStatuses, change)
+	})
+}
+
+func notifyAboutBulkStatusChange(c *worker.Context, posts []*entity.Post, prevStatuses map[int]enum.PostStatus, change *dto.BulkPostChange) error {
+	tenant := c.Tenant()
+	author := c.User()
+	baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+
+	webhookType := enum.WebhookChangeStatus
+	if change.Operation == dto.BulkPostDelete {
+		webhookType = enum.WebhookDeletePost
+	}
+
+	subscribers := make([]*entity.User, 0)
+	changesByUser := make(map[int][]string)
+
+	for _, post := range posts {
+		webhookProps := webhook.Props{}
+		if webhookType == enum.WebhookChangeStatus {
+			webhookProps["post_old_status"] = prevStatuses[post.ID].Name()
+		}
+		webhookProps.SetPost(post, "post", baseURL, true, true)
+		webhookProps.SetUser(author, "author")
+		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
+
+		err := bus.Dispatch(c, &cmd.TriggerWebhooks{
+			Type:  webhookType,
+			Props: webhookProps,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		// Same as when deleting a single post, subscribers are only told when a reason was given
+		if change.Operation == dto.BulkPostDelete && change.Text == "" {
+			continue
+		}
+
+		// Web notification
+		users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventChangeStatus)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		var title, link string
+		if change.Operation == dto.BulkPostDelete {
+			title = fmt.Sprintf("**%s** deleted **%s**", author.Name, post.Title)
+		} else {
+			title = fmt.Sprintf("**%s** changed status of **%s** to **%s**", author.Name, post.Title, post.Status.Name())
+			link = fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+		}
+
+		for _, user := range users {
+			if user.ID != author.ID {
+				err = bus.Dispatch(c, &cmd.AddNewNotification{
+					User:   user,
+					Title:  title,
+					Link:   link,
+					PostID: post.ID,
+				})
+				if err != nil {
+					return c.Failure(err)
+				}
+			}
+		}
+
+		// Email changes are grouped by subscriber
+		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventChangeStatus)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		var line string
+		if change.Operation == dto.BulkPostDelete {
+			line = i18n.T(c, "email.delete_post.text", i18n.Params{"title": template.HTMLEscapeString(post.Title)})
+		} else {
+			line = i18n.T(c, "email.change_status.others", i18n.Params{
+				"title":    template.HTMLEscapeString(post.Title),
+				"postLink": linkWithText(fmt.Sprintf("#%d", post.Number), baseURL, "/posts/%d/%s", post.Number, post.Slug),
+				"status":   strings.ToLower(i18n.T(c, fmt.Sprintf("enum.poststatus.%s", post.Status.Name()))),
+			})
+		}
+
+		for _, user := range users {
+			if user.ID == author.ID {
+				continue
+			}
+			if _, ok := changesByUser[user.ID]; !ok {
+				subscribers = append(subscribers, user)
+			}
+			changesByUser[user.ID] = append(changesByUser[user.ID], line)
+		}
+	}
+
+	if len(subscribers) == 0 {
+		return nil
+	}
+
+	to := make([]dto.Recipient, 0, len(subscribers))
+	for _, user := range subscribers {
+		changes := changesByUser[user.ID]
+		to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{
+			"count":   len(changes),
+			"changes": template.HTML("<p>" + strings.Join(changes, "</p><p>") + "</p>"),
+		}))
+	}
+
+	bus.Publish(c, &cmd.SendMail{
+		From:         dto.Recipient{Name: author.Name},
+		To:           to,
+		TemplateName: "bulk_change_status",
+		Props: dto.Props{
+			"siteName": tenant.Name,
+			"change":   linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/settings"),
+			"logo":     logoURL,
+		},
+	})
+
+	return nil
+}
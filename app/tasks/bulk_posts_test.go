@@ -0,0 +1,125 @@
+package tasks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/dto"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/services/email/emailmock"
+	"github.com/getfider/fider/app/tasks"
+)
+
+func TestApplyBulkPostChangeTask_Respond(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetPostResponse) error {
+		c.Post.Status = c.Status
+		c.Post.Response = &entity.PostResponse{Text: c.Text, RespondedAt: time.Now(), User: mock.JonSnow}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.AryaStark}
+		return nil
+	})
+
+	notifications := make([]*cmd.AddNewNotification, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		notifications = append(notifications, c)
+		return nil
+	})
+
+	webhooks := 0
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		webhooks++
+		return nil
+	})
+
+	posts := []*entity.Post{
+		{ID: 1, Number: 1, Title: "Add support for TypeScript", Slug: "add-support-for-typescript", Status: enum.PostOpen, User: mock.AryaStark},
+		{ID: 2, Number: 2, Title: "Add dark mode", Slug: "add-dark-mode", Status: enum.PostStarted, User: mock.AryaStark},
+		{ID: 3, Number: 3, Title: "Export to CSV", Slug: "export-to-csv", Status: enum.PostCompleted, User: mock.AryaStark},
+	}
+
+	task := tasks.ApplyBulkPostChange(posts, &dto.BulkPostChange{
+		Operation: dto.BulkPostRespond,
+		Status:    enum.PostCompleted,
+		Text:      "Shipped!",
+	})
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(posts[0].Status).Equals(enum.PostCompleted)
+	Expect(posts[1].Status).Equals(enum.PostCompleted)
+
+	// Post #3 was already completed, so nobody is notified about it
+	Expect(notifications).HasLen(2)
+	Expect(notifications[0].Title).Equals("**Jon Snow** changed status of **Add support for TypeScript** to **completed**")
+	Expect(webhooks).Equals(2)
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("bulk_change_status")
+	Expect(emailmock.MessageHistory[0].To).HasLen(1)
+	Expect(emailmock.MessageHistory[0].To[0].Address).Equals("arya.stark@got.com")
+	Expect(emailmock.MessageHistory[0].To[0].Props["count"]).Equals(2)
+}
+
+func TestApplyBulkPostChangeTask_AddTag(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	assigned := make([]int, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AssignTag) error {
+		assigned = append(assigned, c.Post.Number)
+		// Post #1 was already tagged
+		c.Result = c.Post.Number != 1
+		return nil
+	})
+
+	followersOf := make([]int, 0)
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagFollowers) error {
+		followersOf = append(followersOf, q.Post.Number)
+		if q.Channel == enum.NotificationChannelWeb {
+			q.Result = []*entity.User{mock.AryaStark}
+		}
+		return nil
+	})
+
+	notifications := make([]*cmd.AddNewNotification, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		notifications = append(notifications, c)
+		return nil
+	})
+
+	tag := &entity.Tag{ID: 1, Name: "Bug", Slug: "bug"}
+	posts := []*entity.Post{{ID: 1, Number: 1, Title: "Old post"}, {ID: 2, Number: 2, Title: "New post", Slug: "new-post"}}
+	task := tasks.ApplyBulkPostChange(posts, &dto.BulkPostChange{Operation: dto.BulkPostAddTag, Tag: tag})
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(assigned).Equals([]int{1, 2})
+	Expect(followersOf).Equals([]int{2, 2})
+	Expect(notifications).HasLen(1)
+	Expect(notifications[0].User).Equals(mock.AryaStark)
+	Expect(notifications[0].Title).Equals("**New post** was tagged **Bug**")
+	Expect(emailmock.MessageHistory).HasLen(0)
+}
@@ -4,18 +4,21 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/dto"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/errors"
 	"github.com/getfider/fider/app/pkg/i18n"
 	"github.com/getfider/fider/app/pkg/log"
 	"github.com/getfider/fider/app/pkg/markdown"
 	"github.com/getfider/fider/app/pkg/web"
 	"github.com/getfider/fider/app/pkg/webhook"
 	"github.com/getfider/fider/app/pkg/worker"
+	"github.com/getfider/fider/app/services/email"
 )
 
 // NotifyAboutNewComment sends a notification (web and email) to subscribers
@@ -28,16 +31,41 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 		var mentionNotifications []*entity.MentionNotification
 
 		// Web notification
-		users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventNewComment)
+		users, err := getCommentSubscribers(c, post, comment, enum.NotificationChannelWeb, enum.NotificationEventNewComment)
 		if err != nil {
 			return c.Failure(err)
 		}
 
 		author := c.User()
-		title := fmt.Sprintf("**%s** left a comment on **%s**", author.Name, post.Title)
 		link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+
+		// Author of the comment being replied to is notified even if not subscribed to the post
+		parentAuthorID := 0
+		if comment.ParentID > 0 {
+			getParent := &query.GetCommentByID{CommentID: comment.ParentID}
+			if err := bus.Dispatch(c, getParent); err != nil && errors.Cause(err) != app.ErrNotFound {
+				return c.Failure(err)
+			}
+
+			parent := getParent.Result
+			if parent != nil && parent.User != nil && parent.User.ID != author.ID && parent.User.Status == enum.UserActive &&
+				(!comment.IsInternal || parent.User.IsCollaborator()) {
+				parentAuthorID = parent.User.ID
+				err = bus.Dispatch(c, &cmd.AddNewNotification{
+					User:   parent.User,
+					Title:  fmt.Sprintf("**%s** replied to your comment on **%s**", author.Name, post.Title),
+					Link:   fmt.Sprintf("%s#comment-%d", link, comment.ID),
+					PostID: post.ID,
+				})
+				if err != nil {
+					return c.Failure(err)
+				}
+			}
+		}
+
+		title := fmt.Sprintf("**%s** left a comment on **%s**", author.Name, post.Title)
 		for _, user := range users {
-			if user.ID != author.ID {
+			if user.ID != author.ID && user.ID != parentAuthorID {
 				err = bus.Dispatch(c, &cmd.AddNewNotification{
 					User:   user,
 					Title:  title,
@@ -55,7 +83,7 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 
 		if mentions != nil {
 
-			users, err = getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
+			users, err = getCommentSubscribers(c, post, comment, enum.NotificationChannelWeb, enum.NotificationEventMention)
 			if err != nil {
 				return c.Failure(err)
 			}
@@ -106,7 +134,7 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 		}
 
 		// Standard email notitifications
-		users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventNewComment)
+		users, err = getCommentSubscribers(c, post, comment, enum.NotificationChannelEmail, enum.NotificationEventNewComment)
 		if err != nil {
 			return c.Failure(err)
 		}
@@ -114,17 +142,17 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 		to := make([]dto.Recipient, 0)
 		for _, user := range users {
 			if user.ID != author.ID {
-				to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+				to = append(to, commentRecipient(c, post, comment, user))
 			}
 		}
 
-		sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventNewComment, "new_comment")
+		sendEmailNotifications(c, post, c.User(), to, contentString.SanitizeMentions(), enum.NotificationEventNewComment, "new_comment")
 
 		// Mentions
 		to = make([]dto.Recipient, 0)
 		if mentions != nil {
 
-			users, err = getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
+			users, err = getCommentSubscribers(c, post, comment, enum.NotificationChannelEmail, enum.NotificationEventMention)
 			if err != nil {
 				return c.Failure(err)
 			}
@@ -136,7 +164,7 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 						func(n *entity.MentionNotification) bool {
 							return n.UserID == u.ID
 						}) {
-						to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
+						to = append(to, commentRecipient(c, post, comment, u))
 
 						// Also send the notification log
 						err = bus.Dispatch(c, &cmd.AddMentionNotification{
@@ -152,7 +180,12 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 
 		}
 
-		sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
+		sendEmailNotifications(c, post, c.User(), to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
+
+		// Internal notes are never sent to webhooks, as they may reach public consumers
+		if comment.IsInternal {
+			return nil
+		}
 
 		tenant := c.Tenant()
 		baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
@@ -193,7 +226,7 @@ func NotifyAboutUpdatedComment(post *entity.Post, comment *entity.Comment) worke
 		mentionNotificationSent := false
 		if mentions != nil {
 
-			users, err := getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
+			users, err := getCommentSubscribers(c, post, comment, enum.NotificationChannelWeb, enum.NotificationEventMention)
 			if err != nil {
 				return c.Failure(err)
 			}
@@ -244,7 +277,7 @@ func NotifyAboutUpdatedComment(post *entity.Post, comment *entity.Comment) worke
 		to := make([]dto.Recipient, 0)
 		if mentions != nil {
 
-			users, err := getActiveSubscribers(c, post, enum.NotificationChannelEmail, enum.NotificationEventMention)
+			users, err := getCommentSubscribers(c, post, comment, enum.NotificationChannelEmail, enum.NotificationEventMention)
 			if err != nil {
 				return c.Failure(err)
 			}
@@ -273,19 +306,29 @@ func NotifyAboutUpdatedComment(post *entity.Post, comment *entity.Comment) worke
 			}
 		}
 
-		sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
+		sendEmailNotifications(c, post, c.User(), to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
 
 		return nil
 	})
 }
 
-func sendEmailNotifications(c *worker.Context, post *entity.Post, to []dto.Recipient, comment string, event enum.NotificationEvent, templateName string) {
+// commentRecipient returns the recipient of a comment notification,
+// who can reply to it by email when inbound email is configured.
+// Internal notes can't be replied to, as replies are added as public comments
+func commentRecipient(c *worker.Context, post *entity.Post, comment *entity.Comment, user *entity.User) dto.Recipient {
+	recipient := dto.NewRecipient(user.Name, user.Email, dto.Props{})
+	if !comment.IsInternal {
+		recipient.ReplyTo = email.ReplyAddress(c.Tenant().ID, user.ID, post.ID)
+	}
+	return recipient
+}
+
+func sendEmailNotifications(c *worker.Context, post *entity.Post, author *entity.User, to []dto.Recipient, comment string, event enum.NotificationEvent, templateName string) {
 	// Short circuit if there is no one to notify
 	if len(to) == 0 {
 		return
 	}
 
-	author := c.User()
 	tenant := c.Tenant()
 	baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
 	messaleLocaleString := "email.new_comment.text"
@@ -306,6 +349,10 @@ func sendEmailNotifications(c *worker.Context, post *entity.Post, to []dto.Recip
 		"logo":                logoURL,
 	}
 
+	if to[0].ReplyTo != "" {
+		mailProps["replyNotice"] = i18n.T(c, "email.new_comment.reply_notice")
+	}
+
 	bus.Publish(c, &cmd.SendMail{
 		From:         dto.Recipient{Name: author.Name},
 		To:           to,
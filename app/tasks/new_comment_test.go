@@ -16,7 +16,9 @@ import (
 	"github.com/getfider/fider/app/models/dto"
 	. "github.com/getfider/fider/app/pkg/assert"
 	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/env"
 	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/services/email"
 	"github.com/getfider/fider/app/services/email/emailmock"
 	"github.com/getfider/fider/app/tasks"
 )
@@ -406,3 +408,198 @@ func TestNotifyAboutUpdatedComment_UserAlreadyMentioned(t *testing.T) {
 
 	Expect(addNewNotification).IsNil()
 }
+
+func TestNotifyAboutNewCommentTask_Reply(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	notifications := make([]*cmd.AddNewNotification, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		notifications = append(notifications, c)
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetMentionNotifications) error {
+		q.Result = []*entity.MentionNotification{}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
+		q.Result = &entity.Comment{ID: q.CommentID, Content: "What about JavaScript?", User: mock.JonSnow}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		if q.Event.UserSettingsKeyName == "event_notification_new_comment" && q.Channel == enum.NotificationChannelWeb {
+			q.Result = []*entity.User{mock.JonSnow}
+		} else {
+			q.Result = []*entity.User{}
+		}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		return nil
+	})
+
+	post := &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "Add support for TypeScript",
+		Slug:   "add-support-for-typescript",
+		User:   mock.JonSnow,
+	}
+	task := tasks.NotifyAboutNewComment(&entity.Comment{ID: 8, Content: "It's included", ParentID: 5}, post)
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(notifications).HasLen(1)
+	Expect(notifications[0].User).Equals(mock.JonSnow)
+	Expect(notifications[0].Title).Equals("**Arya Stark** replied to your comment on **Add support for TypeScript**")
+	Expect(notifications[0].Link).Equals("/posts/1/add-support-for-typescript#comment-8")
+}
+
+func TestNotifyAboutNewCommentTask_InternalNote(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	notifications := make([]*cmd.AddNewNotification, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		notifications = append(notifications, c)
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddMentionNotification) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetMentionNotifications) error {
+		q.Result = []*entity.MentionNotification{}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.JonSnow, mock.AryaStark}
+		return nil
+	})
+
+	var triggerWebhooks *cmd.TriggerWebhooks
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		triggerWebhooks = c
+		return nil
+	})
+
+	moderator := &entity.User{ID: 3, Name: "Sansa Stark", Email: "sansa@got.com", Role: enum.RoleCollaborator, Status: enum.UserActive}
+	post := &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "Add support for TypeScript",
+		Slug:   "add-support-for-typescript",
+		User:   mock.AryaStark,
+	}
+	comment := &entity.Comment{ID: 9, Content: "Planned for next quarter, @[Jon Snow] and @[Arya Stark]", IsInternal: true}
+	task := tasks.NotifyAboutNewComment(comment, post)
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(moderator).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	for _, n := range notifications {
+		Expect(n.User).Equals(mock.JonSnow)
+	}
+	Expect(notifications).HasLen(2)
+	Expect(emailmock.MessageHistory).HasLen(2)
+	for _, message := range emailmock.MessageHistory {
+		Expect(message.To).HasLen(1)
+		Expect(message.To[0].Address).Equals(mock.JonSnow.Email)
+	}
+	Expect(triggerWebhooks).IsNil()
+}
+
+func TestNotifyAboutNewCommentTask_ReplyAddress(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+	env.Config.Email.Inbound.Domain = "reply.test.fider.io"
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetMentionNotifications) error {
+		q.Result = []*entity.MentionNotification{}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.JonSnow}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		return nil
+	})
+
+	post := &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "Add support for TypeScript",
+		Slug:   "add-support-for-typescript",
+		User:   mock.JonSnow,
+	}
+	task := tasks.NotifyAboutNewComment(&entity.Comment{Content: "I agree"}, post)
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].To).HasLen(1)
+	Expect(emailmock.MessageHistory[0].Props["replyNotice"]).Equals("Reply to this email to add a comment to this post.")
+
+	token, ok := email.ParseReplyAddress(emailmock.MessageHistory[0].To[0].ReplyTo)
+	Expect(ok).IsTrue()
+	Expect(token.TenantID).Equals(mock.DemoTenant.ID)
+	Expect(token.UserID).Equals(mock.JonSnow.ID)
+	Expect(token.PostID).Equals(post.ID)
+}
+
+func TestNotifyAboutNewCommentTask_InternalNote_NoReplyAddress(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+	env.Config.Email.Inbound.Domain = "reply.test.fider.io"
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetMentionNotifications) error {
+		q.Result = []*entity.MentionNotification{}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.JonSnow}
+		return nil
+	})
+
+	moderator := &entity.User{ID: 3, Name: "Sansa Stark", Email: "sansa@got.com", Role: enum.RoleCollaborator, Status: enum.UserActive}
+	post := &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "Add support for TypeScript",
+		Slug:   "add-support-for-typescript",
+		User:   mock.AryaStark,
+	}
+	task := tasks.NotifyAboutNewComment(&entity.Comment{ID: 9, Content: "Planned for next quarter", IsInternal: true}, post)
+
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(moderator).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].To).HasLen(1)
+	Expect(emailmock.MessageHistory[0].To[0].ReplyTo).Equals("")
+	Expect(emailmock.MessageHistory[0].Props["replyNotice"]).IsNil()
+}
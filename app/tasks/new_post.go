@@ -33,15 +33,21 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 		}
 
 		author := c.User()
+		displayedAuthor := author
+		if post.IsAnonymous {
+			displayedAuthor = entity.AnonymousAuthor()
+		}
+
 		title := fmt.Sprintf("New post: **%s**", post.Title)
 		link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
 		for _, user := range users {
 			if user.ID != author.ID {
 				err = bus.Dispatch(c, &cmd.AddNewNotification{
-					User:   user,
-					Title:  title,
-					Link:   link,
-					PostID: post.ID,
+					User:        user,
+					Title:       title,
+					Link:        link,
+					PostID:      post.ID,
+					IsAnonymous: post.IsAnonymous,
 				})
 				if err != nil {
 					return c.Failure(err)
@@ -51,7 +57,7 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 
 		// Web notification - mentions
 		if len(mentions) > 0 {
-			title = fmt.Sprintf("**%s** mentioned you in **%s**", author.Name, post.Title)
+			title = fmt.Sprintf("**%s** mentioned you in **%s**", displayedAuthor.Name, post.Title)
 
 			users, err = getActiveSubscribers(c, post, enum.NotificationChannelWeb, enum.NotificationEventMention)
 			if err != nil {
@@ -77,10 +83,11 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 							return n.UserID == u.ID
 						}) {
 						err = bus.Dispatch(c, &cmd.AddNewNotification{
-							User:   u,
-							Title:  title,
-							Link:   link,
-							PostID: post.ID,
+							User:        u,
+							Title:       title,
+							Link:        link,
+							PostID:      post.ID,
+							IsAnonymous: post.IsAnonymous,
 						})
 						if err != nil {
 							return c.Failure(err)
@@ -118,7 +125,7 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 		mailProps := dto.Props{
 			"title":    post.Title,
 			"siteName": tenant.Name,
-			"userName": author.Name,
+			"userName": displayedAuthor.Name,
 			"content":  markdown.Full(contentString.SanitizeMentions(), false),
 			"postLink": linkWithText(fmt.Sprintf("#%d", post.Number), baseURL, "/posts/%d/%s", post.Number, post.Slug),
 			"view":     linkWithText(i18n.T(c, "email.subscription.view"), baseURL, "/posts/%d/%s", post.Number, post.Slug),
@@ -127,7 +134,7 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 		}
 
 		bus.Publish(c, &cmd.SendMail{
-			From:         dto.Recipient{Name: author.Name},
+			From:         dto.Recipient{Name: displayedAuthor.Name},
 			To:           to,
 			TemplateName: "new_post",
 			Props:        mailProps,
@@ -163,11 +170,11 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 		}
 
 		// Send mention email notifications
-		sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
+		sendEmailNotifications(c, post, displayedAuthor, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
 
 		webhookProps := webhook.Props{}
 		webhookProps.SetPost(post, "post", baseURL, false, false)
-		webhookProps.SetUser(author, "author")
+		webhookProps.SetUser(displayedAuthor, "author")
 		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
 
 		err = bus.Dispatch(c, &cmd.TriggerWebhooks{
@@ -195,6 +202,11 @@ func NotifyAboutUpdatedPost(post *entity.Post) worker.Task {
 		})
 
 		author := c.User()
+		// Staff editing an anonymous post are still named, only its author is hidden
+		isAnonymous := post.IsAnonymous && (post.User == nil || post.User.ID == author.ID)
+		if isAnonymous {
+			author = entity.AnonymousAuthor()
+		}
 		title := fmt.Sprintf("**%s** mentioned you in **%s**", author.Name, post.Title)
 		link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
 		mentionNotificationSent := false
@@ -223,10 +235,11 @@ func NotifyAboutUpdatedPost(post *entity.Post) worker.Task {
 							return n.UserID == u.ID
 						}) {
 						err = bus.Dispatch(c, &cmd.AddNewNotification{
-							User:   u,
-							Title:  title,
-							Link:   link,
-							PostID: post.ID,
+							User:        u,
+							Title:       title,
+							Link:        link,
+							PostID:      post.ID,
+							IsAnonymous: isAnonymous,
 						})
 						if err != nil {
 							return c.Failure(err)
@@ -278,7 +291,7 @@ func NotifyAboutUpdatedPost(post *entity.Post) worker.Task {
 		}
 
 		// Send email notifications for mentions
-		sendEmailNotifications(c, post, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
+		sendEmailNotifications(c, post, author, to, contentString.SanitizeMentions(), enum.NotificationEventMention, "new_comment")
 
 		return nil
 	})
@@ -192,3 +192,35 @@ func TestNotifyAboutNewPostTask_WithMention(t *testing.T) {
 	Expect(triggerWebhooks).IsNotNil()
 	Expect(triggerWebhooks.Type).Equals(enum.WebhookNewPost)
 }
+
+func TestNotifyAboutNewPostTask_AnonymousPost(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	var addNewNotification *cmd.AddNewNotification
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		addNewNotification = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{mock.JonSnow}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error { return nil })
+
+	post := &entity.Post{ID: 1, Number: 1, Title: "Salary transparency", Slug: "salary-transparency", IsAnonymous: true}
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithBaseURL("http://domain.com").
+		Execute(tasks.NotifyAboutNewPost(post))
+
+	Expect(err).IsNil()
+	Expect(addNewNotification.User).Equals(mock.JonSnow)
+	Expect(addNewNotification.IsAnonymous).IsTrue()
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].From.Name).Equals("Anonymous")
+	Expect(emailmock.MessageHistory[0].Props["userName"]).Equals("Anonymous")
+}
@@ -0,0 +1,15 @@
+package tasks
+
+import (
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/worker"
+)
+
+// RecordPostView counts a view of the post outside of the request, repeated views from a session are ignored by the store
+func RecordPostView(post *entity.Post, sessionID string) worker.Task {
+	return describe("Record post view", func(c *worker.Context) error {
+		return bus.Dispatch(c, &cmd.RecordPostView{PostID: post.ID, SessionID: sessionID})
+	})
+}
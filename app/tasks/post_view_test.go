@@ -0,0 +1,33 @@
+package tasks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/mock"
+	"github.com/getfider/fider/app/tasks"
+)
+
+func TestRecordPostViewTask(t *testing.T) {
+	RegisterT(t)
+
+	var recordPostView *cmd.RecordPostView
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordPostView) error {
+		recordPostView = c
+		c.Result = true
+		return nil
+	})
+
+	post := &entity.Post{ID: 5, Number: 5, Title: "Add support for TypeScript"}
+	err := mock.NewWorker().
+		OnTenant(mock.DemoTenant).
+		Execute(tasks.RecordPostView(post, "session-1234"))
+
+	Expect(err).IsNil()
+	Expect(recordPostView.PostID).Equals(5)
+	Expect(recordPostView.SessionID).Equals("session-1234")
+}
@@ -29,11 +29,17 @@ func NotifyAboutStatusChange(post *entity.Post, prevStatus enum.PostStatus) work
 			return c.Failure(err)
 		}
 
+		// Changes made by the system, such as by the stale posts job, are sent on behalf of the site
 		author := c.User()
-		title := fmt.Sprintf("**%s** changed status of **%s** to **%s**", author.Name, post.Title, post.Status.Name())
+		authorID, authorName := 0, c.Tenant().Name
+		if author != nil {
+			authorID, authorName = author.ID, author.Name
+		}
+
+		title := fmt.Sprintf("**%s** changed status of **%s** to **%s**", authorName, post.Title, post.Status.Name())
 		link := fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
 		for _, user := range users {
-			if user.ID != author.ID {
+			if user.ID != authorID {
 				err = bus.Dispatch(c, &cmd.AddNewNotification{
 					User:   user,
 					Title:  title,
@@ -60,7 +66,7 @@ func NotifyAboutStatusChange(post *entity.Post, prevStatus enum.PostStatus) work
 
 		to := make([]dto.Recipient, 0)
 		for _, user := range users {
-			if user.ID != author.ID {
+			if user.ID != authorID {
 				to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
 			}
 		}
@@ -82,7 +88,7 @@ func NotifyAboutStatusChange(post *entity.Post, prevStatus enum.PostStatus) work
 		}
 
 		bus.Publish(c, &cmd.SendMail{
-			From:         dto.Recipient{Name: author.Name},
+			From:         dto.Recipient{Name: authorName},
 			To:           to,
 			TemplateName: "change_status",
 			Props:        props,
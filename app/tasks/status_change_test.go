@@ -255,3 +255,52 @@ func TestNotifyAboutStatusChangeTask_Duplicate(t *testing.T) {
 		"tenant_url":                    "http://domain.com",
 	})
 }
+
+func TestNotifyAboutStatusChangeTask_BySystem(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	var addNewNotification *cmd.AddNewNotification
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		addNewNotification = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		q.Result = []*entity.User{
+			mock.AryaStark,
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error { return nil })
+
+	worker := mock.NewWorker()
+	post := &entity.Post{
+		ID:     3,
+		Number: 3,
+		Title:  "Dark mode",
+		Slug:   "dark-mode",
+		User:   mock.AryaStark,
+		Status: enum.PostDeclined,
+		Response: &entity.PostResponse{
+			RespondedAt: time.Now(),
+			Text:        "Closed due to inactivity.",
+		},
+	}
+
+	task := tasks.NotifyAboutStatusChange(post, enum.PostOpen)
+
+	err := worker.
+		OnTenant(mock.DemoTenant).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].From).Equals(dto.Recipient{
+		Name: "Demonstration",
+	})
+	Expect(addNewNotification.Title).Equals("**Demonstration** changed status of **Dark mode** to **declined**")
+	Expect(addNewNotification.User).Equals(mock.AryaStark)
+}
@@ -32,3 +32,20 @@ func getActiveSubscribers(ctx context.Context, post *entity.Post, channel enum.N
 	err := bus.Dispatch(ctx, q)
 	return q.Result, err
 }
+
+// getCommentSubscribers returns the active subscribers of given post that are allowed to see the comment.
+// Internal notes are only visible to collaborators and administrators.
+func getCommentSubscribers(ctx context.Context, post *entity.Post, comment *entity.Comment, channel enum.NotificationChannel, event enum.NotificationEvent) ([]*entity.User, error) {
+	users, err := getActiveSubscribers(ctx, post, channel, event)
+	if err != nil || !comment.IsInternal {
+		return users, err
+	}
+
+	staff := make([]*entity.User, 0, len(users))
+	for _, user := range users {
+		if user.IsCollaborator() {
+			staff = append(staff, user)
+		}
+	}
+	return staff, nil
+}
@@ -0,0 +1,29 @@
+-- Custom fields: admin-defined fields that can be filled in on posts
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id          SERIAL PRIMARY KEY,
+    tenant_id   INT NOT NULL,
+    key         VARCHAR(50) NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    type        SMALLINT NOT NULL,
+    options     TEXT[] NOT NULL DEFAULT '{}',
+    is_required BOOLEAN NOT NULL DEFAULT false,
+    is_public   BOOLEAN NOT NULL DEFAULT true,
+    position    INT NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT custom_fields_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT custom_fields_unique UNIQUE (tenant_id, key)
+);
+
+CREATE TABLE IF NOT EXISTS post_custom_field_values (
+    tenant_id   INT NOT NULL,
+    post_id     INT NOT NULL,
+    field_id    INT NOT NULL,
+    value       TEXT NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT post_custom_field_values_pkey PRIMARY KEY (post_id, field_id),
+    CONSTRAINT post_custom_field_values_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT post_custom_field_values_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id),
+    CONSTRAINT post_custom_field_values_field_id_fkey FOREIGN KEY (field_id) REFERENCES custom_fields(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS post_custom_field_values_tenant_field ON post_custom_field_values (tenant_id, field_id, value);
@@ -0,0 +1,44 @@
+-- Boards: sections of a tenant, each with its own posts, settings and staff
+CREATE TABLE IF NOT EXISTS boards (
+    id                    SERIAL PRIMARY KEY,
+    tenant_id             INT NOT NULL,
+    name                  VARCHAR(60) NOT NULL,
+    slug                  VARCHAR(60) NOT NULL,
+    welcome_message       TEXT NULL,
+    default_tags          TEXT[] NOT NULL DEFAULT '{}',
+    is_moderation_enabled BOOLEAN NOT NULL DEFAULT false,
+    is_private            BOOLEAN NOT NULL DEFAULT false,
+    position              INT NOT NULL DEFAULT 0,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT boards_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT boards_unique_slug UNIQUE (tenant_id, slug)
+);
+
+CREATE TABLE IF NOT EXISTS board_staff (
+    tenant_id  INT NOT NULL,
+    board_id   INT NOT NULL,
+    user_id    INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT board_staff_pkey PRIMARY KEY (board_id, user_id),
+    CONSTRAINT board_staff_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT board_staff_board_id_fkey FOREIGN KEY (board_id) REFERENCES boards(id) ON DELETE CASCADE,
+    CONSTRAINT board_staff_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- Posts created before boards existed stay without a board
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS board_id INT NULL;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM information_schema.table_constraints
+    WHERE constraint_name = 'posts_board_id_fkey'
+    AND table_name = 'posts'
+  ) THEN
+    ALTER TABLE posts
+      ADD CONSTRAINT posts_board_id_fkey
+      FOREIGN KEY (board_id) REFERENCES boards(id) ON DELETE SET NULL;
+  END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS posts_tenant_id_board_id_idx ON posts (tenant_id, board_id);
@@ -0,0 +1,8 @@
+-- Voting modes: vote budgets, importance levels and weights by role
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS vote_budget INT NOT NULL DEFAULT 0;
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS is_vote_importance_enabled BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS vote_role_weights JSONB NOT NULL DEFAULT '{}';
+
+-- Weight is computed when the vote is cast, so changing the settings doesn't rewrite history
+ALTER TABLE post_votes ADD COLUMN IF NOT EXISTS importance SMALLINT NOT NULL DEFAULT 1;
+ALTER TABLE post_votes ADD COLUMN IF NOT EXISTS weight INT NOT NULL DEFAULT 1;
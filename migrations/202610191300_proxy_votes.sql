@@ -0,0 +1,16 @@
+-- Votes added by staff on behalf of a customer keep track of who recorded them
+ALTER TABLE post_votes ADD COLUMN IF NOT EXISTS proxied_by_id INT NULL;
+ALTER TABLE post_votes ADD COLUMN IF NOT EXISTS proxy_note TEXT NULL;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM information_schema.table_constraints
+    WHERE constraint_name = 'post_votes_proxied_by_id_fkey'
+    AND table_name = 'post_votes'
+  ) THEN
+    ALTER TABLE post_votes
+      ADD CONSTRAINT post_votes_proxied_by_id_fkey
+      FOREIGN KEY (proxied_by_id, tenant_id) REFERENCES users(id, tenant_id);
+  END IF;
+END $$;
@@ -0,0 +1,30 @@
+-- Rules used by the stale posts job to warn about and then close inactive posts
+CREATE TABLE IF NOT EXISTS stale_post_rules (
+    tenant_id     INT NOT NULL,
+    inactive_days INT NOT NULL DEFAULT 0,
+    grace_days    INT NOT NULL DEFAULT 14,
+    close_status  SMALLINT NOT NULL DEFAULT 3,
+    response      TEXT NOT NULL DEFAULT '',
+    exempt_tags   TEXT[] NOT NULL DEFAULT '{}',
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT stale_post_rules_pkey PRIMARY KEY (tenant_id),
+    CONSTRAINT stale_post_rules_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+
+-- Activity log of a post, written by staff actions and scheduled jobs
+CREATE TABLE IF NOT EXISTS post_logs (
+    id         SERIAL PRIMARY KEY,
+    tenant_id  INT NOT NULL,
+    post_id    INT NOT NULL,
+    user_id    INT NULL,
+    action     VARCHAR(50) NOT NULL,
+    details    TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT post_logs_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT post_logs_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    CONSTRAINT post_logs_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS post_logs_tenant_id_post_id_idx ON post_logs (tenant_id, post_id);
+
+-- Any activity after the warning makes the post active again
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS stale_warned_at TIMESTAMPTZ NULL;
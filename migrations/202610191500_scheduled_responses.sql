@@ -0,0 +1,18 @@
+-- Status changes and responses applied by a job at a later time. Rows without a schedule are drafts
+CREATE TABLE IF NOT EXISTS post_scheduled_responses (
+    id            SERIAL PRIMARY KEY,
+    tenant_id     INT NOT NULL,
+    post_id       INT NOT NULL,
+    status        SMALLINT NOT NULL,
+    response      TEXT NOT NULL DEFAULT '',
+    scheduled_at  TIMESTAMPTZ NULL,
+    base_url      TEXT NOT NULL DEFAULT '',
+    created_by_id INT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    executed_at   TIMESTAMPTZ NULL,
+    CONSTRAINT post_scheduled_responses_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT post_scheduled_responses_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    CONSTRAINT post_scheduled_responses_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS post_scheduled_responses_tenant_id_post_id_idx ON post_scheduled_responses (tenant_id, post_id);
+CREATE INDEX IF NOT EXISTS post_scheduled_responses_due_idx ON post_scheduled_responses (scheduled_at) WHERE executed_at IS NULL;
@@ -0,0 +1,5 @@
+-- Tenants can allow posts to be published without showing who wrote them
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS is_anonymous_posting_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The author is still stored on user_id, but only shown to the author themselves
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS is_anonymous BOOLEAN NOT NULL DEFAULT FALSE;
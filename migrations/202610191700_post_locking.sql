@@ -0,0 +1,20 @@
+-- Locked posts stay readable but don't accept new comments, votes or reactions
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS locked_at TIMESTAMPTZ NULL;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS locked_by_id INT NULL;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS lock_reason TEXT NOT NULL DEFAULT '';
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM information_schema.table_constraints
+    WHERE constraint_name = 'posts_locked_by_id_fkey'
+    AND table_name = 'posts'
+  ) THEN
+    ALTER TABLE posts
+      ADD CONSTRAINT posts_locked_by_id_fkey
+      FOREIGN KEY (locked_by_id, tenant_id) REFERENCES users(id, tenant_id);
+  END IF;
+END $$;
+
+-- Closed posts can be locked automatically some days after their last response
+ALTER TABLE stale_post_rules ADD COLUMN IF NOT EXISTS lock_after_days INT NOT NULL DEFAULT 0;
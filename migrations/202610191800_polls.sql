@@ -0,0 +1,43 @@
+-- Polls asked by staff on a post or on one of their comments
+CREATE TABLE IF NOT EXISTS polls (
+    id                 SERIAL PRIMARY KEY,
+    tenant_id          INT NOT NULL,
+    post_id            INT NOT NULL,
+    comment_id         INT NULL,
+    question           TEXT NOT NULL,
+    is_multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    is_voters_only     BOOLEAN NOT NULL DEFAULT FALSE,
+    closes_at          TIMESTAMPTZ NULL,
+    created_by_id      INT NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT polls_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT polls_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    CONSTRAINT polls_comment_id_fkey FOREIGN KEY (comment_id) REFERENCES comments(id) ON DELETE CASCADE,
+    CONSTRAINT polls_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS polls_tenant_id_post_id_idx ON polls (tenant_id, post_id);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    id        SERIAL PRIMARY KEY,
+    tenant_id INT NOT NULL,
+    poll_id   INT NOT NULL,
+    text      TEXT NOT NULL,
+    position  INT NOT NULL,
+    CONSTRAINT poll_options_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT poll_options_poll_id_fkey FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS poll_options_poll_id_idx ON poll_options (poll_id);
+
+CREATE TABLE IF NOT EXISTS poll_answers (
+    tenant_id  INT NOT NULL,
+    poll_id    INT NOT NULL,
+    option_id  INT NOT NULL,
+    user_id    INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT poll_answers_pkey PRIMARY KEY (option_id, user_id),
+    CONSTRAINT poll_answers_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT poll_answers_poll_id_fkey FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    CONSTRAINT poll_answers_option_id_fkey FOREIGN KEY (option_id) REFERENCES poll_options(id) ON DELETE CASCADE,
+    CONSTRAINT poll_answers_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS poll_answers_poll_id_user_id_idx ON poll_answers (poll_id, user_id);
@@ -0,0 +1,15 @@
+-- Post templates: markdown skeletons offered when creating a post
+CREATE TABLE IF NOT EXISTS post_templates (
+    id                SERIAL PRIMARY KEY,
+    tenant_id         INT NOT NULL,
+    name              VARCHAR(60) NOT NULL,
+    content           TEXT NOT NULL,
+    required_sections TEXT[] NOT NULL DEFAULT '{}',
+    tag_slug          VARCHAR(60) NULL,
+    board_id          INT NULL,
+    position          INT NOT NULL DEFAULT 0,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT post_templates_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT post_templates_board_id_fkey FOREIGN KEY (board_id) REFERENCES boards(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS post_templates_tenant_id_idx ON post_templates (tenant_id);
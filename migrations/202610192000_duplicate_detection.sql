@@ -0,0 +1,19 @@
+-- Trigram index used to find near-duplicate titles
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS posts_lower_title_trgm_idx ON posts USING GIN (lower(title) gin_trgm_ops);
+
+-- Pairs of posts found by the duplicate detection job, reviewed by staff
+CREATE TABLE IF NOT EXISTS post_duplicate_candidates (
+    id           SERIAL PRIMARY KEY,
+    tenant_id    INT NOT NULL,
+    post_id      INT NOT NULL,
+    original_id  INT NOT NULL,
+    score        REAL NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    dismissed_at TIMESTAMPTZ NULL,
+    CONSTRAINT post_duplicate_candidates_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT post_duplicate_candidates_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    CONSTRAINT post_duplicate_candidates_original_id_fkey FOREIGN KEY (original_id) REFERENCES posts(id) ON DELETE CASCADE,
+    CONSTRAINT post_duplicate_candidates_unique_pair UNIQUE (post_id, original_id)
+);
+CREATE INDEX IF NOT EXISTS post_duplicate_candidates_tenant_id_idx ON post_duplicate_candidates (tenant_id, dismissed_at);
@@ -0,0 +1,12 @@
+-- Post co-authors: users who share editing rights and notifications with the post owner
+CREATE TABLE IF NOT EXISTS post_coauthors (
+    tenant_id     INT NOT NULL,
+    post_id       INT NOT NULL,
+    user_id       INT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id),
+    CONSTRAINT post_coauthors_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT post_coauthors_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    CONSTRAINT post_coauthors_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS post_coauthors_user_id_idx ON post_coauthors (tenant_id, user_id);
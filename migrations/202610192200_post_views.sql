@@ -0,0 +1,14 @@
+-- Post views: one row per session and post within the deduplication window
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS views_count INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS post_views (
+    id          BIGSERIAL PRIMARY KEY,
+    tenant_id   INT NOT NULL,
+    post_id     INT NOT NULL,
+    session_id  VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT post_views_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT post_views_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS post_views_post_id_session_id_idx ON post_views (post_id, session_id, created_at);
+CREATE INDEX IF NOT EXISTS post_views_post_id_created_at_idx ON post_views (post_id, created_at);
@@ -0,0 +1,21 @@
+-- External references (issues, merge requests, tickets) linked to posts
+CREATE TABLE IF NOT EXISTS post_links (
+    id             SERIAL PRIMARY KEY,
+    tenant_id      INT NOT NULL,
+    post_id        INT NOT NULL,
+    type           VARCHAR(20) NOT NULL,
+    external_id    VARCHAR(200) NOT NULL DEFAULT '',
+    url            VARCHAR(300) NOT NULL,
+    state          VARCHAR(20) NOT NULL DEFAULT 'open',
+    created_by_id  INT NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT post_links_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT post_links_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    CONSTRAINT post_links_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS post_links_post_id_idx ON post_links (post_id);
+CREATE INDEX IF NOT EXISTS post_links_external_id_idx ON post_links (tenant_id, type, external_id);
+
+-- Secret shared with GitHub and GitLab to verify inbound issue tracker webhooks
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS issue_webhook_secret VARCHAR(100) NOT NULL DEFAULT '';
@@ -0,0 +1,17 @@
+-- Replies reference the comment they answer; top-level comments have no parent
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS parent_id INT NULL;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM information_schema.table_constraints
+    WHERE constraint_name = 'comments_parent_id_fkey'
+    AND table_name = 'comments'
+  ) THEN
+    ALTER TABLE comments
+      ADD CONSTRAINT comments_parent_id_fkey
+      FOREIGN KEY (parent_id) REFERENCES comments(id);
+  END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS comments_parent_id_idx ON comments (parent_id) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Internal notes are comments only visible to collaborators and administrators
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS is_internal BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,16 @@
+-- A comment can be accepted as the answer of the post it belongs to
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS accepted_comment_id INT NULL;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS answer_accepted_at TIMESTAMPTZ NULL;
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM information_schema.table_constraints
+    WHERE constraint_name = 'posts_accepted_comment_id_fkey'
+    AND table_name = 'posts'
+  ) THEN
+    ALTER TABLE posts
+      ADD CONSTRAINT posts_accepted_comment_id_fkey
+      FOREIGN KEY (accepted_comment_id) REFERENCES comments(id);
+  END IF;
+END $$;
@@ -0,0 +1,30 @@
+-- Emoji reactions on posts, kept apart from votes
+CREATE TABLE IF NOT EXISTS post_reactions (
+  id         SERIAL PRIMARY KEY,
+  tenant_id  INT NOT NULL,
+  post_id    INT NOT NULL,
+  user_id    INT NOT NULL,
+  emoji      VARCHAR(8) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+  FOREIGN KEY (post_id) REFERENCES posts(id),
+  FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM information_schema.table_constraints
+    WHERE constraint_name = 'post_reactions_unique'
+    AND table_name = 'post_reactions'
+  ) THEN
+    ALTER TABLE post_reactions
+      ADD CONSTRAINT post_reactions_unique
+      UNIQUE (post_id, user_id, emoji);
+  END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS post_reactions_tenant_post_idx ON post_reactions (tenant_id, post_id);
+
+-- Emojis members can react with, on both posts and comments
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS allowed_reactions JSONB NOT NULL DEFAULT '["👍","👎","😄","🎉","😕","❤️","🚀","👀"]';
@@ -0,0 +1,28 @@
+-- The reaction palette has a name and optionally an uploaded image per reaction
+ALTER TABLE tenants RENAME COLUMN allowed_reactions TO reactions;
+ALTER TABLE tenants ALTER COLUMN reactions DROP DEFAULT;
+
+UPDATE tenants SET reactions = (
+  SELECT COALESCE(jsonb_agg(jsonb_build_object(
+    'emoji', e.value,
+    'name', CASE e.value
+      WHEN '👍' THEN '+1'
+      WHEN '👎' THEN '-1'
+      WHEN '😄' THEN 'Laugh'
+      WHEN '🎉' THEN 'Hooray'
+      WHEN '😕' THEN 'Confused'
+      WHEN '❤️' THEN 'Heart'
+      WHEN '🚀' THEN 'Rocket'
+      WHEN '👀' THEN 'Eyes'
+      ELSE e.value
+    END
+  ) ORDER BY e.ordinality), '[]'::jsonb)
+  FROM jsonb_array_elements_text(tenants.reactions) WITH ORDINALITY AS e(value, ordinality)
+)
+WHERE jsonb_typeof(reactions) = 'array';
+
+ALTER TABLE tenants ALTER COLUMN reactions SET DEFAULT '[]';
+
+-- Custom reactions use a short name instead of an emoji
+ALTER TABLE reactions ALTER COLUMN emoji TYPE VARCHAR(50);
+ALTER TABLE post_reactions ALTER COLUMN emoji TYPE VARCHAR(50);
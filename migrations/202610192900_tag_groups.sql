@@ -0,0 +1,18 @@
+-- Tag groups such as "Area" or "Platform", optionally allowing a single tag per post
+CREATE TABLE IF NOT EXISTS tag_groups (
+  id               SERIAL PRIMARY KEY,
+  tenant_id        INT NOT NULL,
+  name             VARCHAR(30) NOT NULL,
+  slug             VARCHAR(30) NOT NULL,
+  is_single_select BOOLEAN NOT NULL DEFAULT false,
+  created_at       TIMESTAMPTZ NOT NULL,
+  FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tag_groups_tenant_slug_idx ON tag_groups (tenant_id, slug);
+
+-- Tags may belong to a group and have a parent tag; filtering by a parent includes its children
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS group_id INT NULL REFERENCES tag_groups(id) ON DELETE SET NULL;
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS parent_id INT NULL REFERENCES tags(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tags_parent_idx ON tags (tenant_id, parent_id);
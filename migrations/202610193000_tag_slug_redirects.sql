@@ -0,0 +1,12 @@
+-- Old tag slugs left behind by renames and merges keep resolving to the current tag
+CREATE TABLE IF NOT EXISTS tag_slug_redirects (
+  id         SERIAL PRIMARY KEY,
+  tenant_id  INT NOT NULL,
+  old_slug   VARCHAR(30) NOT NULL,
+  tag_id     INT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+  FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tag_slug_redirects_tenant_slug_idx ON tag_slug_redirects (tenant_id, old_slug);
@@ -0,0 +1,21 @@
+-- Rules evaluated when a post is created or edited to tag, route and flag it automatically
+CREATE TABLE IF NOT EXISTS post_rules (
+    id                  SERIAL PRIMARY KEY,
+    tenant_id           INT NOT NULL,
+    name                VARCHAR(60) NOT NULL,
+    is_enabled          BOOLEAN NOT NULL DEFAULT true,
+    keywords            TEXT[] NOT NULL DEFAULT '{}',
+    pattern             TEXT NOT NULL DEFAULT '',
+    match_in            VARCHAR(20) NOT NULL DEFAULT '',
+    email_domains       TEXT[] NOT NULL DEFAULT '{}',
+    roles               INT[] NOT NULL DEFAULT '{}',
+    board_slugs         TEXT[] NOT NULL DEFAULT '{}',
+    tags                TEXT[] NOT NULL DEFAULT '{}',
+    status              SMALLINT NULL,
+    subscriber_ids      INT[] NOT NULL DEFAULT '{}',
+    flag_for_moderation BOOLEAN NOT NULL DEFAULT false,
+    position            INT NOT NULL DEFAULT 0,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT post_rules_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE INDEX IF NOT EXISTS post_rules_tenant_id_idx ON post_rules (tenant_id);
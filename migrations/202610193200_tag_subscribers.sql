@@ -0,0 +1,16 @@
+-- Users following a tag are notified about posts with that tag, channels is the same web/email bitmask of user settings
+CREATE TABLE IF NOT EXISTS tag_subscribers (
+  tenant_id        INT NOT NULL,
+  user_id          INT NOT NULL,
+  tag_id           INT NOT NULL,
+  channels         SMALLINT NOT NULL,
+  include_comments BOOLEAN NOT NULL DEFAULT false,
+  created_at       TIMESTAMPTZ NOT NULL,
+  updated_at       TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (user_id, tag_id),
+  FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+  FOREIGN KEY (user_id) REFERENCES users(id),
+  FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS tag_subscribers_tenant_tag_idx ON tag_subscribers (tenant_id, tag_id);
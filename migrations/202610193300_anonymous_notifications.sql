@@ -0,0 +1,2 @@
+-- Notifications about anonymous posts are stored without an author
+ALTER TABLE notifications ALTER COLUMN author_id DROP NOT NULL;
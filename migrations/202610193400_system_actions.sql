@@ -0,0 +1,5 @@
+-- Tags assigned and posts flagged by post rules are stored without a user
+ALTER TABLE post_tags ALTER COLUMN created_by_id DROP NOT NULL;
+ALTER TABLE post_flags ALTER COLUMN user_id DROP NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS post_flags_system_unique ON post_flags (tenant_id, post_id) WHERE user_id IS NULL;
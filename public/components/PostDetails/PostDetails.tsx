@@ -1,8 +1,8 @@
 import "./PostDetails.scss"
 
-import React, { useState, useEffect, useCallback } from "react"
+import React, { useState, useEffect, useCallback, useRef } from "react"
 
-import { Comment, Post, Tag, Vote, CurrentUser, PostStatus } from "@fider/models"
+import { Comment, Post, Tag, Vote, CurrentUser, PostStatus, flattenComments } from "@fider/models"
 import { actions, cache, clearUrlHash, Failure, Fider, notify, timeAgo } from "@fider/services"
 import IconDuplicate from "@fider/assets/images/heroicons-duplicate.svg"
 import { i18n } from "@lingui/core"
@@ -10,7 +10,7 @@ import IconRSS from "@fider/assets/images/heroicons-rss.svg"
 import IconPencil from "@fider/assets/images/heroicons-pencil-alt.svg"
 import IconChat from "@fider/assets/images/heroicons-chat-alt-2.svg"
 
-import { ResponseDetails, Button, UserName, Moment, Markdown, Input, Form, Icon, Avatar, RSSModal, ResponseLozenge } from "@fider/components"
+import { ResponseDetails, Button, UserName, Moment, Markdown, Input, Form, Icon, Avatar, RSSModal, ResponseLozenge, Reactions } from "@fider/components"
 import { CommentInput } from "@fider/pages/ShowPost/components/CommentInput"
 import { ShowComment } from "@fider/pages/ShowPost/components/ShowComment"
 import { VoteSection } from "@fider/pages/ShowPost/components/VoteSection"
@@ -21,6 +21,7 @@ import IconThumbsUp from "@fider/assets/images/heroicons-thumbsup.svg"
 import IconTrash from "@fider/assets/images/heroicons-trash.svg"
 import IconExclamation from "@fider/assets/images/heroicons-exclamation-circle.svg"
 import IconStar from "@fider/assets/images/heroicons-star.svg"
+import IconLockClosed from "@fider/assets/images/heroicons-lock-closed.svg"
 import { HStack, VStack } from "@fider/components/layout"
 import { Trans } from "@lingui/react/macro"
 import { DeletePostModal } from "@fider/pages/ShowPost/components/DeletePostModal"
@@ -32,6 +33,11 @@ import { t } from "@lingui/macro"
 import { useFider } from "@fider/hooks"
 import { useAttachments } from "@fider/hooks/useAttachments"
 import { FollowButton } from "@fider/pages/ShowPost/components/FollowButton"
+import { PollsPanel } from "@fider/pages/ShowPost/components/PollsPanel"
+import { AuthorsPanel } from "@fider/pages/ShowPost/components/AuthorsPanel"
+import { LinksPanel } from "@fider/pages/ShowPost/components/LinksPanel"
+import { AcceptedAnswer } from "@fider/pages/ShowPost/components/AcceptedAnswer"
+import { PostAnalyticsPanel } from "@fider/pages/ShowPost/components/PostAnalyticsPanel"
 
 interface PostDetailsProps {
   postNumber: number
@@ -51,6 +57,10 @@ const canEditPost = (user: CurrentUser, post: Post) => {
     return true
   }
 
+  if (post.coAuthorIds?.includes(user.id)) {
+    return true
+  }
+
   return user.id === post.user.id && timeAgo(post.createdAt) <= oneHour
 }
 
@@ -87,6 +97,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
   })
   const [highlightedComment, setHighlightedComment] = useState<number | undefined>(undefined)
   const [error, setError] = useState<Failure | undefined>(undefined)
+  const emojiSelectorRef = useRef<HTMLDivElement>(null)
   const fider = useFider()
 
   // Fetch data if not provided initially
@@ -149,7 +160,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
         newHighlightedComment = undefined
       } else {
         const id = parseInt(result[1])
-        if (comments.map((comment) => comment.id).includes(id)) {
+        if (flattenComments(comments).some((comment) => comment.id === id)) {
           newHighlightedComment = id
         } else {
           if (e?.cancelable) {
@@ -235,6 +246,27 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
     setNewDescription(value)
   }
 
+  const togglePostReaction = async (emoji: string) => {
+    if (!post) return
+    const response = await actions.togglePostReaction(post.number, emoji)
+    if (response.ok) {
+      const added = response.data.added
+      const counts = [...(post.reactionCounts ?? [])]
+      const reactionIndex = counts.findIndex((r) => r.emoji === emoji)
+      if (reactionIndex !== -1) {
+        const newCount = added ? counts[reactionIndex].count + 1 : counts[reactionIndex].count - 1
+        if (newCount === 0) {
+          counts.splice(reactionIndex, 1)
+        } else {
+          counts[reactionIndex] = { ...counts[reactionIndex], count: newCount, includesMe: added }
+        }
+      } else if (added) {
+        counts.push({ emoji, count: 1, includesMe: true })
+      }
+      setPost({ ...post, reactionCounts: counts })
+    }
+  }
+
   const handleApprovePost = async () => {
     if (!post) return
     const result = await actions.approvePost(post.id)
@@ -270,7 +302,20 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
     }
   }
 
-  const onActionSelected = (action: "copy" | "delete" | "status" | "pin" | "unpin" | "feed" | "edit" | "flag") => () => {
+  const handleLockPost = async (locked: boolean) => {
+    if (!post) return
+    const result = await actions.lockPost(post.number, locked)
+    if (result.ok) {
+      notify.success(locked ? t({ id: "showpost.lock.success", message: "Post locked" }) : t({ id: "showpost.unlock.success", message: "Post unlocked" }))
+      props.onDataChanged?.()
+      setTimeout(() => location.reload(), 500)
+    } else {
+      const errMsg = result.error?.errors?.[0]?.message ?? (locked ? "Failed to lock post" : "Failed to unlock post")
+      notify.error(errMsg)
+    }
+  }
+
+  const onActionSelected = (action: "copy" | "delete" | "status" | "pin" | "unpin" | "lock" | "unlock" | "feed" | "edit" | "flag") => () => {
     if (action === "copy") {
       navigator.clipboard.writeText(window.location.href)
       notify.success(<Trans id="showpost.copylink.success">Link copied to clipboard</Trans>)
@@ -282,6 +327,10 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
       handlePinPost(true)
     } else if (action === "unpin") {
       handlePinPost(false)
+    } else if (action === "lock") {
+      handleLockPost(true)
+    } else if (action === "unlock") {
+      handleLockPost(false)
     } else if (action === "edit") {
       startEdit()
     } else if (action == "feed") {
@@ -341,6 +390,11 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
                 <Trans id="label.pinned">Pinned</Trans>
               </span>
             )}
+            {!editMode && post.lockedAt && (
+              <span className="text-xs px-2 py-0.5 rounded bg-gray-100 text-gray-800">
+                <Trans id="label.locked">Locked</Trans>
+              </span>
+            )}
             {/* Posted by info with status */}
             {!editMode && (
               <div className="p-show-post__meta">
@@ -389,6 +443,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
                   <Trans id="showpost.message.nodescription">No description provided.</Trans>
                 </em>
               )}
+              <Reactions reactions={post.reactionCounts} emojiSelectorRef={emojiSelectorRef} toggleReaction={togglePostReaction} />
             </div>
           ) : (
             <div className="p-show-post__description-section">
@@ -411,6 +466,30 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
             </div>
           )}
 
+          {!editMode && (
+            <div className="pt-7">
+              <PollsPanel post={post} />
+            </div>
+          )}
+
+          {!editMode && (
+            <div className="pt-7">
+              <AuthorsPanel post={post} onDataChanged={props.onDataChanged} />
+            </div>
+          )}
+
+          {!editMode && (
+            <div className="pt-7">
+              <LinksPanel post={post} />
+            </div>
+          )}
+
+          {!editMode && Fider.session.isAuthenticated && Fider.session.user.isCollaborator && (
+            <div className="pt-7">
+              <PostAnalyticsPanel post={post} />
+            </div>
+          )}
+
           {tags.length >= 1 && (
             <div className="pt-7">
               <TagsPanel post={post} tags={tags} onDataChanged={props.onDataChanged} />
@@ -423,7 +502,7 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
               <VoteSection
                 post={post}
                 votes={post.votesCount}
-                commentsCount={comments.length}
+                commentsCount={flattenComments(comments).length}
                 onDataChanged={props.onDataChanged}
               />
             </div>
@@ -478,6 +557,15 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
                         <Trans id="action.pinpost">Pin post</Trans>
                       </ActionButton>
                     )}
+                    {post.lockedAt ? (
+                      <ActionButton icon={IconLockClosed} onClick={onActionSelected("unlock")}>
+                        <Trans id="action.unlockpost">Unlock post</Trans>
+                      </ActionButton>
+                    ) : (
+                      <ActionButton icon={IconLockClosed} onClick={onActionSelected("lock")}>
+                        <Trans id="action.lockpost">Lock post</Trans>
+                      </ActionButton>
+                    )}
                     <ActionButton icon={IconChat} onClick={onActionSelected("status")}>
                       <Trans id="action.changestatus">Change status</Trans>
                     </ActionButton>
@@ -520,17 +608,28 @@ export const PostDetails: React.FC<PostDetailsProps> = (props) => {
             </h2>
           </HStack>
 
+          {/* Locked posts only take comments from staff */}
+          {post.lockedAt && (
+            <div className="text-muted text-sm p-3 bg-gray-50 rounded-md mt-2">
+              <Trans id="showpost.locked.message">This post has been locked and no longer accepts comments, votes or reactions.</Trans>
+              {post.lockReason && <p className="mt-1">{post.lockReason}</p>}
+            </div>
+          )}
+
           {/* Comment Input at top */}
-          <CommentInput post={post} />
+          {(!post.lockedAt || (Fider.session.isAuthenticated && Fider.session.user.isCollaborator)) && <CommentInput post={post} />}
 
           {/* Response Details - First discussion item */}
           {post.response && <ResponseDetails status={post.status} response={post.response} />}
 
+          {/* Accepted answer, repeated ahead of the other comments */}
+          {post.acceptedAnswer && <AcceptedAnswer answer={post.acceptedAnswer} />}
+
           {/* Comments List */}
           {comments.length > 0 && (
             <VStack spacing={4}>
               {comments.map((c) => (
-                <ShowComment key={c.id} post={post} comment={c} highlighted={highlightedComment === c.id} />
+                <ShowComment key={c.id} post={post} comment={c} highlightedComment={highlightedComment} />
               ))}
             </VStack>
           )}
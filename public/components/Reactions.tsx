@@ -1,9 +1,9 @@
 import React, { useEffect, useState } from "react"
-import { ReactionCount } from "@fider/models"
+import { ReactionCount, ReactionType } from "@fider/models"
 import { Icon } from "@fider/components"
 import ReactionAdd from "@fider/assets/images/reaction-add.svg"
 import { HStack } from "@fider/components/layout"
-import { classSet } from "@fider/services"
+import { classSet, uploadedImageURL } from "@fider/services"
 import { useFider } from "@fider/hooks"
 import "./Reactions.scss"
 
@@ -13,11 +13,30 @@ interface ReactionsProps {
   reactions?: ReactionCount[]
 }
 
-const availableEmojis = ["👍", "👎", "😄", "🎉", "😕", "❤️", "🚀", "👀"]
+const defaultReactions: ReactionType[] = [
+  { emoji: "👍", name: "+1" },
+  { emoji: "👎", name: "-1" },
+  { emoji: "😄", name: "Laugh" },
+  { emoji: "🎉", name: "Hooray" },
+  { emoji: "😕", name: "Confused" },
+  { emoji: "❤️", name: "Heart" },
+  { emoji: "🚀", name: "Rocket" },
+  { emoji: "👀", name: "Eyes" },
+]
+
+const ReactionEmoji = (props: { emoji: string; reaction?: ReactionType }) => {
+  if (props.reaction && props.reaction.imageBlobKey) {
+    return <img className="c-reactions-image" src={uploadedImageURL(props.reaction.imageBlobKey, 50)} alt={props.reaction.name} />
+  }
+  return <>{props.emoji}</>
+}
 
 export const Reactions: React.FC<ReactionsProps> = ({ emojiSelectorRef, toggleReaction, reactions }) => {
   const fider = useFider()
   const [isEmojiSelectorOpen, setIsEmojiSelectorOpen] = useState(false)
+  const palette = fider.session.tenant.reactions
+  const availableReactions = palette && palette.length > 0 ? palette : defaultReactions
+  const findReaction = (emoji: string) => availableReactions.find((r) => r.emoji === emoji)
 
   useEffect(() => {
     const handleClickOutside = (event: MouseEvent) => {
@@ -45,16 +64,17 @@ export const Reactions: React.FC<ReactionsProps> = ({ emojiSelectorRef, toggleRe
             </span>
             {isEmojiSelectorOpen && (
               <div className="c-reactions-emojis p-2 absolute bg-white border rounded shadow-lg">
-                {availableEmojis.map((emoji) => (
+                {availableReactions.map((reaction) => (
                   <a
-                    key={emoji}
+                    key={reaction.emoji}
+                    title={reaction.name}
                     className="clickable p-2 hover:bg-gray-100"
                     onClick={() => {
-                      toggleReaction(emoji)
+                      toggleReaction(reaction.emoji)
                       setIsEmojiSelectorOpen(false)
                     }}
                   >
-                    {emoji}
+                    <ReactionEmoji emoji={reaction.emoji} reaction={reaction} />
                   </a>
                 ))}
               </div>
@@ -66,6 +86,7 @@ export const Reactions: React.FC<ReactionsProps> = ({ emojiSelectorRef, toggleRe
             {reactions.map((reaction) => (
               <span
                 key={reaction.emoji}
+                title={findReaction(reaction.emoji)?.name}
                 {...(fider.session.isAuthenticated && { onClick: () => toggleReaction(reaction.emoji) })}
                 className={classSet({
                   "inline-flex items-center px-2 py-1 rounded-full text-xs": true,
@@ -75,7 +96,7 @@ export const Reactions: React.FC<ReactionsProps> = ({ emojiSelectorRef, toggleRe
                   "clickable hover:bg-gray-200": fider.session.isAuthenticated && !reaction.includesMe,
                 })}
               >
-                {reaction.emoji} <span className="ml-1 text-semibold">{reaction.count}</span>
+                <ReactionEmoji emoji={reaction.emoji} reaction={findReaction(reaction.emoji)} /> <span className="ml-1 text-semibold">{reaction.count}</span>
               </span>
             ))}
           </>
@@ -29,13 +29,17 @@ export const ResponseDetails = (props: PostResponseProps): JSX.Element | null =>
 
   return (
     <HStack spacing={4} align="start" className="c-response-details">
-      <Avatar user={props.response.user} size="large" />
+      {props.response.user && <Avatar user={props.response.user} size="large" />}
       <div className="c-response-details__card">
         <div className="c-response-details__inner">
           <VStack spacing={2}>
             <HStack spacing={2} align="center">
-              <UserName user={props.response.user} />
-              <span className="text-xs text-gray-600">•</span>
+              {props.response.user && (
+                <>
+                  <UserName user={props.response.user} />
+                  <span className="text-xs text-gray-600">•</span>
+                </>
+              )}
               <Moment className="text-xs text-gray-600" locale={fider.currentLocale} date={props.response.respondedAt} />
               <ResponseLozenge status={props.status} response={props.response} size="xsmall" />
             </HStack>
@@ -227,6 +227,8 @@ interface CommentEditorProps {
   onGetImageSrc?: (bkey: string) => string
   maxAttachments?: number
   maxImageSizeKB?: number
+  // isInternal only suggests staff on mentions
+  isInternal?: boolean
 }
 
 const Tiptap: React.FunctionComponent<CommentEditorProps> = (props) => {
@@ -241,6 +243,10 @@ const Tiptap: React.FunctionComponent<CommentEditorProps> = (props) => {
   // This avoids the async state update issue and prevents unnecessary re-renders
   const documentImagesRef = useRef<Map<string, boolean>>(new Map())
 
+  // The editor is created once, so mentions read whether the comment is internal from a ref
+  const isInternalRef = useRef(!!props.isInternal)
+  isInternalRef.current = !!props.isInternal
+
   const [editorContent, setEditorContent] = useState(props.initialValue ?? "")
 
   const toggleMarkdownMode = () => {
@@ -467,7 +473,7 @@ const Tiptap: React.FunctionComponent<CommentEditorProps> = (props) => {
       HTMLAttributes: {
         class: "mention",
       },
-      suggestion,
+      suggestion: suggestion(() => isInternalRef.current),
     }),
     CustomImage.configure({
       HTMLAttributes: {},
@@ -645,7 +651,7 @@ const Tiptap: React.FunctionComponent<CommentEditorProps> = (props) => {
 }
 
 const CommentEditor = React.memo(Tiptap, (prevProps, nextProps) => {
-  return prevProps.placeholder === nextProps.placeholder
+  return prevProps.placeholder === nextProps.placeholder && prevProps.isInternal === nextProps.isInternal
 })
 
 export default CommentEditor
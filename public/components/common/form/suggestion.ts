@@ -8,21 +8,25 @@ interface MentionListProps {
   command?: (item: MentionNodeAttrs) => void
 }
 
-// Cache for storing users
-let cachedUsers: MentionNodeAttrs[] = []
+// Cache for storing users, internal notes only suggest staff
+const cachedUsers: { [key: string]: MentionNodeAttrs[] } = {}
 
-export default {
+export default (isInternal: () => boolean) => ({
   items: async ({ query }: { query: string }) => {
+    const internal = isInternal()
+    const key = internal ? "staff" : "all"
+
     // If we don't have cached users yet, fetch them
-    if (cachedUsers.length === 0) {
-      const result = await actions.getTaggableUsers("")
-      if (result.ok) {
-        cachedUsers = result.data.map((user, idx) => ({ id: idx.toString(), label: user.name }))
+    if (!cachedUsers[key]) {
+      const result = await actions.getTaggableUsers("", internal)
+      if (!result.ok) {
+        return []
       }
+      cachedUsers[key] = result.data.map((user, idx) => ({ id: idx.toString(), label: user.name }))
     }
 
     // Filter the cached users based on the query
-    return cachedUsers.filter((item) => item.label?.toLowerCase().startsWith(query.toLowerCase())).slice(0, 100)
+    return cachedUsers[key].filter((item) => item.label?.toLowerCase().startsWith(query.toLowerCase())).slice(0, 100)
   },
   render: () => {
     let reactRenderer: ReactRenderer<MentionListHandle, MentionListProps>
@@ -149,4 +153,4 @@ export default {
       },
     }
   },
-}
+})
@@ -15,6 +15,17 @@ export interface Tenant {
   isFeedEnabled: boolean
   isModerationEnabled: boolean
   hasCommercialFeatures: boolean
+  voteBudget: number
+  isVoteImportanceEnabled: boolean
+  voteRoleWeights: { [role: string]: number }
+  reactions?: ReactionType[]
+  isAnonymousPostingEnabled: boolean
+}
+
+export interface ReactionType {
+  emoji: string
+  name: string
+  imageBlobKey?: string
 }
 
 export enum TenantStatus {
@@ -1,4 +1,4 @@
-import { User } from "./identity"
+import { User, UserRole } from "./identity"
 
 export interface Post {
   id: number
@@ -17,6 +17,35 @@ export interface Post {
   isApproved: boolean
   pinnedAt?: string
   pinnedBy?: User
+  customFields?: { [key: string]: string }
+  boardId?: number
+  boardSlug?: string
+  isAnonymous: boolean
+  lockedAt?: string
+  lockedBy?: User
+  lockReason?: string
+  coAuthorIds?: number[]
+  viewsCount?: number
+  links?: PostLink[]
+  acceptedAnswer?: PostAnswer
+  reactionCounts?: ReactionCount[]
+}
+
+export interface PostAnswer {
+  commentId: number
+  content: string
+  user: User
+  acceptedAt: string
+}
+
+export interface PostLink {
+  id: number
+  type: "github" | "gitlab" | "jira" | "url"
+  externalId: string
+  url: string
+  state: "open" | "closed" | "merged"
+  createdAt: string
+  updatedAt: string
 }
 
 export class PostStatus {
@@ -42,16 +71,18 @@ export class PostStatus {
   public static All = [PostStatus.Open, PostStatus.Planned, PostStatus.Started, PostStatus.Completed, PostStatus.Duplicate, PostStatus.Declined]
 }
 
+export interface OriginalPost {
+  number: number
+  title: string
+  slug: string
+  status: string
+}
+
 export interface PostResponse {
-  user: User
+  user?: User
   text: string
   respondedAt: Date
-  original?: {
-    number: number
-    title: string
-    slug: string
-    status: string
-  }
+  original?: OriginalPost
 }
 
 export interface ReactionCount {
@@ -73,6 +104,15 @@ export interface Comment {
   flagsCount?: number
   pinnedAt?: string
   pinnedBy?: User
+  parentId?: number
+  isDeleted?: boolean
+  replies?: Comment[]
+  isInternal?: boolean
+}
+
+// flattenComments returns all comments of a thread, replies included, except tombstones
+export const flattenComments = (comments: Comment[]): Comment[] => {
+  return comments.flatMap((c) => [...(c.isDeleted ? [] : [c]), ...flattenComments(c.replies || [])])
 }
 
 export interface Tag {
@@ -81,10 +121,112 @@ export interface Tag {
   name: string
   color: string
   isPublic: boolean
+  groupId?: number
+  parentId?: number
+}
+
+export interface TagSubscription {
+  tag: Tag
+  // Same bitmask as notification settings: 1 = web, 2 = email
+  channels: number
+  includeComments: boolean
 }
 
+export interface TagStats {
+  tag: Tag
+  postCount: number
+  votesCount: number
+  openCount: number
+  completedCount: number
+  openRatio: number
+  completedRatio: number
+  lastUsedAt?: string
+}
+
+export interface TagGroup {
+  id: number
+  name: string
+  slug: string
+  isSingleSelect: boolean
+}
+
+// sortTagsByHierarchy orders tags so that each parent is followed by its children
+export const sortTagsByHierarchy = (tags: Tag[]): { tag: Tag; depth: number }[] => {
+  const ids = new Set(tags.map((t) => t.id))
+  const walk = (parentId: number | undefined, depth: number): { tag: Tag; depth: number }[] =>
+    tags
+      .filter((t) => (depth === 0 ? !t.parentId || !ids.has(t.parentId) : t.parentId === parentId))
+      .flatMap((t) => [{ tag: t, depth }, ...walk(t.id, depth + 1)])
+  return walk(undefined, 0)
+}
+
+export interface Board {
+  id: number
+  name: string
+  slug: string
+  welcomeMessage: string
+  defaultTags: string[]
+  isModerationEnabled: boolean
+  isPrivate: boolean
+  position: number
+  staffIds: number[]
+}
+
+export interface SimilarPost extends Post {
+  confidence: number
+}
+
+export interface PostDuplicateCandidate {
+  id: number
+  post: OriginalPost
+  original: OriginalPost
+  score: number
+  createdAt: string
+}
+
+export interface PostTemplate {
+  id: number
+  name: string
+  content: string
+  requiredSections: string[]
+  tag?: string
+  board?: string
+  position: number
+}
+
+export interface PostRule {
+  id: number
+  name: string
+  isEnabled: boolean
+  position: number
+  keywords: string[]
+  pattern: string
+  matchIn: "" | "title" | "description"
+  emailDomains: string[]
+  roles: UserRole[]
+  boards: string[]
+  tags: string[]
+  status?: string
+  subscriberIds: number[]
+  flagForModeration: boolean
+}
+
+export interface PostRuleDryRun {
+  checked: number
+  posts: { number: number; title: string; slug: string }[]
+}
+
+export type VoteImportance = "nice-to-have" | "important" | "must-have"
+
 export interface Vote {
   createdAt: Date
+  importance: VoteImportance
+  weight: number
+  proxiedBy?: {
+    id: number
+    name: string
+  }
+  proxyNote?: string
   user: {
     id: number
     name: string
@@ -93,6 +235,60 @@ export interface Vote {
   }
 }
 
+export interface PostActivity {
+  date: string
+  views: number
+  votes: number
+  comments: number
+}
+
+export interface PostAnalytics {
+  viewsCount: number
+  votersCount: number
+  commentsCount: number
+  conversionRate: number
+  series: PostActivity[]
+}
+
+export interface PostLog {
+  id: number
+  action: string
+  details: string
+  userId?: number
+  userName?: string
+  createdAt: string
+}
+
+export interface ScheduledResponse {
+  id: number
+  status: string
+  text: string
+  scheduledAt?: string
+  createdBy: User
+  createdAt: string
+}
+
+export interface PollOption {
+  id: number
+  text: string
+  answersCount: number
+}
+
+export interface Poll {
+  id: number
+  postNumber: number
+  commentId?: number
+  question: string
+  isMultipleChoice: boolean
+  isVotersOnly: boolean
+  closesAt?: string
+  options: PollOption[]
+  votersCount: number
+  myAnswers: number[] | null
+  createdBy: User
+  createdAt: string
+}
+
 export interface InlineImage {
   bkey: string
   remove: boolean
@@ -23,6 +23,7 @@ export interface SystemSettings {
   oauth: OAuthProviderOption[]
   postWithTags: boolean
   allowAllowedSchemes: boolean
+  commentReplyMaxDepth: number
 }
 
 export interface UserSettings {
@@ -67,3 +68,12 @@ export enum EmailVerificationKind {
   ChangeEmail = 3,
   UserInvitation = 4,
 }
+
+export interface StalePostRules {
+  inactiveDays: number
+  graceDays: number
+  closeStatus: string
+  response: string
+  exemptTags: string[]
+  lockAfterDays: number
+}
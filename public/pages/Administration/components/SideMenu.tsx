@@ -50,6 +50,7 @@ export const SideMenu = (props: SiteMenuProps) => {
           <>
             <SideMenuItem name="flaggedPosts" title="Flagged Ideas" href="/admin/flagged" isActive={activeItem === "flaggedPosts"} />
             <SideMenuItem name="flagged" title="Flagged Comments" href="/admin/flagged-comments" isActive={activeItem === "flagged"} />
+            <SideMenuItem name="duplicatePosts" title="Possible Duplicates" href="/admin/duplicates" isActive={activeItem === "duplicatePosts"} />
           </>
         )}
         <SideMenuItem name="advanced" title="Advanced" href="/admin/advanced" isActive={activeItem === "advanced"} />
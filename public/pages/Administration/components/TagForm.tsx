@@ -1,5 +1,6 @@
 import React from "react"
-import { Button, Input, ShowTag, Form, RadioButton, Field, SelectOption } from "@fider/components"
+import { Button, Input, ShowTag, Form, RadioButton, Field, Select, SelectOption } from "@fider/components"
+import { Tag, TagGroup } from "@fider/models"
 import { Failure } from "@fider/services"
 import { HStack } from "@fider/components/layout"
 
@@ -7,6 +8,10 @@ interface TagFormProps {
   name?: string
   color?: string
   isPublic?: boolean
+  group?: string
+  parent?: string
+  groups?: TagGroup[]
+  parents?: Tag[]
   onSave: (data: TagFormState) => Promise<Failure | undefined>
   onCancel: () => void
 }
@@ -15,6 +20,8 @@ export interface TagFormState {
   name: string
   color: string
   isPublic: boolean
+  group: string
+  parent: string
   error?: Failure
 }
 
@@ -28,6 +35,8 @@ export class TagForm extends React.Component<TagFormProps, TagFormState> {
       color: props.color || this.getRandomColor(),
       name: props.name || "",
       isPublic: props.isPublic || false,
+      group: props.group || "",
+      parent: props.parent || "",
     }
   }
 
@@ -63,6 +72,14 @@ export class TagForm extends React.Component<TagFormProps, TagFormState> {
     this.setState({ isPublic: option === this.visibilityPublic })
   }
 
+  private setGroup = (option?: SelectOption) => {
+    this.setState({ group: option?.value || "" })
+  }
+
+  private setParent = (option?: SelectOption) => {
+    this.setState({ parent: option?.value || "" })
+  }
+
   private randomize = () => {
     this.setColor(this.getRandomColor())
   }
@@ -86,6 +103,20 @@ export class TagForm extends React.Component<TagFormProps, TagFormState> {
             options={[this.visibilityPublic, this.visibilityPrivate]}
             onSelect={this.setVisibility}
           />
+          <Select
+            field="group"
+            label="Group"
+            defaultValue={this.state.group}
+            options={[{ value: "", label: "None" }, ...(this.props.groups || []).map((g) => ({ value: g.slug, label: g.name }))]}
+            onChange={this.setGroup}
+          />
+          <Select
+            field="parent"
+            label="Parent"
+            defaultValue={this.state.parent}
+            options={[{ value: "", label: "None" }, ...(this.props.parents || []).map((t) => ({ value: t.slug, label: t.name }))]}
+            onChange={this.setParent}
+          />
           <Field label="Preview">
             <ShowTag
               tag={{
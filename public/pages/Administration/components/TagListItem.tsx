@@ -1,6 +1,6 @@
 import React, { useState } from "react"
-import { Tag } from "@fider/models"
-import { ShowTag, Button, Icon } from "@fider/components"
+import { Tag, TagGroup, TagStats } from "@fider/models"
+import { ShowTag, Button, Icon, Moment, Select } from "@fider/components"
 import { TagFormState, TagForm } from "./TagForm"
 import { actions, Failure } from "@fider/services"
 import { useFider } from "@fider/hooks"
@@ -11,16 +11,22 @@ import { HStack, VStack } from "@fider/components/layout"
 
 interface TagListItemProps {
   tag: Tag
+  tags: Tag[]
+  tagGroups: TagGroup[]
+  stats?: TagStats
   onTagEdited: (tag: Tag) => void
   onTagDeleted: (tag: Tag) => void
+  onTagMerged: (from: Tag, into: Tag) => void
 }
 
 export const TagListItem = (props: TagListItemProps) => {
   const fider = useFider()
   const [tag] = useState(props.tag)
-  const [state, setState] = useState<"view" | "edit" | "delete">("view")
+  const [state, setState] = useState<"view" | "edit" | "delete" | "merge">("view")
+  const [mergeInto, setMergeInto] = useState("")
 
   const startDelete = async () => setState("delete")
+  const startMerge = async () => setState("merge")
   const startEdit = async () => setState("edit")
   const resetState = async () => setState("view")
 
@@ -32,13 +38,23 @@ export const TagListItem = (props: TagListItemProps) => {
     }
   }
 
+  const mergeTag = async () => {
+    const result = await actions.mergeTags(tag.slug, mergeInto)
+    if (result.ok) {
+      resetState()
+      props.onTagMerged(tag, result.data)
+    }
+  }
+
   const updateTag = async (data: TagFormState): Promise<Failure | undefined> => {
-    const result = await actions.updateTag(tag.slug, data.name, data.color, data.isPublic)
+    const result = await actions.updateTag(tag.slug, data.name, data.color, data.isPublic, data.group, data.parent)
     if (result.ok) {
       tag.name = result.data.name
       tag.slug = result.data.slug
       tag.color = result.data.color
       tag.isPublic = result.data.isPublic
+      tag.groupId = result.data.groupId
+      tag.parentId = result.data.parentId
 
       resetState()
       props.onTagEdited(tag)
@@ -68,13 +84,38 @@ export const TagListItem = (props: TagListItemProps) => {
     )
   }
 
+  const renderMergeMode = () => {
+    const options = props.tags.filter((t) => t.id !== tag.id).map((t) => ({ value: t.slug, label: t.name }))
+    return (
+      <VStack spacing={2}>
+        <div>
+          <span>
+            Merge <ShowTag tag={tag} /> into another tag. Its posts move to the chosen tag and links to it keep working.
+          </span>
+        </div>
+        <Select field="into" options={[{ value: "", label: "Select a tag" }, ...options]} onChange={(option) => setMergeInto(option?.value || "")} />
+        <div>
+          <Button variant="danger" onClick={mergeTag} disabled={!mergeInto}>
+            Merge tag
+          </Button>
+          <Button onClick={resetState} variant="tertiary">
+            Cancel
+          </Button>
+        </div>
+      </VStack>
+    )
+  }
+
   const renderViewMode = () => {
     const buttons = fider.session.user.isAdministrator && [
       <Button size="small" key={0} onClick={startEdit}>
         <Icon sprite={IconPencilAlt} />
         <span>Edit</span>
       </Button>,
-      <Button size="small" key={1} onClick={startDelete}>
+      <Button size="small" key={1} onClick={startMerge}>
+        <span>Merge</span>
+      </Button>,
+      <Button size="small" key={2} onClick={startDelete}>
         <Icon sprite={IconX} />
         <span>Delete</span>
       </Button>,
@@ -82,15 +123,43 @@ export const TagListItem = (props: TagListItemProps) => {
 
     return (
       <HStack justify="between">
-        <ShowTag tag={tag} link />
+        <VStack spacing={1}>
+          <ShowTag tag={tag} link />
+          {props.stats && (
+            <span className="text-muted text-xs">
+              {props.stats.postCount} posts · {props.stats.votesCount} votes · {Math.round(props.stats.openRatio * 100)}% open ·{" "}
+              {Math.round(props.stats.completedRatio * 100)}% completed
+              {props.stats.lastUsedAt && (
+                <>
+                  {" "}
+                  · last used <Moment locale={fider.currentLocale} date={props.stats.lastUsedAt} />
+                </>
+              )}
+            </span>
+          )}
+        </VStack>
         <HStack>{buttons}</HStack>
       </HStack>
     )
   }
 
   const renderEditMode = () => {
-    return <TagForm name={props.tag.name} color={props.tag.color} isPublic={props.tag.isPublic} onSave={updateTag} onCancel={resetState} />
+    const group = props.tagGroups.find((g) => g.id === tag.groupId)
+    const parent = props.tags.find((t) => t.id === tag.parentId)
+    return (
+      <TagForm
+        name={props.tag.name}
+        color={props.tag.color}
+        isPublic={props.tag.isPublic}
+        group={group?.slug}
+        parent={parent?.slug}
+        groups={props.tagGroups}
+        parents={props.tags.filter((t) => t.id !== tag.id)}
+        onSave={updateTag}
+        onCancel={resetState}
+      />
+    )
   }
 
-  return state === "delete" ? renderDeleteMode() : state === "edit" ? renderEditMode() : renderViewMode()
+  return state === "delete" ? renderDeleteMode() : state === "merge" ? renderMergeMode() : state === "edit" ? renderEditMode() : renderViewMode()
 }
@@ -1,12 +1,13 @@
 import React from "react"
 
-import { TextArea, Form, Button } from "@fider/components"
+import { TextArea, Input, Form, Button } from "@fider/components"
 import { Failure, actions, Fider } from "@fider/services"
 import { AdminBasePage } from "../components/AdminBasePage"
 
 interface AdvancedSettingsPageProps {
   customCSS: string
   allowedSchemes: string
+  issueWebhookSecret: string
   licenseKey: string
   hasCommercialFeatures: boolean
 }
@@ -14,6 +15,7 @@ interface AdvancedSettingsPageProps {
 interface AdvancedSettingsPageState {
   customCSS: string
   allowedSchemes: string
+  issueWebhookSecret: string
   error?: Failure
   copied: boolean
 }
@@ -30,6 +32,7 @@ export default class AdvancedSettingsPage extends AdminBasePage<AdvancedSettings
     this.state = {
       customCSS: this.props.customCSS,
       allowedSchemes: this.props.allowedSchemes,
+      issueWebhookSecret: this.props.issueWebhookSecret,
       copied: false,
     }
   }
@@ -42,8 +45,12 @@ export default class AdvancedSettingsPage extends AdminBasePage<AdvancedSettings
     this.setState({ allowedSchemes })
   }
 
+  private setIssueWebhookSecret = (issueWebhookSecret: string): void => {
+    this.setState({ issueWebhookSecret })
+  }
+
   private handleSave = async (): Promise<void> => {
-    const result = await actions.updateTenantAdvancedSettings(this.state.customCSS, this.state.allowedSchemes)
+    const result = await actions.updateTenantAdvancedSettings(this.state.customCSS, this.state.allowedSchemes, this.state.issueWebhookSecret)
     if (result.ok) {
       location.reload()
     } else {
@@ -129,6 +136,22 @@ export default class AdvancedSettingsPage extends AdminBasePage<AdvancedSettings
           </TextArea>
         )}
 
+        <Input
+          field="issueWebhookSecret"
+          label="Issue Tracker Webhook Secret"
+          disabled={!Fider.session.user.isAdministrator}
+          maxLength={100}
+          value={this.state.issueWebhookSecret}
+          onChange={this.setIssueWebhookSecret}
+        >
+          <p className="text-muted">
+            Posts linked to GitHub or GitLab issues are marked as completed when all of them are closed or merged.
+            <br />
+            Add a webhook pointing to <code>{Fider.settings.baseURL}/webhooks/github</code> or <code>{Fider.settings.baseURL}/webhooks/gitlab</code> using this
+            value as its secret. Webhooks are rejected while it&apos;s empty.
+          </p>
+        </Input>
+
         {Fider.session.user.isAdministrator && (
           <div className="field">
             <Button variant="primary" onClick={this.handleSave}>
@@ -0,0 +1,95 @@
+import React, { useEffect, useState } from "react"
+import { AdminPageContainer } from "@fider/pages/Administration/components/AdminBasePage"
+import { Button } from "@fider/components"
+import { actions, notify } from "@fider/services"
+import { PostDuplicateCandidate, PostStatus } from "@fider/models"
+import { HStack, VStack } from "@fider/components/layout"
+import { Trans } from "@lingui/react/macro"
+
+const DuplicatePostsPage = () => {
+  const [items, setItems] = useState<PostDuplicateCandidate[]>([])
+  const [loading, setLoading] = useState(true)
+
+  useEffect(() => {
+    actions.listPostDuplicateCandidates().then((result) => {
+      if (result.ok && Array.isArray(result.data)) {
+        setItems(result.data)
+      }
+      setLoading(false)
+    })
+  }, [])
+
+  const removeItem = (item: PostDuplicateCandidate) => {
+    setItems(items.filter((i) => i.id !== item.id && i.post.number !== item.post.number))
+  }
+
+  const handleMerge = async (item: PostDuplicateCandidate) => {
+    const result = await actions.respond(item.post.number, {
+      status: PostStatus.Duplicate.value,
+      text: "",
+      originalNumber: item.original.number,
+    })
+    if (result.ok) {
+      removeItem(item)
+    } else if (result.error) {
+      notify.error(result.error.errors?.[0]?.message ?? "Failed to merge posts")
+    }
+  }
+
+  const handleDismiss = async (item: PostDuplicateCandidate) => {
+    const result = await actions.dismissPostDuplicateCandidate(item.id)
+    if (result.ok) {
+      setItems(items.filter((i) => i.id !== item.id))
+    }
+  }
+
+  return (
+    <AdminPageContainer
+      id="p-admin-duplicate-posts"
+      name="duplicatePosts"
+      title="Possible Duplicates"
+      subtitle="Pairs of ideas with similar titles that may be merged"
+    >
+      {loading ? (
+        <p className="text-muted">
+          <Trans id="showpost.loading">Loading...</Trans>
+        </p>
+      ) : items.length === 0 ? (
+        <p className="text-muted">
+          <Trans id="admin.duplicateposts.empty">No possible duplicates found.</Trans>
+        </p>
+      ) : (
+        <VStack spacing={4}>
+          {items.map((item) => (
+            <div key={item.id} className="p-4 border rounded-md bg-white shadow-sm">
+              <HStack justify="between" align="start">
+                <VStack spacing={2} align="start">
+                  <a href={`/posts/${item.post.number}/${item.post.slug}`} className="text-sm font-medium text-green-700 hover:underline">
+                    {item.post.title} #{item.post.number}
+                  </a>
+                  <span className="text-xs text-gray-500">
+                    <Trans id="admin.duplicateposts.similarto">similar to</Trans>{" "}
+                    <a href={`/posts/${item.original.number}/${item.original.slug}`} className="hover:underline">
+                      {item.original.title} #{item.original.number}
+                    </a>{" "}
+                    ({Math.round(item.score * 100)}%)
+                  </span>
+                </VStack>
+                <HStack spacing={2}>
+                  <Button variant="primary" size="small" onClick={() => handleMerge(item)}>
+                    <Trans id="admin.duplicateposts.merge">Merge</Trans>
+                  </Button>
+                  <Button variant="tertiary" size="small" onClick={() => handleDismiss(item)}>
+                    <Trans id="admin.duplicateposts.dismiss">Dismiss</Trans>
+                  </Button>
+                </HStack>
+              </HStack>
+            </div>
+          ))}
+        </VStack>
+      )}
+    </AdminPageContainer>
+  )
+}
+
+export default DuplicatePostsPage
@@ -23,6 +23,15 @@ export default class ExportPage extends AdminBasePage<any, any> {
           <span>posts.csv</span>
         </Button>
 
+        <div className="mt-8">
+          <h2 className="text-display">Export Polls</h2>
+          <p className="text-muted">Use this button to download a CSV file with the results of all polls, with one row per option.</p>
+          <Button variant="secondary" href="/admin/export/polls.csv">
+            <Icon sprite={IconDownload} />
+            <span>polls.csv</span>
+          </Button>
+        </div>
+
         <div className="mt-8">
           <h2 className="text-display">Backup your data</h2>
           <p className="text-muted">
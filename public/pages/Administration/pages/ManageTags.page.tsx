@@ -1,20 +1,26 @@
 import React from "react"
-import { Button } from "@fider/components"
+import { Button, Checkbox, Form, Input } from "@fider/components"
 
-import { Tag } from "@fider/models"
+import { Tag, TagGroup, TagStats } from "@fider/models"
 import { actions, Failure, Fider } from "@fider/services"
 import { AdminBasePage } from "../components/AdminBasePage"
 import { TagFormState, TagForm } from "../components/TagForm"
 import { TagListItem } from "../components/TagListItem"
-import { VStack } from "@fider/components/layout"
+import { HStack, VStack } from "@fider/components/layout"
 
 interface ManageTagsPageProps {
   tags: Tag[]
+  tagGroups: TagGroup[]
+  tagStats: TagStats[]
 }
 
 interface ManageTagsPageState {
   isAdding: boolean
   allTags: Tag[]
+  allTagGroups: TagGroup[]
+  newGroupName: string
+  newGroupIsSingleSelect: boolean
+  groupError?: Failure
   deleting?: number
   editing?: number
 }
@@ -39,6 +45,9 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
     this.state = {
       isAdding: false,
       allTags: this.props.tags,
+      allTagGroups: this.props.tagGroups || [],
+      newGroupName: "",
+      newGroupIsSingleSelect: false,
     }
   }
 
@@ -55,7 +64,7 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
   }
 
   private saveNewTag = async (data: TagFormState): Promise<Failure | undefined> => {
-    const result = await actions.createTag(data.name, data.color, data.isPublic)
+    const result = await actions.createTag(data.name, data.color, data.isPublic, data.group, data.parent)
     if (result.ok) {
       this.setState({
         isAdding: false,
@@ -66,6 +75,30 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
     }
   }
 
+  private saveNewTagGroup = async () => {
+    const result = await actions.createTagGroup(this.state.newGroupName, this.state.newGroupIsSingleSelect)
+    if (result.ok) {
+      this.setState({
+        allTagGroups: this.state.allTagGroups.concat(result.data),
+        newGroupName: "",
+        newGroupIsSingleSelect: false,
+        groupError: undefined,
+      })
+    } else {
+      this.setState({ groupError: result.error })
+    }
+  }
+
+  private deleteTagGroup = async (group: TagGroup) => {
+    const result = await actions.deleteTagGroup(group.slug)
+    if (result.ok) {
+      this.setState({
+        allTagGroups: this.state.allTagGroups.filter((g) => g.id !== group.id),
+        allTags: this.state.allTags.map((t) => (t.groupId === group.id ? { ...t, groupId: undefined } : t)),
+      })
+    }
+  }
+
   private handleTagDeleted = (tag: Tag) => {
     const idx = this.state.allTags.indexOf(tag)
     this.setState({
@@ -73,6 +106,12 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
     })
   }
 
+  private handleTagMerged = (from: Tag, into: Tag) => {
+    this.setState({
+      allTags: this.state.allTags.filter((t) => t.id !== from.id).map((t) => (t.parentId === from.id ? { ...t, parentId: into.id } : t)),
+    })
+  }
+
   private handleTagEdited = () => {
     this.setState({
       allTags: this.state.allTags.sort(tagSorter),
@@ -81,7 +120,18 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
 
   private getTagList(filter: (tag: Tag) => boolean) {
     return this.state.allTags.filter(filter).map((t) => {
-      return <TagListItem key={t.id} tag={t} onTagDeleted={this.handleTagDeleted} onTagEdited={this.handleTagEdited} />
+      return (
+        <TagListItem
+          key={t.id}
+          tag={t}
+          tags={this.state.allTags}
+          tagGroups={this.state.allTagGroups}
+          stats={(this.props.tagStats || []).find((s) => s.tag.id === t.id)}
+          onTagDeleted={this.handleTagDeleted}
+          onTagMerged={this.handleTagMerged}
+          onTagEdited={this.handleTagEdited}
+        />
+      )
     })
   }
 
@@ -92,7 +142,7 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
     const form =
       Fider.session.user.isAdministrator &&
       (this.state.isAdding ? (
-        <TagForm onSave={this.saveNewTag} onCancel={this.cancelAdd} />
+        <TagForm groups={this.state.allTagGroups} parents={this.state.allTags} onSave={this.saveNewTag} onCancel={this.cancelAdd} />
       ) : (
         <Button variant="secondary" onClick={this.addNew}>
           Add new
@@ -116,6 +166,39 @@ export default class ManageTagsPage extends AdminBasePage<ManageTagsPageProps, M
           </VStack>
         </div>
         <div>{form}</div>
+        <div>
+          <h2 className="text-display">Tag Groups</h2>
+          <p className="text-muted">Groups organize tags by topic. On single-select groups, a post can have at most one tag of the group.</p>
+          <VStack spacing={4} divide={true}>
+            {this.state.allTagGroups.length === 0 ? (
+              <p className="text-muted">There aren’t any tag groups yet.</p>
+            ) : (
+              this.state.allTagGroups.map((g) => (
+                <HStack key={g.id} justify="between">
+                  <span>
+                    {g.name} {g.isSingleSelect && <span className="text-muted text-xs">(single-select)</span>}
+                  </span>
+                  {Fider.session.user.isAdministrator && (
+                    <Button size="small" onClick={() => this.deleteTagGroup(g)}>
+                      Delete
+                    </Button>
+                  )}
+                </HStack>
+              ))
+            )}
+          </VStack>
+        </div>
+        {Fider.session.user.isAdministrator && (
+          <Form error={this.state.groupError}>
+            <Input field="name" label="Group name" value={this.state.newGroupName} onChange={(newGroupName) => this.setState({ newGroupName })} />
+            <Checkbox field="isSingleSelect" checked={this.state.newGroupIsSingleSelect} onChange={(newGroupIsSingleSelect) => this.setState({ newGroupIsSingleSelect })}>
+              Allow only one tag of this group per post
+            </Checkbox>
+            <Button variant="secondary" onClick={this.saveNewTagGroup}>
+              Add group
+            </Button>
+          </Form>
+        )}
       </VStack>
     )
   }
@@ -7,6 +7,7 @@ export interface PrivacySettingsPageState {
   isPrivate: boolean
   isFeedEnabled: boolean
   isModerationEnabled: boolean
+  isAnonymousPostingEnabled: boolean
 }
 
 export default class PrivacySettingsPage extends AdminBasePage<any, PrivacySettingsPageState> {
@@ -22,15 +23,17 @@ export default class PrivacySettingsPage extends AdminBasePage<any, PrivacySetti
       isPrivate: Fider.session.tenant.isPrivate,
       isFeedEnabled: Fider.session.tenant.isFeedEnabled,
       isModerationEnabled: Fider.session.tenant.isModerationEnabled,
+      isAnonymousPostingEnabled: Fider.session.tenant.isAnonymousPostingEnabled,
     }
   }
 
-  private updatePrivacySettings = async (isPrivate: boolean, isFeedEnabled: boolean, isModerationEnabled?: boolean) => {
+  private updatePrivacySettings = async (isPrivate: boolean, isFeedEnabled: boolean, isModerationEnabled?: boolean, isAnonymousPostingEnabled?: boolean) => {
     this.setState(
       {
         isPrivate,
         isFeedEnabled: isPrivate ? false : isFeedEnabled, // Disable feed if site is private
         isModerationEnabled: isModerationEnabled !== undefined ? isModerationEnabled : this.state.isModerationEnabled,
+        isAnonymousPostingEnabled: isAnonymousPostingEnabled !== undefined ? isAnonymousPostingEnabled : this.state.isAnonymousPostingEnabled,
       },
       async () => {
         const response = await actions.updateTenantPrivacy(this.state)
@@ -53,6 +56,10 @@ export default class PrivacySettingsPage extends AdminBasePage<any, PrivacySetti
     this.updatePrivacySettings(this.state.isPrivate, this.state.isFeedEnabled, enabled)
   }
 
+  private anonymousPostingToggle = async (enabled: boolean) => {
+    this.updatePrivacySettings(this.state.isPrivate, this.state.isFeedEnabled, this.state.isModerationEnabled, enabled)
+  }
+
   public content() {
     return (
       <Form>
@@ -80,6 +87,13 @@ export default class PrivacySettingsPage extends AdminBasePage<any, PrivacySetti
             </p>
           </Field>
         )}
+        <Field label="Anonymous Posting">
+          <Toggle disabled={!Fider.session.user.isAdministrator} active={this.state.isAnonymousPostingEnabled} onToggle={this.anonymousPostingToggle} />
+          <p className="text-muted mt-1">
+            When enabled, users can choose to publish their posts anonymously. <br /> The author's name is hidden from other users, feeds and webhooks, but is
+            still known to administrators, who can reveal it when needed. Every reveal is recorded in the post's history.
+          </p>
+        </Field>
       </Form>
     )
   }
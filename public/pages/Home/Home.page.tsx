@@ -4,7 +4,7 @@ import IconPlusCircle from "@fider/assets/images/heroicons-pluscircle.svg"
 import IconArrowLeft from "@fider/assets/images/heroicons-arrowleft.svg"
 
 import React, { useEffect, useState, useRef } from "react"
-import { Post, Tag, PostStatus } from "@fider/models"
+import { Post, Tag, TagGroup, PostStatus } from "@fider/models"
 import { Markdown, Hint, Icon, Header, Button } from "@fider/components"
 import { PostsContainer } from "./components/PostsContainer"
 import { useFider } from "@fider/hooks"
@@ -18,6 +18,7 @@ import { PostDetails } from "@fider/components/PostDetails"
 export interface HomePageProps {
   posts: Post[]
   tags: Tag[]
+  tagGroups?: TagGroup[]
   searchNoiseWords: string[]
   countPerStatus: { [key: string]: number }
 }
@@ -213,6 +214,7 @@ What can we do better? This is the place for you to vote, discuss and share idea
                   ref={postsContainerRef}
                   posts={props.posts}
                   tags={props.tags}
+                  tagGroups={props.tagGroups}
                   countPerStatus={props.countPerStatus}
                   onPostClick={handlePostClick}
                 />
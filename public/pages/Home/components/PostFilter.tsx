@@ -1,5 +1,5 @@
 import React, { useState } from "react"
-import { PostStatus, Tag } from "@fider/models"
+import { PostStatus, Tag, TagGroup, sortTagsByHierarchy } from "@fider/models"
 import { Checkbox, Dropdown, Icon } from "@fider/components"
 import { HStack } from "@fider/components/layout"
 import HeroIconFilter from "@fider/assets/images/heroicons-filter.svg"
@@ -9,13 +9,15 @@ import { FilterState } from "./PostsContainer"
 
 import "./PostFilter.scss"
 
-type FilterType = "tag" | "status" | "myVotes" | "noTags" | "myPosts"
+type FilterType = "tag" | "status" | "myVotes" | "noTags" | "myPosts" | "answered"
 
 interface OptionItem {
   value: string | boolean
   label: string
   count?: number
   type: FilterType
+  groupId?: number
+  depth?: number
 }
 
 interface PostFilterProps {
@@ -23,6 +25,7 @@ interface PostFilterProps {
   countPerStatus: { [key: string]: number }
   filtersChanged: (filter: FilterState) => void
   tags: Tag[]
+  tagGroups: TagGroup[]
 }
 
 export interface FilterItem {
@@ -47,11 +50,14 @@ const FilterStateToFilterItems = (filterState: FilterState): FilterItem[] => {
   if (filterState.myPosts) {
     filterItems.push({ type: "myPosts", value: true })
   }
+  if (filterState.answered) {
+    filterItems.push({ type: "answered", value: filterState.answered })
+  }
   return filterItems
 }
 
 const FilterItemsToFilterState = (filterItems: FilterItem[]): FilterState => {
-  const filterState: FilterState = { tags: [], statuses: [], myVotes: false, noTags: false, myPosts: false }
+  const filterState: FilterState = { tags: [], statuses: [], myVotes: false, noTags: false, myPosts: false, answered: "" }
   filterItems.forEach((i) => {
     if (i.type === "tag") {
       filterState.tags.push(i.value as string)
@@ -63,6 +69,8 @@ const FilterItemsToFilterState = (filterItems: FilterItem[]): FilterState => {
       filterState.noTags = true
     } else if (i.type === "myPosts") {
       filterState.myPosts = true
+    } else if (i.type === "answered") {
+      filterState.answered = i.value as string
     }
   })
   return filterState
@@ -76,9 +84,11 @@ export const PostFilter = (props: PostFilterProps) => {
 
   const handleChangeFilter = (item: OptionItem) => () => {
     const exists = filterItems.find((i) => i.type === item.type && i.value === item.value)
+    // Answered and unanswered exclude each other
+    const others = item.type === "answered" ? filterItems.filter((i) => i.type !== "answered") : filterItems
     const newFilter = exists
       ? filterItems.filter((i) => !(i.type === item.type && i.value === item.value))
-      : [...filterItems, { type: item.type, value: item.value }]
+      : [...others, { type: item.type, value: item.value }]
 
     props.filtersChanged(FilterItemsToFilterState(newFilter))
     setQuery("")
@@ -109,6 +119,9 @@ export const PostFilter = (props: PostFilterProps) => {
     })
   }
 
+  options.push({ value: "answered", label: i18n._({ id: "home.postfilter.option.answered", message: "Answered" }), type: "answered" })
+  options.push({ value: "unanswered", label: i18n._({ id: "home.postfilter.option.unanswered", message: "Unanswered" }), type: "answered" })
+
   if (props.tags.length > 0) {
     options.push({
       value: true,
@@ -116,11 +129,13 @@ export const PostFilter = (props: PostFilterProps) => {
       type: "noTags",
     })
 
-    props.tags.forEach((t) => {
+    sortTagsByHierarchy(props.tags).forEach(({ tag, depth }) => {
       options.push({
-        label: t.name,
-        value: t.slug,
+        label: tag.name,
+        value: tag.slug,
         type: "tag",
+        groupId: props.tagGroups.some((g) => g.id === tag.groupId) ? tag.groupId : undefined,
+        depth,
       })
     })
   }
@@ -128,8 +143,8 @@ export const PostFilter = (props: PostFilterProps) => {
   const filterCount = filterItems.length
   const filteredOptions = options.filter((option) => option.label.toLowerCase().includes(query.toLowerCase()))
 
-  const FilterGroupSection = ({ title, type }: { title: string; type: string[] }) => {
-    const options = filteredOptions.filter((o) => type.includes(o.type))
+  const FilterGroupSection = ({ title, type, groupId }: { title: string; type: string[]; groupId?: number }) => {
+    const options = filteredOptions.filter((o) => type.includes(o.type) && o.groupId === groupId)
 
     if (options.length === 0) return null
 
@@ -143,7 +158,7 @@ export const PostFilter = (props: PostFilterProps) => {
           return (
             <Dropdown.ListItem onClick={handleChangeFilter(o)} key={o.value.toString()}>
               <Checkbox field={o.value.toString()} checked={isChecked}>
-                <HStack spacing={2}>
+                <HStack spacing={2} className={o.depth ? `pl-${Math.min(o.depth * 3, 9)}` : undefined}>
                   <span className={isChecked ? "text-semibold" : ""}>{o.label}</span>
                   {o.count && o.count > 0 && <span className="bg-gray-200 inline-block rounded-full px-1 w-min-4 text-2xs text-center">{o.count}</span>}
                 </HStack>
@@ -179,7 +194,13 @@ export const PostFilter = (props: PostFilterProps) => {
 
         <FilterGroupSection title={i18n._({ id: "home.postfilter.label.status", message: "Status" })} type={["status"]} />
 
+        <FilterGroupSection title={i18n._({ id: "home.postfilter.label.answer", message: "Answer" })} type={["answered"]} />
+
         <FilterGroupSection title={i18n._({ id: "label.tags", message: "Tags" })} type={["noTags", "tag"]} />
+
+        {props.tagGroups.map((g) => (
+          <FilterGroupSection key={g.id} title={g.name} type={["tag"]} groupId={g.id} />
+        ))}
       </Dropdown>
     </HStack>
   )
@@ -2,7 +2,7 @@ import "./PostsContainer.scss"
 
 import React from "react"
 
-import { Post, Tag, CurrentUser } from "@fider/models"
+import { Post, Tag, TagGroup, CurrentUser } from "@fider/models"
 import { Loader, Input } from "@fider/components"
 import { actions, navigator, querystring } from "@fider/services"
 import IconSearch from "@fider/assets/images/heroicons-search.svg"
@@ -17,6 +17,7 @@ interface PostsContainerProps {
   user?: CurrentUser
   posts: Post[]
   tags: Tag[]
+  tagGroups?: TagGroup[]
   countPerStatus: { [key: string]: number }
   onPostClick?: (postNumber: number, slug: string) => void
 }
@@ -36,6 +37,7 @@ export interface FilterState {
   myVotes: boolean
   myPosts: boolean
   noTags: boolean
+  answered: string
 }
 
 export class PostsContainer extends React.Component<PostsContainerProps, PostsContainerState> {
@@ -55,6 +57,7 @@ export class PostsContainer extends React.Component<PostsContainerProps, PostsCo
         myVotes: querystring.get("myvotes") === "true",
         myPosts: querystring.get("myposts") === "true",
         noTags: querystring.get("notags") === "true",
+        answered: querystring.get("answered"),
       },
       limit: querystring.getNumber("limit"),
     }
@@ -70,6 +73,7 @@ export class PostsContainer extends React.Component<PostsContainerProps, PostsCo
           myvotes: this.state.filterState.myVotes ? "true" : undefined,
           myposts: this.state.filterState.myPosts ? "true" : undefined,
           notags: this.state.filterState.noTags ? "true" : undefined,
+          answered: this.state.filterState.answered || undefined,
           query,
           view: this.state.view,
           limit: this.state.limit,
@@ -85,6 +89,7 @@ export class PostsContainer extends React.Component<PostsContainerProps, PostsCo
         this.state.filterState.myVotes,
         this.state.filterState.myPosts,
         this.state.filterState.noTags,
+        this.state.filterState.answered,
         reset
       )
     })
@@ -100,6 +105,7 @@ export class PostsContainer extends React.Component<PostsContainerProps, PostsCo
     myVotes: boolean,
     myPosts: boolean,
     noTags: boolean,
+    answered: string,
     reset: boolean
   ) {
     window.clearTimeout(this.timer)
@@ -115,7 +121,7 @@ export class PostsContainer extends React.Component<PostsContainerProps, PostsCo
         moderation = "pending"
       }
 
-      actions.searchPosts({ query, view: view, limit, tags, statuses: actualStatuses, myVotes, myPosts, noTags, moderation }).then((response) => {
+      actions.searchPosts({ query, view: view, limit, tags, statuses: actualStatuses, myVotes, myPosts, noTags, moderation, answered }).then((response) => {
         if (response.ok && this.state.loading) {
           this.setState({ loading: false, posts: response.data })
         }
@@ -169,6 +175,7 @@ export class PostsContainer extends React.Component<PostsContainerProps, PostsCo
             <div className="c-posts-container__filter-col">
               <PostFilter
                 tags={this.props.tags}
+                tagGroups={this.props.tagGroups || []}
                 activeFilter={this.state.filterState}
                 filtersChanged={this.handleFilterChanged}
                 countPerStatus={this.props.countPerStatus}
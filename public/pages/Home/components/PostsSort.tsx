@@ -5,6 +5,7 @@ import IconSparkles from "@fider/assets/images/heroicons-sparkles-outline.svg"
 import IconThumbsUp from "@fider/assets/images/heroicons-thumbsup.svg"
 import IconChat from "@fider/assets/images/heroicons-chat-alt-2.svg"
 import IconClock from "@fider/assets/images/heroicons-clock.svg"
+import IconEye from "@fider/assets/images/heroicons-eye.svg"
 import { HStack } from "@fider/components/layout"
 
 interface PostsSortProps {
@@ -17,6 +18,7 @@ export const PostsSort: React.FC<PostsSortProps> = ({ value = "trending", onChan
     { value: "trending", label: i18n._({ id: "home.postfilter.option.trending", message: "Trending" }), icon: IconSparkles },
     { value: "most-wanted", label: i18n._({ id: "home.postfilter.option.mostwanted", message: "Most Wanted" }), icon: IconThumbsUp },
     { value: "most-discussed", label: i18n._({ id: "home.postfilter.option.mostdiscussed", message: "Most Discussed" }), icon: IconChat },
+    { value: "most-viewed", label: i18n._({ id: "home.postfilter.option.mostviewed", message: "Most Viewed" }), icon: IconEye },
     { value: "recent", label: i18n._({ id: "home.postfilter.option.recent", message: "Recent" }), icon: IconClock },
   ]
 
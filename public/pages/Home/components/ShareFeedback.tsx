@@ -2,13 +2,13 @@ import "./ShareFeedback.scss"
 
 import React, { useEffect, useRef, useState } from "react"
 import { SignInControl } from "@fider/components/common/SignInControl"
-import { Modal, CloseIcon, Form, Button, Input, LegalFooter } from "@fider/components/common"
+import { Modal, CloseIcon, Form, Button, Input, LegalFooter, Checkbox, Select, SelectOption } from "@fider/components/common"
 import { useFider } from "@fider/hooks"
 import { Trans } from "@lingui/react/macro"
 import { actions, Failure, querystring, classSet, cache } from "@fider/services"
 import { plainText } from "@fider/services/markdown"
 import { i18n } from "@lingui/core"
-import { Tag } from "@fider/models"
+import { PostTemplate, Tag } from "@fider/models"
 import { SimilarPosts } from "../components/SimilarPosts"
 import { TagsSelect } from "@fider/components/common/TagsSelect"
 import CommentEditor from "@fider/components/common/form/CommentEditor"
@@ -63,6 +63,9 @@ export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
     maxAttachments: 3,
   })
   const [tags, setTags] = useState(getTagsCachedValue())
+  const [isAnonymous, setIsAnonymous] = useState(false)
+  const [templates, setTemplates] = useState<PostTemplate[]>([])
+  const [template, setTemplate] = useState<PostTemplate | undefined>(undefined)
   const [error, setError] = useState<Failure | undefined>(undefined)
   const titleRef = useRef<HTMLInputElement>()
   const editorRef = useRef<HTMLDivElement>(null)
@@ -73,6 +76,16 @@ export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
     setIsInitialMount(false)
   }, [])
 
+  useEffect(() => {
+    if (isOpen) {
+      actions.listPostTemplates().then((result) => {
+        if (result.ok) {
+          setTemplates(result.data)
+        }
+      })
+    }
+  }, [isOpen])
+
   // Handle browser back button
   useEffect(() => {
     if (isOpen) {
@@ -167,6 +180,15 @@ export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
     setDescription(value)
   }
 
+  const handleTemplateChange = (option?: SelectOption) => {
+    const selected = templates.find((t) => t.id.toString() === option?.value)
+    setTemplate(selected)
+    if (selected) {
+      setDescription(selected.content)
+      setCachedDescription(selected.content)
+    }
+  }
+
   const onSubmitFeedback = () => {
     setPostPending(true)
   }
@@ -182,7 +204,9 @@ export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
           title,
           description,
           attachments,
-          tags.map((tag) => tag.slug)
+          tags.map((tag) => tag.slug),
+          isAnonymous,
+          template?.id
         ),
         minDelay,
       ])
@@ -229,8 +253,21 @@ export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
           </h1>
           <div className="c-share-feedback-form">
             <Form error={error}>
+              {templates.length > 0 && (
+                <Select
+                  field="templateId"
+                  label={i18n._({ id: "newpost.modal.template.label", message: "Template" })}
+                  defaultValue=""
+                  options={[
+                    { value: "", label: i18n._({ id: "newpost.modal.template.none", message: "No template" }) },
+                    ...templates.map((t) => ({ value: t.id.toString(), label: t.name })),
+                  ]}
+                  onChange={handleTemplateChange}
+                />
+              )}
               <div ref={editorRef} className="mb-4">
                 <CommentEditor
+                  key={template?.id || 0}
                   field="description"
                   onChange={handleDescriptionChange}
                   onFocus={handleEditorFocus}
@@ -268,6 +305,11 @@ export const ShareFeedback: React.FC<ShareFeedbackProps> = (props) => {
                   </div>
                 </div>
               )}
+              {fider.session.tenant.isAnonymousPostingEnabled && (
+                <Checkbox field="isAnonymous" checked={isAnonymous} onChange={setIsAnonymous}>
+                  <Trans id="newpost.modal.anonymous">Post anonymously</Trans>
+                </Checkbox>
+              )}
             </Form>
           </div>
         </div>
@@ -1,7 +1,10 @@
 import React, { useState, useEffect, useRef } from "react"
-import { Post, Tag, CurrentUser } from "@fider/models"
-import { ListPosts } from "./ListPosts"
+import { SimilarPost, Tag, CurrentUser, PostStatus } from "@fider/models"
+import { Button } from "@fider/components"
+import { HStack } from "@fider/components/layout"
+import { useFider } from "@fider/hooks"
 import { actions } from "@fider/services"
+import { Trans } from "@lingui/react/macro"
 
 import { i18n } from "@lingui/core"
 
@@ -13,9 +16,40 @@ interface SimilarPostsProps {
   user?: CurrentUser
 }
 
+const SimilarPostItem = (props: { post: SimilarPost }) => {
+  const fider = useFider()
+  const confidence = Math.round(props.post.confidence * 100)
+  const canVote = fider.session.isAuthenticated && !fider.isReadOnly && !props.post.hasVoted && !PostStatus.Get(props.post.status).closed
+
+  const voteInstead = async () => {
+    const result = await actions.addVote(props.post.number)
+    if (result.ok) {
+      location.href = `/posts/${props.post.number}/${props.post.slug}`
+    }
+  }
+
+  return (
+    <HStack justify="between" className="py-2">
+      <a href={`/posts/${props.post.number}/${props.post.slug}`} className="text-break" target="_blank" rel="noopener noreferrer">
+        {props.post.title}
+      </a>
+      <HStack>
+        <span className="text-xs text-muted flex-shrink-0">
+          <Trans id="home.similar.confidence">{confidence}% match</Trans>
+        </span>
+        {canVote && (
+          <Button size="small" variant="secondary" onClick={voteInstead}>
+            <Trans id="home.similar.voteinstead">Vote instead</Trans>
+          </Button>
+        )}
+      </HStack>
+    </HStack>
+  )
+}
+
 export const SimilarPosts: React.FC<SimilarPostsProps> = (props) => {
   const [title, setTitle] = useState(props.title)
-  const [posts, setPosts] = useState<Post[]>([])
+  const [posts, setPosts] = useState<SimilarPost[]>([])
   const [loading, setLoading] = useState(true)
   const [isVisible, setIsVisible] = useState(false)
   const timerRef = useRef<number>()
@@ -84,7 +118,9 @@ export const SimilarPosts: React.FC<SimilarPostsProps> = (props) => {
       <div className={`similar-posts-container overflow-auto ${animationClass}`} {...(!isVisible && { inert: "true" })}>
         <div className="mb-4 text-gray-700">{title_text}</div>
         <div className="mb-6">
-          <ListPosts posts={posts} tags={props.tags} emptyText="" minimalView={true} />
+          {posts.map((post) => (
+            <SimilarPostItem key={post.id} post={post} />
+          ))}
         </div>
       </div>
     </>
@@ -0,0 +1,33 @@
+import React from "react"
+import { PostAnswer } from "@fider/models"
+import { Avatar, Markdown, UserName } from "@fider/components"
+import { HStack, VStack } from "@fider/components/layout"
+import { Trans } from "@lingui/react/macro"
+
+import "./ShowComment.scss"
+
+interface AcceptedAnswerProps {
+  answer: PostAnswer
+}
+
+export const AcceptedAnswer = (props: AcceptedAnswerProps) => {
+  return (
+    <HStack spacing={4} align="start" className="c-comment">
+      <Avatar user={props.answer.user} size="large" />
+      <div className="c-comment__card c-comment__card--answer">
+        <VStack spacing={2}>
+          <HStack spacing={2} align="center">
+            <UserName user={props.answer.user} />
+            <span className="text-xs px-2 py-0.5 rounded bg-green-100 text-green-800">
+              <Trans id="label.acceptedanswer">Accepted answer</Trans>
+            </span>
+          </HStack>
+          <Markdown text={props.answer.content} style="full" />
+          <a className="text-link text-sm" href={`#comment-${props.answer.commentId}`}>
+            <Trans id="showpost.answer.view">View in discussion</Trans>
+          </a>
+        </VStack>
+      </div>
+    </HStack>
+  )
+}
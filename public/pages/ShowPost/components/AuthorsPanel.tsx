@@ -0,0 +1,137 @@
+import React, { useEffect, useState } from "react"
+import { Post, User } from "@fider/models"
+import { Avatar, Button, Input, UserName } from "@fider/components"
+import { actions, http, notify, Failure } from "@fider/services"
+import { useFider } from "@fider/hooks"
+import { VStack, HStack } from "@fider/components/layout"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+interface AuthorsPanelProps {
+  post: Post
+  onDataChanged?: () => void
+}
+
+interface SearchUsersResponse {
+  users: User[]
+}
+
+export const AuthorsPanel = (props: AuthorsPanelProps) => {
+  const fider = useFider()
+  const [coAuthors, setCoAuthors] = useState<User[]>([])
+  const [query, setQuery] = useState("")
+  const [results, setResults] = useState<User[]>([])
+
+  const canManage = fider.session.isAuthenticated && fider.session.user.isCollaborator && !fider.isReadOnly
+
+  useEffect(() => {
+    actions.listPostCoAuthors(props.post.number).then((result) => {
+      if (result.ok) {
+        setCoAuthors(result.data)
+      }
+    })
+  }, [props.post.number])
+
+  const search = async (value: string) => {
+    setQuery(value)
+    if (value.trim().length < 2) {
+      setResults([])
+      return
+    }
+
+    const result = await http.get<SearchUsersResponse>(`/api/v1/users?query=${encodeURIComponent(value.trim())}&limit=5`)
+    if (result.ok) {
+      setResults(result.data.users.filter((u) => u.id !== props.post.user.id && !coAuthors.some((c) => c.id === u.id)))
+    }
+  }
+
+  const showError = (error?: Failure) => {
+    notify.error(error?.errors?.[0]?.message ?? "Failed to change authors")
+  }
+
+  const addCoAuthor = async (user: User) => {
+    const result = await actions.addPostCoAuthor(props.post.number, user.id)
+    if (result.ok) {
+      setCoAuthors([...coAuthors, result.data])
+      setQuery("")
+      setResults([])
+      props.onDataChanged?.()
+    } else {
+      showError(result.error)
+    }
+  }
+
+  const removeCoAuthor = async (user: User) => {
+    const result = await actions.removePostCoAuthor(props.post.number, user.id)
+    if (result.ok) {
+      setCoAuthors(coAuthors.filter((c) => c.id !== user.id))
+      props.onDataChanged?.()
+    }
+  }
+
+  const transferOwnership = async (user: User) => {
+    const result = await actions.changePostOwner(props.post.number, user.id)
+    if (result.ok) {
+      props.onDataChanged?.()
+      setTimeout(() => location.reload(), 500)
+    } else {
+      showError(result.error)
+    }
+  }
+
+  if (coAuthors.length === 0 && !canManage) {
+    return null
+  }
+
+  return (
+    <VStack spacing={2}>
+      <span className="text-category">
+        <Trans id="showpost.authors.coauthors">Co-authors</Trans>
+      </span>
+      {coAuthors.length === 0 && (
+        <span className="text-sm text-muted">
+          <Trans id="showpost.authors.none">No co-authors yet.</Trans>
+        </span>
+      )}
+      {coAuthors.map((user) => (
+        <HStack key={user.id} justify="between">
+          <HStack spacing={2}>
+            <Avatar user={user} size="small" />
+            <UserName user={user} />
+          </HStack>
+          {canManage && (
+            <Button size="small" variant="tertiary" onClick={() => removeCoAuthor(user)}>
+              <Trans id="action.remove">Remove</Trans>
+            </Button>
+          )}
+        </HStack>
+      ))}
+      {canManage && (
+        <>
+          <Input
+            field="authorSearch"
+            value={query}
+            onChange={search}
+            placeholder={i18n._({ id: "showpost.authors.search.placeholder", message: "Search users to add as co-author or new owner..." })}
+          />
+          {results.map((user) => (
+            <HStack key={user.id} justify="between">
+              <HStack spacing={2}>
+                <Avatar user={user} size="small" />
+                <UserName user={user} />
+              </HStack>
+              <HStack>
+                <Button size="small" variant="secondary" onClick={() => addCoAuthor(user)}>
+                  <Trans id="showpost.authors.add">Add co-author</Trans>
+                </Button>
+                <Button size="small" variant="tertiary" onClick={() => transferOwnership(user)}>
+                  <Trans id="showpost.authors.transfer">Make owner</Trans>
+                </Button>
+              </HStack>
+            </HStack>
+          ))}
+        </>
+      )}
+    </VStack>
+  )
+}
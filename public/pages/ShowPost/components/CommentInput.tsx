@@ -1,7 +1,7 @@
 import React, { useCallback, useState, useEffect } from "react"
 
 import { Post } from "@fider/models"
-import { Avatar, Button, Form } from "@fider/components"
+import { Avatar, Button, Checkbox, Form } from "@fider/components"
 import { SignInModal } from "@fider/components"
 
 import { cache, actions, Failure, Fider } from "@fider/services"
@@ -15,13 +15,18 @@ import CommentEditor from "@fider/components/common/form/CommentEditor"
 
 interface CommentInputProps {
   post: Post
+  // parentId is set when replying to a comment
+  parentId?: number
+  // parentIsInternal is set when replying to an internal note, replies to which are always internal
+  parentIsInternal?: boolean
+  onCancel?: () => void
 }
 
 const CACHE_TITLE_KEY = "CommentInput-Comment-Title-"
 const CACHE_ATTACHMENTS_KEY = "CommentInput-Comment-Attachments-"
 
 export const CommentInput = (props: CommentInputProps) => {
-  const getCacheKey = (cachePrefix: string) => `${cachePrefix}${props.post.id}`
+  const getCacheKey = (cachePrefix: string) => `${cachePrefix}${props.post.id}${props.parentId ? `-${props.parentId}` : ""}`
 
   const getContentFromCache = () => {
     return cache.session.get(getCacheKey(CACHE_TITLE_KEY))
@@ -31,6 +36,9 @@ export const CommentInput = (props: CommentInputProps) => {
   const [isSignInModalOpen, setIsSignInModalOpen] = useState(false)
   const [error, setError] = useState<Failure | undefined>(undefined)
   const [isClient, setIsClient] = useState(false)
+  const [isInternal, setIsInternal] = useState(false)
+
+  const canWriteInternal = fider.session.isAuthenticated && fider.session.user.isCollaborator && !props.parentIsInternal
 
   // Use the attachments hook
   const { attachments, handleImageUploaded, getImageSrc, clearAttachments } = useAttachments({
@@ -51,7 +59,7 @@ export const CommentInput = (props: CommentInputProps) => {
 
     const content = getContentFromCache()
 
-    const result = await actions.createComment(props.post.number, content || "", attachments)
+    const result = await actions.createComment(props.post.number, content || "", attachments, props.parentId, canWriteInternal && isInternal)
     if (result.ok) {
       clearAttachments()
       cache.session.remove(getCacheKey(CACHE_TITLE_KEY))
@@ -91,18 +99,36 @@ export const CommentInput = (props: CommentInputProps) => {
                   onChange={commentChanged}
                   onFocus={editorFocused}
                   initialValue={getContentFromCache()}
-                  placeholder={i18n._({ id: "showpost.commentinput.placeholder", message: "Leave a comment" })}
+                  placeholder={
+                    props.parentId
+                      ? i18n._({ id: "showpost.commentinput.reply.placeholder", message: "Write a reply" })
+                      : i18n._({ id: "showpost.commentinput.placeholder", message: "Leave a comment" })
+                  }
                   maxAttachments={2}
                   maxImageSizeKB={5 * 1024}
                   onGetImageSrc={getImageSrc}
                   onImageUploaded={handleImageUploaded}
+                  isInternal={props.parentIsInternal || (canWriteInternal && isInternal)}
                 />
 
+                {canWriteInternal && (
+                  <div className="mt-2">
+                    <Checkbox field="isInternal" checked={isInternal} onChange={setIsInternal}>
+                      <Trans id="showpost.commentinput.internal">Internal note, only visible to staff</Trans>
+                    </Checkbox>
+                  </div>
+                )}
+
                 {hasContent && (
                   <>
                     <Button disabled={!fider.session.isAuthenticated} variant="primary" onClick={submit} className="mt-4">
                       <Trans id="action.postcomment">Post</Trans>
                     </Button>
+                    {props.onCancel && (
+                      <Button variant="tertiary" onClick={props.onCancel} className="mt-4">
+                        <Trans id="action.cancel">Cancel</Trans>
+                      </Button>
+                    )}
                   </>
                 )}
               </>
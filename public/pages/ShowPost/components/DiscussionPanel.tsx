@@ -21,7 +21,7 @@ export const DiscussionPanel = (props: DiscussionPanelProps) => {
         </span>
         <VStack spacing={4} className="c-comment-list">
           {props.comments.map((c) => (
-            <ShowComment key={c.id} post={props.post} comment={c} highlighted={props.highlightedComment === c.id} />
+            <ShowComment key={c.id} post={props.post} comment={c} highlightedComment={props.highlightedComment} />
           ))}
           <CommentInput post={props.post} />
         </VStack>
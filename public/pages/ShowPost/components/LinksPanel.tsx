@@ -0,0 +1,114 @@
+import React, { useState } from "react"
+import { Post, PostLink } from "@fider/models"
+import { Button, Form, Input, Select, SelectOption } from "@fider/components"
+import { actions, Failure } from "@fider/services"
+import { useFider } from "@fider/hooks"
+import { VStack, HStack } from "@fider/components/layout"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+interface LinksPanelProps {
+  post: Post
+}
+
+const linkTypes: SelectOption[] = [
+  { value: "github", label: "GitHub" },
+  { value: "gitlab", label: "GitLab" },
+  { value: "jira", label: "Jira" },
+  { value: "url", label: "URL" },
+]
+
+const linkStates: SelectOption[] = [
+  { value: "open", label: "Open" },
+  { value: "closed", label: "Closed" },
+  { value: "merged", label: "Merged" },
+]
+
+export const LinksPanel = (props: LinksPanelProps) => {
+  const fider = useFider()
+  const [links, setLinks] = useState<PostLink[]>(props.post.links || [])
+  const [type, setType] = useState("github")
+  const [url, setURL] = useState("")
+  const [error, setError] = useState<Failure | undefined>()
+
+  const canManage = fider.session.isAuthenticated && fider.session.user.isCollaborator && !fider.isReadOnly
+
+  const add = async () => {
+    const result = await actions.addPostLink(props.post.number, type, url)
+    if (result.ok) {
+      setLinks([...links, result.data])
+      setURL("")
+      setError(undefined)
+    } else {
+      setError(result.error)
+    }
+  }
+
+  const changeState = async (link: PostLink, option?: SelectOption) => {
+    if (!option || option.value === link.state) {
+      return
+    }
+
+    const result = await actions.updatePostLink(props.post.number, link.id, option.value)
+    if (result.ok) {
+      setLinks(links.map((l) => (l.id === link.id ? result.data : l)))
+    }
+  }
+
+  const remove = async (link: PostLink) => {
+    const result = await actions.deletePostLink(props.post.number, link.id)
+    if (result.ok) {
+      setLinks(links.filter((l) => l.id !== link.id))
+    }
+  }
+
+  if (links.length === 0 && !canManage) {
+    return null
+  }
+
+  return (
+    <VStack spacing={2}>
+      <span className="text-category">
+        <Trans id="showpost.links.title">Linked issues</Trans>
+      </span>
+      {links.length === 0 && (
+        <span className="text-sm text-muted">
+          <Trans id="showpost.links.none">No linked issues yet.</Trans>
+        </span>
+      )}
+      {links.map((link) => (
+        <HStack key={link.id} justify="between">
+          <a href={link.url} className="text-link text-sm" target="_blank" rel="noopener nofollow">
+            {link.externalId || link.url}
+          </a>
+          {canManage ? (
+            <HStack>
+              <Select field={`linkState-${link.id}`} defaultValue={link.state} options={linkStates} onChange={(option) => changeState(link, option)} />
+              <Button size="small" variant="tertiary" onClick={() => remove(link)}>
+                <Trans id="action.remove">Remove</Trans>
+              </Button>
+            </HStack>
+          ) : (
+            <span className="text-xs text-muted">{linkStates.find((s) => s.value === link.state)?.label}</span>
+          )}
+        </HStack>
+      ))}
+      {canManage && (
+        <Form error={error}>
+          <HStack>
+            <Select field="type" defaultValue={type} options={linkTypes} onChange={(option) => setType(option?.value || "github")} />
+            <Input
+              field="url"
+              value={url}
+              onChange={setURL}
+              placeholder={i18n._({ id: "showpost.links.url.placeholder", message: "Issue, merge request or ticket URL" })}
+            />
+            <Button size="small" variant="secondary" onClick={add} disabled={url.trim() === ""}>
+              <Trans id="showpost.links.add">Link</Trans>
+            </Button>
+          </HStack>
+        </Form>
+      )}
+    </VStack>
+  )
+}
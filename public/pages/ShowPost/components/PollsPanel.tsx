@@ -0,0 +1,136 @@
+import React, { useEffect, useState } from "react"
+import { Poll, Post } from "@fider/models"
+import { Button, Moment } from "@fider/components"
+import { actions, notify } from "@fider/services"
+import { useFider } from "@fider/hooks"
+import { VStack, HStack } from "@fider/components/layout"
+import { Trans } from "@lingui/react/macro"
+
+interface PollsPanelProps {
+  post: Post
+}
+
+interface PollItemProps {
+  post: Post
+  poll: Poll
+  onChanged: (poll: Poll) => void
+  onDeleted: (poll: Poll) => void
+}
+
+const PollItem = (props: PollItemProps) => {
+  const fider = useFider()
+  const [selected, setSelected] = useState<number[]>(props.poll.myAnswers || [])
+
+  const isClosed = !!props.poll.closesAt && new Date(props.poll.closesAt) <= new Date()
+  const canAnswer = fider.session.isAuthenticated && !fider.isReadOnly && !isClosed && !props.post.lockedAt
+  const canDelete = fider.session.isAuthenticated && fider.session.user.isCollaborator
+
+  const toggle = (optionID: number) => {
+    if (!props.poll.isMultipleChoice) {
+      setSelected([optionID])
+    } else if (selected.includes(optionID)) {
+      setSelected(selected.filter((id) => id !== optionID))
+    } else {
+      setSelected([...selected, optionID])
+    }
+  }
+
+  const submit = async () => {
+    const result = await actions.answerPoll(props.post.number, props.poll.id, selected)
+    if (result.ok) {
+      props.onChanged(result.data)
+    } else if (result.error) {
+      notify.error(result.error.errors?.[0]?.message ?? "Failed to answer poll")
+    }
+  }
+
+  const remove = async () => {
+    const result = await actions.deletePoll(props.post.number, props.poll.id)
+    if (result.ok) {
+      props.onDeleted(props.poll)
+    }
+  }
+
+  const count = props.poll.votersCount
+  const percentage = (answers: number) => (count > 0 ? Math.round((answers / count) * 100) : 0)
+
+  return (
+    <VStack spacing={2}>
+      <span className="text-bold text-gray-900">{props.poll.question}</span>
+      {props.poll.options.map((option) => (
+        <HStack key={option.id} justify="between">
+          <label className="text-sm">
+            <input
+              type={props.poll.isMultipleChoice ? "checkbox" : "radio"}
+              name={`poll-${props.poll.id}`}
+              checked={selected.includes(option.id)}
+              disabled={!canAnswer}
+              onChange={() => toggle(option.id)}
+            />{" "}
+            {option.text}
+          </label>
+          <span className="text-sm text-muted">
+            {option.answersCount} ({percentage(option.answersCount)}%)
+          </span>
+        </HStack>
+      ))}
+      <HStack justify="between">
+        <span className="text-xs text-muted">
+          <Trans id="showpost.polls.voters">{count} people answered</Trans>
+          {props.poll.closesAt && (
+            <>
+              {" · "}
+              {isClosed ? <Trans id="showpost.polls.closed">Closed</Trans> : <Trans id="showpost.polls.closes">Closes</Trans>}{" "}
+              <Moment locale={fider.currentLocale} date={props.poll.closesAt} />
+            </>
+          )}
+          {props.poll.isVotersOnly && (
+            <>
+              {" · "}
+              <Trans id="showpost.polls.votersonly">Voters only</Trans>
+            </>
+          )}
+        </span>
+        <HStack>
+          {canAnswer && (
+            <Button size="small" variant="secondary" onClick={submit} disabled={selected.length === 0}>
+              <Trans id="showpost.polls.answer">Answer</Trans>
+            </Button>
+          )}
+          {canDelete && (
+            <Button size="small" variant="tertiary" onClick={remove} disabled={fider.isReadOnly}>
+              <Trans id="action.delete">Delete</Trans>
+            </Button>
+          )}
+        </HStack>
+      </HStack>
+    </VStack>
+  )
+}
+
+export const PollsPanel = (props: PollsPanelProps) => {
+  const [polls, setPolls] = useState<Poll[]>([])
+
+  useEffect(() => {
+    actions.listPolls(props.post.number).then((result) => {
+      if (result.ok) {
+        setPolls(result.data)
+      }
+    })
+  }, [props.post.number])
+
+  if (polls.length === 0) {
+    return null
+  }
+
+  const onChanged = (poll: Poll) => setPolls(polls.map((p) => (p.id === poll.id ? poll : p)))
+  const onDeleted = (poll: Poll) => setPolls(polls.filter((p) => p.id !== poll.id))
+
+  return (
+    <VStack spacing={4} className="card">
+      {polls.map((poll) => (
+        <PollItem key={poll.id} post={props.post} poll={poll} onChanged={onChanged} onDeleted={onDeleted} />
+      ))}
+    </VStack>
+  )
+}
@@ -0,0 +1,65 @@
+import React, { useEffect, useState } from "react"
+import { Post, PostAnalytics, PostActivity } from "@fider/models"
+import { actions } from "@fider/services"
+import { VStack, HStack } from "@fider/components/layout"
+import { Trans } from "@lingui/react/macro"
+
+interface PostAnalyticsPanelProps {
+  post: Post
+}
+
+const days = 30
+
+const ActivityBar = (props: { activity: PostActivity; max: number }) => {
+  const height = props.max > 0 ? Math.max(2, Math.round((props.activity.views / props.max) * 40)) : 2
+  const title = `${props.activity.date.substring(0, 10)}: ${props.activity.views} views, ${props.activity.votes} votes, ${props.activity.comments} comments`
+  return <div title={title} className="flex-grow bg-gray-300 rounded-sm" style={{ height: `${height}px` }} />
+}
+
+export const PostAnalyticsPanel = (props: PostAnalyticsPanelProps) => {
+  const [analytics, setAnalytics] = useState<PostAnalytics | undefined>(undefined)
+
+  useEffect(() => {
+    actions.getPostAnalytics(props.post.number, days).then((result) => {
+      if (result.ok) {
+        setAnalytics(result.data)
+      }
+    })
+  }, [props.post.number])
+
+  if (!analytics) {
+    return null
+  }
+
+  const max = Math.max(0, ...analytics.series.map((a) => a.views))
+
+  return (
+    <VStack spacing={2}>
+      <span className="text-category">
+        <Trans id="showpost.analytics.title">Engagement</Trans>
+      </span>
+      <HStack spacing={4} className="text-sm">
+        <span>
+          <strong>{analytics.viewsCount}</strong> <Trans id="showpost.analytics.views">Views</Trans>
+        </span>
+        <span>
+          <strong>{analytics.votersCount}</strong> <Trans id="showpost.analytics.voters">Voters</Trans>
+        </span>
+        <span>
+          <strong>{analytics.commentsCount}</strong> <Trans id="showpost.analytics.comments">Comments</Trans>
+        </span>
+        <span>
+          <strong>{Math.round(analytics.conversionRate * 100)}%</strong> <Trans id="showpost.analytics.conversion">Vote conversion</Trans>
+        </span>
+      </HStack>
+      <div className="flex items-end" style={{ gap: "2px", height: "40px" }}>
+        {analytics.series.map((activity) => (
+          <ActivityBar key={activity.date} activity={activity} max={max} />
+        ))}
+      </div>
+      <span className="text-xs text-muted">
+        <Trans id="showpost.analytics.period">Last {days} days</Trans>
+      </span>
+    </VStack>
+  )
+}
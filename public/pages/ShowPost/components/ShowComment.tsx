@@ -9,13 +9,16 @@ import { t } from "@lingui/core/macro"
 import { Trans, useLingui } from "@lingui/react/macro"
 import CommentEditor from "@fider/components/common/form/CommentEditor"
 import { useAttachments } from "@fider/hooks/useAttachments"
+import { CommentInput } from "./CommentInput"
 
 import "./ShowComment.scss"
 
 interface ShowCommentProps {
   post: Post
   comment: Comment
-  highlighted?: boolean
+  highlightedComment?: number
+  // depth is zero for top-level comments and increases for each level of replies
+  depth?: number
   onToggleReaction?: () => void
 }
 
@@ -24,6 +27,7 @@ export const ShowComment = (props: ShowCommentProps) => {
   const { i18n } = useLingui()
   const node = useRef<HTMLDivElement | null>(null)
   const [isEditing, setIsEditing] = useState(false)
+  const [isReplying, setIsReplying] = useState(false)
   const [newContent, setNewContent] = useState<string>(props.comment.content)
   const [isDeleteConfirmationModalOpen, setIsDeleteConfirmationModalOpen] = useState(false)
   const { attachments, handleImageUploaded, getImageSrc } = useAttachments({
@@ -34,6 +38,9 @@ export const ShowComment = (props: ShowCommentProps) => {
 
   const [error, setError] = useState<Failure>()
 
+  const depth = props.depth ?? 0
+  const highlighted = props.highlightedComment === props.comment.id
+
   const handleClick = (e: MouseEvent) => {
     if (node.current == null || !node.current.contains(e.target as Node)) {
       clearUrlHash()
@@ -41,13 +48,13 @@ export const ShowComment = (props: ShowCommentProps) => {
   }
 
   useEffect(() => {
-    if (props.highlighted) {
+    if (highlighted) {
       document.addEventListener("mousedown", handleClick)
       return () => {
         document.removeEventListener("mousedown", handleClick)
       }
     }
-  }, [props.highlighted])
+  }, [highlighted])
 
   const canEditComment = (): boolean => {
     if (fider.session.isAuthenticated) {
@@ -56,6 +63,22 @@ export const ShowComment = (props: ShowCommentProps) => {
     return false
   }
 
+  const canAcceptAnswer = (): boolean => {
+    if (!fider.session.isAuthenticated || fider.isReadOnly || props.comment.isInternal || !props.comment.isApproved) {
+      return false
+    }
+    return fider.session.user.isCollaborator || props.post.user.id === fider.session.user.id
+  }
+
+  const isAcceptedAnswer = props.post.acceptedAnswer?.commentId === props.comment.id
+
+  const canReply = (): boolean => {
+    if (!fider.session.isAuthenticated || fider.isReadOnly || depth >= fider.settings.commentReplyMaxDepth) {
+      return false
+    }
+    return !props.post.lockedAt || fider.session.user.isCollaborator
+  }
+
   const clearError = () => setError(undefined)
 
   const cancelEdit = async () => {
@@ -103,6 +126,15 @@ export const ShowComment = (props: ShowCommentProps) => {
     }
   }
 
+  const handleAcceptAnswer = async (accepted: boolean) => {
+    const response = await actions.acceptAnswer(props.post.number, props.comment.id, accepted)
+    if (response.ok) {
+      location.reload()
+    } else {
+      notify.error(response.error?.errors?.[0]?.message ?? t({ id: "showpost.comment.answer.error", message: "Failed to update the accepted answer" }))
+    }
+  }
+
   const handleApproveComment = async () => {
     const result = await actions.approveComment(props.comment.id)
     if (result.ok) {
@@ -168,6 +200,10 @@ export const ShowComment = (props: ShowCommentProps) => {
       handlePinComment(true)
     } else if (action === "unpin") {
       handlePinComment(false)
+    } else if (action === "accept") {
+      handleAcceptAnswer(true)
+    } else if (action === "unaccept") {
+      handleAcceptAnswer(false)
     }
   }
 
@@ -205,9 +241,26 @@ export const ShowComment = (props: ShowCommentProps) => {
 
   const classList = classSet({
     "c-comment__content": true,
-    "c-comment__content--highlighted": props.highlighted,
+    "c-comment__content--highlighted": highlighted,
+    "c-comment__card--internal": !!comment.isInternal,
+    "c-comment__card--answer": isAcceptedAnswer,
   })
 
+  const replies = (comment.replies || []).map((reply) => (
+    <ShowComment key={reply.id} post={props.post} comment={reply} highlightedComment={props.highlightedComment} depth={depth + 1} />
+  ))
+
+  if (comment.isDeleted) {
+    return (
+      <div id={`comment-${comment.id}`} className="c-comment">
+        <div className="c-comment__card c-comment__card--deleted text-muted text-sm">
+          <Trans id="showpost.comment.deleted">This comment has been deleted.</Trans>
+        </div>
+        {replies.length > 0 && <div className="c-comment__replies">{replies}</div>}
+      </div>
+    )
+  }
+
   return (
     <div id={`comment-${comment.id}`} className="c-comment">
       {modal()}
@@ -226,6 +279,16 @@ export const ShowComment = (props: ShowCommentProps) => {
                     <Trans id="label.pinned">Pinned</Trans>
                   </span>
                 )}
+                {isAcceptedAnswer && (
+                  <span className="text-xs px-2 py-0.5 rounded bg-green-100 text-green-800">
+                    <Trans id="label.acceptedanswer">Accepted answer</Trans>
+                  </span>
+                )}
+                {comment.isInternal && (
+                  <span className="text-xs px-2 py-0.5 rounded bg-blue-100 text-blue-700">
+                    <Trans id="label.internalnote">Internal note</Trans>
+                  </span>
+                )}
                 {fider.session.isAuthenticated && fider.session.user.isCollaborator && (comment.flagsCount ?? 0) > 0 && (
                   <span className="text-xs px-2 py-0.5 rounded bg-yellow-100 text-yellow-800">
                     {(i18n as any)._({ id: "label.flagcount", message: "{count} flag(s)" }, { count: comment.flagsCount })}
@@ -256,6 +319,20 @@ export const ShowComment = (props: ShowCommentProps) => {
                       )}
                     </>
                   )}
+                  {canAcceptAnswer() && (
+                    <>
+                      <Dropdown.Divider />
+                      {isAcceptedAnswer ? (
+                        <Dropdown.ListItem onClick={onActionSelected("unaccept")}>
+                          <Trans id="action.unacceptanswer">Unmark as answer</Trans>
+                        </Dropdown.ListItem>
+                      ) : (
+                        <Dropdown.ListItem onClick={onActionSelected("accept")}>
+                          <Trans id="action.acceptanswer">Accept as answer</Trans>
+                        </Dropdown.ListItem>
+                      )}
+                    </>
+                  )}
                   {canEditComment() && (
                     <>
                       <Dropdown.Divider />
@@ -284,6 +361,7 @@ export const ShowComment = (props: ShowCommentProps) => {
                   maxImageSizeKB={5 * 1024}
                   onGetImageSrc={getImageSrc}
                   onImageUploaded={handleImageUploaded}
+                  isInternal={comment.isInternal}
                 />
                 <div className="mt-2">
                   <Button size="small" onClick={saveEdit} variant="primary">
@@ -329,12 +407,25 @@ export const ShowComment = (props: ShowCommentProps) => {
                   </div>
                 )}
 
-                <Reactions reactions={localReactionCounts} emojiSelectorRef={emojiSelectorRef} toggleReaction={toggleReaction} />
+                <HStack spacing={2} align="center">
+                  <Reactions reactions={localReactionCounts} emojiSelectorRef={emojiSelectorRef} toggleReaction={toggleReaction} />
+                  {canReply() && !isReplying && (
+                    <Button size="small" variant="tertiary" onClick={() => setIsReplying(true)}>
+                      <Trans id="action.reply">Reply</Trans>
+                    </Button>
+                  )}
+                </HStack>
               </>
             )}
           </div>
         </div>
       </HStack>
+      {(isReplying || replies.length > 0) && (
+        <div className="c-comment__replies">
+          {replies}
+          {isReplying && <CommentInput post={props.post} parentId={comment.id} parentIsInternal={comment.isInternal} onCancel={() => setIsReplying(false)} />}
+        </div>
+      )}
     </div>
   )
 }
@@ -39,7 +39,7 @@ export const VoteSection = (props: VoteSectionProps) => {
   const hideModal = () => setIsSignInModalOpen(false)
 
   const status = PostStatus.Get(props.post.status)
-  const isDisabled = status.closed || fider.isReadOnly
+  const isDisabled = status.closed || !!props.post.lockedAt || fider.isReadOnly
 
   const buttonText = hasVoted ? <Trans id="action.voted">Voted!</Trans> : <Trans id="action.vote">Vote for this idea</Trans>
   const icon = hasVoted ? IconCheck : IconThumbsUp
@@ -1,5 +1,5 @@
 import { http, Result, querystring } from "@fider/services"
-import { Post, Vote, ImageUpload, UserNames } from "@fider/models"
+import { Post, Vote, VoteImportance, PostLog, PostAnalytics, ScheduledResponse, Poll, PostTemplate, PostRule, PostRuleDryRun, SimilarPost, PostDuplicateCandidate, PostLink, ImageUpload, UserNames, User } from "@fider/models"
 
 export const getAllPosts = async (): Promise<Result<Post[]>> => {
   return await http.get<Post[]>("/api/v1/posts")
@@ -19,6 +19,7 @@ export interface SearchPostsParams {
   myPosts?: boolean
   statuses?: string[]
   moderation?: string
+  answered?: string
 }
 
 export const searchPosts = async (params: SearchPostsParams): Promise<Result<Post[]>> => {
@@ -29,6 +30,7 @@ export const searchPosts = async (params: SearchPostsParams): Promise<Result<Pos
     view: params.view,
     limit: params.limit,
     moderation: params.moderation,
+    answered: params.answered,
   })
   if (params.myVotes) {
     qsParams += `&myvotes=true`
@@ -42,9 +44,17 @@ export const searchPosts = async (params: SearchPostsParams): Promise<Result<Pos
   return await http.get<Post[]>(`/api/v1/posts${qsParams}`)
 }
 
-export const findSimilarPosts = async (query: string): Promise<Result<Post[]>> => {
+export const findSimilarPosts = async (query: string): Promise<Result<SimilarPost[]>> => {
   const params = querystring.stringify({ query: query })
-  return await http.get<Post[]>(`/api/v1/similarposts${params}`)
+  return await http.get<SimilarPost[]>(`/api/v1/similarposts${params}`)
+}
+
+export const listPostDuplicateCandidates = async (): Promise<Result<PostDuplicateCandidate[]>> => {
+  return http.get<PostDuplicateCandidate[]>("/api/v1/admin/duplicates")
+}
+
+export const dismissPostDuplicateCandidate = async (id: number): Promise<Result> => {
+  return http.delete(`/api/v1/admin/duplicates/${id}`)
 }
 
 export const deletePost = async (postNumber: number, text: string): Promise<Result> => {
@@ -55,8 +65,28 @@ export const deletePost = async (postNumber: number, text: string): Promise<Resu
     .then(http.event("post", "delete"))
 }
 
-export const addVote = async (postNumber: number): Promise<Result> => {
-  return http.post(`/api/v1/posts/${postNumber}/votes`).then(http.event("post", "vote"))
+export const addVote = async (postNumber: number, importance?: VoteImportance): Promise<Result> => {
+  return http.post(`/api/v1/posts/${postNumber}/votes`, { importance }).then(http.event("post", "vote"))
+}
+
+export interface AddProxyVoteRequest {
+  userId?: number
+  email?: string
+  name?: string
+  note?: string
+  importance?: VoteImportance
+}
+
+export const addProxyVote = async (postNumber: number, request: AddProxyVoteRequest): Promise<Result<{ userId: number }>> => {
+  return http.post<{ userId: number }>(`/api/v1/posts/${postNumber}/votes/proxy`, request)
+}
+
+export const listPostLogs = async (postNumber: number): Promise<Result<PostLog[]>> => {
+  return http.get<PostLog[]>(`/api/v1/posts/${postNumber}/logs`)
+}
+
+export const getPostAnalytics = async (postNumber: number, days: number): Promise<Result<PostAnalytics>> => {
+  return http.get<PostAnalytics>(`/api/v1/posts/${postNumber}/analytics?days=${days}`)
 }
 
 export const removeVote = async (postNumber: number): Promise<Result> => {
@@ -79,12 +109,18 @@ export const listVotes = async (postNumber: number): Promise<Result<Vote[]>> =>
   return http.get<Vote[]>(`/api/v1/posts/${postNumber}/votes`)
 }
 
-export const getTaggableUsers = async (userFilter: string): Promise<Result<UserNames[]>> => {
-  return http.get<UserNames[]>(`/api/v1/taggable-users${querystring.stringify({ query: userFilter })}`)
+export const getTaggableUsers = async (userFilter: string, internal?: boolean): Promise<Result<UserNames[]>> => {
+  return http.get<UserNames[]>(`/api/v1/taggable-users${querystring.stringify({ query: userFilter, internal: internal ? "true" : undefined })}`)
 }
 
-export const createComment = async (postNumber: number, content: string, attachments: ImageUpload[]): Promise<Result> => {
-  return http.post(`/api/v1/posts/${postNumber}/comments`, { content, attachments }).then(http.event("comment", "create"))
+export const createComment = async (
+  postNumber: number,
+  content: string,
+  attachments: ImageUpload[],
+  parentId?: number,
+  isInternal?: boolean
+): Promise<Result> => {
+  return http.post(`/api/v1/posts/${postNumber}/comments`, { content, attachments, parentId, isInternal }).then(http.event("comment", "create"))
 }
 
 export const updateComment = async (postNumber: number, commentID: number, content: string, attachments: ImageUpload[]): Promise<Result> => {
@@ -107,10 +143,46 @@ export const pinPost = async (postNumber: number, pinned: boolean): Promise<Resu
   return http.post(`/api/v1/posts/${postNumber}/pin`, { pinned })
 }
 
+export const lockPost = async (postNumber: number, locked: boolean, reason?: string): Promise<Result> => {
+  return http.post(`/api/v1/posts/${postNumber}/lock`, { locked, reason: reason || "" })
+}
+
+export const listPostCoAuthors = async (postNumber: number): Promise<Result<User[]>> => {
+  return http.get<User[]>(`/api/v1/posts/${postNumber}/coauthors`)
+}
+
+export const changePostOwner = async (postNumber: number, userID: number): Promise<Result> => {
+  return http.put(`/api/v1/posts/${postNumber}/owner`, { userId: userID })
+}
+
+export const addPostCoAuthor = async (postNumber: number, userID: number): Promise<Result<User>> => {
+  return http.post<User>(`/api/v1/posts/${postNumber}/coauthors`, { userId: userID })
+}
+
+export const removePostCoAuthor = async (postNumber: number, userID: number): Promise<Result> => {
+  return http.delete(`/api/v1/posts/${postNumber}/coauthors/${userID}`)
+}
+
+export const addPostLink = async (postNumber: number, type: string, url: string, state?: string): Promise<Result<PostLink>> => {
+  return http.post<PostLink>(`/api/v1/posts/${postNumber}/links`, { type, url, state })
+}
+
+export const updatePostLink = async (postNumber: number, linkID: number, state: string): Promise<Result<PostLink>> => {
+  return http.put<PostLink>(`/api/v1/posts/${postNumber}/links/${linkID}`, { state })
+}
+
+export const deletePostLink = async (postNumber: number, linkID: number): Promise<Result> => {
+  return http.delete(`/api/v1/posts/${postNumber}/links/${linkID}`)
+}
+
 export const pinComment = async (postNumber: number, commentID: number, pinned: boolean): Promise<Result> => {
   return http.post(`/api/v1/posts/${postNumber}/comments/${commentID}/pin`, { pinned })
 }
 
+export const acceptAnswer = async (postNumber: number, commentID: number, accepted: boolean): Promise<Result> => {
+  return http.post(`/api/v1/posts/${postNumber}/comments/${commentID}/answer`, { accepted })
+}
+
 interface ToggleReactionResponse {
   added: boolean
 }
@@ -119,6 +191,10 @@ export const toggleCommentReaction = async (postNumber: number, commentID: numbe
   return http.post<ToggleReactionResponse>(`/api/v1/posts/${postNumber}/comments/${commentID}/reactions/${emoji}`)
 }
 
+export const togglePostReaction = async (postNumber: number, emoji: string): Promise<Result<ToggleReactionResponse>> => {
+  return http.post<ToggleReactionResponse>(`/api/v1/posts/${postNumber}/reactions/${emoji}`)
+}
+
 interface SetResponseInput {
   status: string
   text: string
@@ -135,6 +211,84 @@ export const respond = async (postNumber: number, input: SetResponseInput): Prom
     .then(http.event("post", "respond"))
 }
 
+export interface ScheduleResponseInput {
+  status: string
+  text: string
+  scheduledAt?: Date
+}
+
+export const listScheduledResponses = async (postNumber: number): Promise<Result<ScheduledResponse[]>> => {
+  return http.get<ScheduledResponse[]>(`/api/v1/posts/${postNumber}/scheduled-responses`)
+}
+
+export const scheduleResponse = async (postNumber: number, input: ScheduleResponseInput): Promise<Result<ScheduledResponse>> => {
+  return http.post<ScheduledResponse>(`/api/v1/posts/${postNumber}/scheduled-responses`, input)
+}
+
+export const cancelScheduledResponse = async (postNumber: number, id: number): Promise<Result> => {
+  return http.delete(`/api/v1/posts/${postNumber}/scheduled-responses/${id}`)
+}
+
+interface CreatePollInput {
+  commentId?: number
+  question: string
+  options: string[]
+  isMultipleChoice: boolean
+  isVotersOnly: boolean
+  closesAt?: Date
+}
+
+export const listPolls = async (postNumber: number): Promise<Result<Poll[]>> => {
+  return http.get<Poll[]>(`/api/v1/posts/${postNumber}/polls`)
+}
+
+export const createPoll = async (postNumber: number, input: CreatePollInput): Promise<Result<Poll>> => {
+  return http.post<Poll>(`/api/v1/posts/${postNumber}/polls`, input)
+}
+
+export const answerPoll = async (postNumber: number, pollID: number, options: number[]): Promise<Result<Poll>> => {
+  return http.post<Poll>(`/api/v1/posts/${postNumber}/polls/${pollID}/answers`, { options })
+}
+
+export const deletePoll = async (postNumber: number, pollID: number): Promise<Result> => {
+  return http.delete(`/api/v1/posts/${postNumber}/polls/${pollID}`)
+}
+
+export type BulkPostOperation = "respond" | "addTag" | "removeTag" | "move" | "delete"
+
+export interface BulkPostFilter {
+  query?: string
+  view?: string
+  statuses?: string[]
+  tags?: string[]
+  notags?: boolean
+  board?: string
+}
+
+export interface BulkUpdatePostsInput {
+  numbers?: number[]
+  filter?: BulkPostFilter
+  operation: BulkPostOperation
+  status?: string
+  text?: string
+  tag?: string
+  board?: string
+}
+
+export const bulkUpdatePosts = async (input: BulkUpdatePostsInput): Promise<Result<{ count: number; batches: number }>> => {
+  return http.post<{ count: number; batches: number }>("/api/v1/posts/bulk", input).then(http.event("post", "bulk"))
+}
+
+export interface RevealedPostAuthor {
+  id: number
+  name: string
+  email: string
+}
+
+export const revealPostAuthor = async (postNumber: number, reason: string): Promise<Result<RevealedPostAuthor>> => {
+  return http.post<RevealedPostAuthor>(`/api/v1/posts/${postNumber}/reveal-author`, { reason }).then(http.event("post", "reveal-author"))
+}
+
 interface CreatePostResponse {
   id: number
   number: number
@@ -143,8 +297,43 @@ interface CreatePostResponse {
   isApproved: boolean
 }
 
-export const createPost = async (title: string, description: string, attachments: ImageUpload[], tags: string[]): Promise<Result<CreatePostResponse>> => {
-  return http.post<CreatePostResponse>(`/api/v1/posts`, { title, description, attachments, tags }).then(http.event("post", "create"))
+export const createPost = async (
+  title: string,
+  description: string,
+  attachments: ImageUpload[],
+  tags: string[],
+  isAnonymous = false,
+  templateId?: number
+): Promise<Result<CreatePostResponse>> => {
+  return http
+    .post<CreatePostResponse>(`/api/v1/posts`, { title, description, attachments, tags, isAnonymous, templateId })
+    .then(http.event("post", "create"))
+}
+
+export const listPostTemplates = async (): Promise<Result<PostTemplate[]>> => {
+  return http.get<PostTemplate[]>("/api/v1/post-templates")
+}
+
+export type PostRuleInput = Omit<PostRule, "id">
+
+export const listPostRules = async (): Promise<Result<PostRule[]>> => {
+  return http.get<PostRule[]>("/api/v1/post-rules")
+}
+
+export const createPostRule = async (rule: PostRuleInput): Promise<Result<PostRule>> => {
+  return http.post<PostRule>("/api/v1/post-rules", rule)
+}
+
+export const updatePostRule = async (id: number, rule: PostRuleInput): Promise<Result<PostRule>> => {
+  return http.put<PostRule>(`/api/v1/post-rules/${id}`, rule)
+}
+
+export const deletePostRule = async (id: number): Promise<Result> => {
+  return http.delete(`/api/v1/post-rules/${id}`)
+}
+
+export const dryRunPostRule = async (rule: PostRuleInput): Promise<Result<PostRuleDryRun>> => {
+  return http.post<PostRuleDryRun>("/api/v1/post-rules/dry-run", rule)
 }
 
 export const updatePost = async (postNumber: number, title: string, description: string, attachments: ImageUpload[]): Promise<Result> => {
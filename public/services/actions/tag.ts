@@ -1,18 +1,26 @@
 import { http, Result } from "@fider/services/http"
-import { Tag } from "@fider/models"
+import { Tag, TagGroup, TagStats, TagSubscription } from "@fider/models"
 
-export const createTag = async (name: string, color: string, isPublic: boolean): Promise<Result<Tag>> => {
-  return http.post<Tag>(`/api/v1/tags`, { name, color, isPublic }).then(http.event("tag", "create"))
+export const createTag = async (name: string, color: string, isPublic: boolean, group = "", parent = ""): Promise<Result<Tag>> => {
+  return http.post<Tag>(`/api/v1/tags`, { name, color, isPublic, group, parent }).then(http.event("tag", "create"))
 }
 
-export const updateTag = async (slug: string, name: string, color: string, isPublic: boolean): Promise<Result<Tag>> => {
-  return http.put<Tag>(`/api/v1/tags/${slug}`, { name, color, isPublic }).then(http.event("tag", "update"))
+export const updateTag = async (slug: string, name: string, color: string, isPublic: boolean, group = "", parent = ""): Promise<Result<Tag>> => {
+  return http.put<Tag>(`/api/v1/tags/${slug}`, { name, color, isPublic, group, parent }).then(http.event("tag", "update"))
 }
 
 export const deleteTag = async (slug: string): Promise<Result> => {
   return http.delete(`/api/v1/tags/${slug}`).then(http.event("tag", "delete"))
 }
 
+export const mergeTags = async (slug: string, into: string): Promise<Result<Tag>> => {
+  return http.post<Tag>(`/api/v1/tags/${slug}/merge`, { into }).then(http.event("tag", "merge"))
+}
+
+export const getTagStats = async (): Promise<Result<TagStats[]>> => {
+  return http.get<TagStats[]>(`/api/v1/tag-stats`)
+}
+
 export const assignTag = async (slug: string, postNumber: number): Promise<Result> => {
   return http.post(`/api/v1/posts/${postNumber}/tags/${slug}`).then(http.event("tag", "assign"))
 }
@@ -20,3 +28,31 @@ export const assignTag = async (slug: string, postNumber: number): Promise<Resul
 export const unassignTag = async (slug: string, postNumber: number): Promise<Result> => {
   return http.delete(`/api/v1/posts/${postNumber}/tags/${slug}`).then(http.event("tag", "unassign"))
 }
+
+export const listTagGroups = async (): Promise<Result<TagGroup[]>> => {
+  return http.get<TagGroup[]>(`/api/v1/tag-groups`)
+}
+
+export const createTagGroup = async (name: string, isSingleSelect: boolean): Promise<Result<TagGroup>> => {
+  return http.post<TagGroup>(`/api/v1/tag-groups`, { name, isSingleSelect }).then(http.event("tag-group", "create"))
+}
+
+export const updateTagGroup = async (slug: string, name: string, isSingleSelect: boolean): Promise<Result<TagGroup>> => {
+  return http.put<TagGroup>(`/api/v1/tag-groups/${slug}`, { name, isSingleSelect }).then(http.event("tag-group", "update"))
+}
+
+export const deleteTagGroup = async (slug: string): Promise<Result> => {
+  return http.delete(`/api/v1/tag-groups/${slug}`).then(http.event("tag-group", "delete"))
+}
+
+export const listTagSubscriptions = async (): Promise<Result<TagSubscription[]>> => {
+  return http.get<TagSubscription[]>(`/api/v1/tag-subscriptions`)
+}
+
+export const subscribeToTag = async (slug: string, channels: number, includeComments: boolean): Promise<Result> => {
+  return http.post(`/api/v1/tags/${slug}/subscription`, { channels, includeComments }).then(http.event("tag", "subscribe"))
+}
+
+export const unsubscribeFromTag = async (slug: string): Promise<Result> => {
+  return http.delete(`/api/v1/tags/${slug}/subscription`).then(http.event("tag", "unsubscribe"))
+}
@@ -1,11 +1,12 @@
 import { http, Result } from "@fider/services/http"
-import { UserRole, OAuthConfig, ImageUpload, EmailVerificationKind } from "@fider/models"
+import { UserRole, OAuthConfig, ImageUpload, EmailVerificationKind, StalePostRules } from "@fider/models"
 
 /** Request shape for updateTenantPrivacy (avoids importing page and circular dependency) */
 export interface UpdateTenantPrivacyRequest {
   isPrivate: boolean
   isFeedEnabled: boolean
   isModerationEnabled: boolean
+  isAnonymousPostingEnabled: boolean
 }
 
 export interface CheckAvailabilityResponse {
@@ -43,14 +44,42 @@ export const updateTenantSettings = async (request: UpdateTenantSettingsRequest)
   return await http.post("/_api/admin/settings/general", request)
 }
 
-export const updateTenantAdvancedSettings = async (customCSS: string, allowedSchemes: string): Promise<Result> => {
-  return await http.post("/_api/admin/settings/advanced", { customCSS, allowedSchemes })
+export const updateTenantAdvancedSettings = async (customCSS: string, allowedSchemes: string, issueWebhookSecret: string): Promise<Result> => {
+  return await http.post("/_api/admin/settings/advanced", { customCSS, allowedSchemes, issueWebhookSecret })
 }
 
 export const updateTenantPrivacy = async (request: UpdateTenantPrivacyRequest): Promise<Result> => {
   return await http.post("/_api/admin/settings/privacy", request)
 }
 
+export interface UpdateTenantVotingRequest {
+  voteBudget: number
+  isVoteImportanceEnabled: boolean
+  voteRoleWeights: { [role: string]: number }
+}
+
+export const updateTenantVoting = async (request: UpdateTenantVotingRequest): Promise<Result> => {
+  return await http.post("/_api/admin/settings/voting", request)
+}
+
+export interface UpdateTenantReactionRequest {
+  emoji: string
+  name: string
+  image?: ImageUpload
+}
+
+export const updateTenantReactions = async (reactions: UpdateTenantReactionRequest[]): Promise<Result> => {
+  return await http.post("/_api/admin/settings/reactions", { reactions })
+}
+
+export const getStalePostRules = async (): Promise<Result<StalePostRules>> => {
+  return await http.get<StalePostRules>("/_api/admin/settings/stale-posts")
+}
+
+export const updateStalePostRules = async (rules: StalePostRules): Promise<Result> => {
+  return await http.post("/_api/admin/settings/stale-posts", rules)
+}
+
 export const updateTenantEmailAuthAllowed = async (isEmailAuthAllowed: boolean): Promise<Result> => {
   return await http.post("/_api/admin/settings/emailauth", {
     isEmailAuthAllowed,